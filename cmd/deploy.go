@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/config"
+	"github.com/yourusername/context.io/cli/internal/deploy"
+	"github.com/yourusername/context.io/cli/internal/diff"
+	"github.com/yourusername/context.io/cli/internal/plan"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var deployCmd = &cobra.Command{
+	Use:   "deploy [dir]",
+	Short: "Pass the TraceKit configuration to your deployments",
+	Long: `The .env written by 'tracekit init' stays out of container images, so
+deployed services don't see the TraceKit variables. This command finds the
+Dockerfile, docker compose files, Kubernetes manifests, Helm charts, Procfile,
+fly.toml or vercel.json for a project and wires the variables in:
+
+  compose      env_file entries loading the project's .env
+  Kubernetes   a Secret (git-ignored) plus envFrom in each container
+  Helm         tracekit values and a Secret template
+  Dockerfile   .env added to .dockerignore
+  Heroku, Fly.io, Vercel   commands to set the variables on the platform
+
+A diff of every file is shown before anything is written.
+
+Example:
+  tracekit deploy
+  tracekit deploy services/api --yes`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDeploy,
+}
+
+func init() {
+	rootCmd.AddCommand(deployCmd)
+	deployCmd.Flags().BoolP("yes", "y", false, "Apply the changes without asking")
+}
+
+func runDeploy(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	cfg, err := config.ReadDir(dir)
+	if err != nil {
+		ui.PrintError("No TraceKit configuration found in " + filepath.Join(dir, ".env"))
+		ui.PrintMuted("   Run 'tracekit init' to set up your project")
+		return nil
+	}
+
+	if !wireDeployment(dir, cfg, yes) {
+		ui.PrintInfo("No Dockerfile, compose file, Kubernetes manifest, Helm chart, Procfile, fly.toml or vercel.json found")
+	}
+	return nil
+}
+
+// wireDeployment finds the deployment targets of the project in dir, shows a
+// diff of the wiring and applies it once confirmed. It reports whether any
+// target was found.
+func wireDeployment(dir string, cfg *config.Config, yes bool) bool {
+	targets := deploy.Detect(dir)
+	if len(targets) == 0 {
+		return false
+	}
+
+	ui.PrintSection(fmt.Sprintf("🚢 Deployment: %s", cfg.ServiceName))
+	fmt.Println()
+
+	cwd, _ := os.Getwd()
+	for _, t := range targets {
+		label := relativeTo(cwd, t.Path)
+		if len(t.Workloads) > 0 {
+			label += " (" + strings.Join(t.Workloads, ", ") + ")"
+		}
+		ui.PrintBullet(fmt.Sprintf("%s: %s", t.Kind, label))
+	}
+	fmt.Println()
+
+	plan, err := deploy.NewPlan(dir, cfg.ServiceName, targets, cfg.EnvVars())
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Could not plan deployment changes: %v", err))
+		fmt.Println()
+		return true
+	}
+
+	for _, c := range plan.Changes {
+		path := relativeTo(cwd, c.Path)
+		ui.PrintInfo(fmt.Sprintf("%s: %s", path, c.Description))
+		ui.PrintDiff(diff.Unified(path, c.Before, c.After))
+		fmt.Println()
+	}
+
+	if len(plan.Changes) == 0 {
+		ui.PrintSuccess("Deployment files already load the TraceKit configuration")
+		fmt.Println()
+	} else if confirmDeployChanges(len(plan.Changes), yes) {
+		if err := plan.Apply(); err != nil {
+			ui.PrintWarning(fmt.Sprintf("Failed to apply deployment changes: %v", err))
+		} else {
+			ui.PrintSuccess(updatedMessage(fmt.Sprintf("%d files", len(plan.Changes))))
+		}
+		fmt.Println()
+	} else {
+		ui.PrintInfo("No files changed")
+		ui.PrintMuted("   Run 'tracekit deploy' later to apply these changes")
+		fmt.Println()
+	}
+
+	if len(plan.Instructions) > 0 {
+		ui.PrintInfo("Still to do:")
+		for _, line := range plan.Instructions {
+			ui.PrintMuted("   " + line)
+		}
+		fmt.Println()
+	}
+	return true
+}
+
+func confirmDeployChanges(n int, yes bool) bool {
+	return confirmAction(fmt.Sprintf("Apply %d changes?", n), yes)
+}
+
+// confirmAction asks a yes/no question (defaulting to no) unless yes is set
+// or this is a dry run
+func confirmAction(question string, yes bool) bool {
+	if yes || plan.DryRun() {
+		return true
+	}
+	ui.PrintPrompt(question + " (y/N):")
+
+	var response string
+	fmt.Scanln(&response)
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes"
+}
+
+// relativeTo shortens path for display
+func relativeTo(base, path string) string {
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
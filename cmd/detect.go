@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/detector"
+	"github.com/yourusername/context.io/cli/internal/sdk"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var detectCmd = &cobra.Command{
+	Use:   "detect [dir]",
+	Short: "Show how TraceKit detects your framework",
+	Long: `List every framework that may apply to a directory, ranked by
+confidence, with the evidence used for each (manifest entries, lockfile
+versions and framework-specific files).
+
+Teams can describe their own frameworks (e.g. internal wrappers around Gin or
+Laravel) in .tracekit/detectors.yaml or in detectors.yaml under the user
+config directory; matching rules are listed alongside the built-ins.
+
+The top candidate is what 'tracekit init' and 'tracekit status' use. If
+it is wrong, pass --framework to those commands to override detection.
+
+Example:
+  tracekit detect
+  tracekit detect services/api
+  tracekit detect -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDetect,
+}
+
+func init() {
+	rootCmd.AddCommand(detectCmd)
+	detectCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+}
+
+// detectResult is the JSON shape of a candidate
+type detectResult struct {
+	Name           string              `json:"name"`
+	Type           string              `json:"type"`
+	Version        string              `json:"version,omitempty"`
+	Constraint     string              `json:"constraint,omitempty"`
+	PackageManager string              `json:"package_manager,omitempty"`
+	MinVersion     string              `json:"min_version,omitempty"`
+	Unsupported    bool                `json:"unsupported,omitempty"`
+	SDK            string              `json:"sdk,omitempty"`
+	Detector       string              `json:"detector"`
+	Priority       int                 `json:"priority,omitempty"`
+	Confidence     int                 `json:"confidence"`
+	Evidence       []detector.Evidence `json:"evidence"`
+	Warnings       []string            `json:"warnings,omitempty"`
+}
+
+func runDetect(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString("output")
+	if err := validateOutputFormat(output, "table", "json"); err != nil {
+		return err
+	}
+	// Minimum framework versions come from the SDK catalog
+	loadSDKCatalog(cmd, sdk.CatalogTTL)
+
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	candidates, err := detector.DetectCandidates(dir)
+	if err != nil {
+		return fmt.Errorf("failed to detect framework: %w", err)
+	}
+
+	if output == "json" {
+		results := make([]detectResult, 0, len(candidates))
+		for _, c := range candidates {
+			results = append(results, detectResult{
+				Name:           c.Framework.Name,
+				Type:           c.Framework.Type,
+				Version:        c.Framework.Version,
+				Constraint:     c.Framework.Constraint,
+				PackageManager: c.Framework.PackageManager,
+				MinVersion:     c.Framework.MinVersion,
+				Unsupported:    c.Framework.Unsupported,
+				SDK:            c.Framework.SDK,
+				Detector:       c.Detector,
+				Priority:       c.Priority,
+				Confidence:     c.Confidence,
+				Evidence:       c.Evidence,
+				Warnings:       c.Warnings,
+			})
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	}
+
+	ui.PrintSection("🔍 Framework Detection")
+	fmt.Println()
+
+	for _, file := range detector.RuleFiles(dir) {
+		ui.PrintMuted("   Custom rules: " + file)
+	}
+	if len(detector.RuleFiles(dir)) > 0 {
+		fmt.Println()
+	}
+
+	if len(candidates) == 0 {
+		ui.PrintWarning("No framework detected")
+		ui.PrintMuted("   No supported manifest found in " + dir)
+		return nil
+	}
+
+	for i, c := range candidates {
+		line := fmt.Sprintf("%s (%s) — %s", frameworkLabel(c.Framework), c.Framework.Type, confidenceLabel(c.Confidence))
+		if c.Priority != 0 {
+			line += fmt.Sprintf(", priority %d", c.Priority)
+		}
+		if i == 0 {
+			ui.PrintSuccess(line + " [selected]")
+		} else {
+			ui.PrintBullet(line)
+		}
+		for _, e := range c.Evidence {
+			ui.PrintMuted(fmt.Sprintf("     %s: %s", e.File, e.Detail))
+		}
+		for _, w := range c.Warnings {
+			ui.PrintWarning(w)
+		}
+		ui.PrintSubtle("     detector: " + c.Detector)
+		if c.Framework.SDK != "" {
+			ui.PrintMuted("     recommends SDK: " + c.Framework.SDK)
+		}
+		printUnsupportedWarning(c.Framework)
+		fmt.Println()
+	}
+
+	ui.PrintMuted("   Override with: tracekit init --framework <name>")
+	return nil
+}
+
+// detectFramework runs detection in dir unless --framework names one
+// explicitly, and reports the confidence of the result (100 for overrides)
+func detectFramework(cmd *cobra.Command, dir string) (*detector.Framework, int, error) {
+	if name, _ := cmd.Flags().GetString("framework"); name != "" {
+		framework, err := detector.Override(dir, name)
+		return framework, 100, err
+	}
+
+	candidates, err := detector.DetectCandidates(dir)
+	if err != nil {
+		return nil, 0, err
+	}
+	// On stderr, as some callers print JSON
+	for _, c := range candidates {
+		for _, w := range c.Warnings {
+			fmt.Fprintf(os.Stderr, "⚠️  %s\n", w)
+		}
+	}
+	if len(candidates) == 0 {
+		return &detector.Framework{Name: "generic", Type: "unknown"}, 0, nil
+	}
+	return candidates[0].Framework, candidates[0].Confidence, nil
+}
+
+// confidenceLabel formats a detection confidence for display
+func confidenceLabel(confidence int) string {
+	return strconv.Itoa(confidence) + "% confidence"
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/devserver"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var devServerCmd = &cobra.Command{
+	Use:   "dev-server",
+	Short: "Run a local stand-in for the TraceKit API",
+	Long: `Run a local, in-memory stand-in for the TraceKit API for offline
+development and integration tests.
+
+The dev server emulates:
+  POST /v1/traces                 Trace ingest (TraceKit JSON and OTLP/HTTP JSON)
+  GET  /v1/traces/search, /{id}   Trace queries and the live stream used by 'tail'
+  /v1/integrate/*                 Account registration, verification and status
+  /v1/webhooks                    Webhook create, list and delete
+  /api/health-checks              Health check create and list, plus heartbeats
+
+Inspect what was received at http://localhost:8081/ or as JSON at
+/_dev/dump, and clear everything with POST /_dev/reset.
+
+Point any command at it with --api-url (or --dev on the default port).
+
+Example:
+  tracekit dev-server
+  tracekit dev-server --port 9000 --dump-file received.json
+  tracekit test --api-url http://localhost:8081`,
+	RunE: runDevServer,
+}
+
+func init() {
+	rootCmd.AddCommand(devServerCmd)
+	devServerCmd.Flags().String("host", "127.0.0.1", "Address to listen on")
+	devServerCmd.Flags().Int("port", 8081, "Port to listen on")
+	devServerCmd.Flags().String("code", devserver.DefaultVerificationCode, "Verification code accepted by init and login")
+	devServerCmd.Flags().String("dump-file", "", "Write everything received to this JSON file on shutdown")
+	devServerCmd.Flags().Bool("quiet", false, "Don't log each request")
+}
+
+func runDevServer(cmd *cobra.Command, args []string) error {
+	host, _ := cmd.Flags().GetString("host")
+	port, _ := cmd.Flags().GetInt("port")
+	code, _ := cmd.Flags().GetString("code")
+	dumpFile, _ := cmd.Flags().GetString("dump-file")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	baseURL := "http://" + addr
+
+	logf := func(format string, args ...interface{}) {
+		if !quiet {
+			ui.PrintMuted(time.Now().Format("15:04:05") + "  " + fmt.Sprintf(format, args...))
+		}
+	}
+
+	server := devserver.New(devserver.Options{
+		VerificationCode: code,
+		BaseURL:          baseURL,
+		Logf:             logf,
+	})
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	httpServer := &http.Server{Handler: server.Handler()}
+
+	ui.PrintSection("🧪 TraceKit Dev Server")
+	ui.PrintSuccess("Listening on " + baseURL)
+	fmt.Println()
+	ui.PrintKeyValue("Ingest", baseURL+"/v1/traces")
+	ui.PrintKeyValue("Inspect", baseURL+"/")
+	ui.PrintKeyValue("JSON dump", baseURL+"/_dev/dump")
+	ui.PrintKeyValue("Verification code", code)
+	fmt.Println()
+	ui.PrintMuted("   Point the CLI at it:  tracekit test --api-url " + baseURL)
+	ui.PrintMuted("   Point your SDK at it: TRACEKIT_ENDPOINT=" + baseURL)
+	ui.PrintMuted("   Press Ctrl+C to stop")
+	fmt.Println()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		// Open tail streams don't finish on their own
+		httpServer.Close()
+	}
+
+	fmt.Println()
+	if dumpFile != "" {
+		data, err := json.MarshalIndent(server.Snapshot(), "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode dump: %w", err)
+		}
+		if err := os.WriteFile(dumpFile, data, 0644); err != nil {
+			return fmt.Errorf("failed to write dump: %w", err)
+		}
+		ui.PrintSuccess("Wrote received data to " + dumpFile)
+	}
+	ui.PrintMuted("Dev server stopped")
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/yourusername/context.io/cli/internal/plan"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+// planOutput is where a JSON plan is written. Everything else goes to
+// stderr so stdout holds only the plan.
+var planOutput = os.Stdout
+
+// startDryRun switches to recording changes when --dry-run is given
+func startDryRun() {
+	flag := rootCmd.PersistentFlags().Lookup("dry-run")
+	if flag == nil || !flag.Changed {
+		return
+	}
+	plan.StartDryRun()
+	if flag.Value.String() == "json" {
+		planOutput = os.Stdout
+		os.Stdout = os.Stderr
+		color.Output = os.Stderr
+	}
+}
+
+// printPlan prints the changes recorded under --dry-run
+func printPlan() error {
+	if !plan.DryRun() {
+		return nil
+	}
+
+	format := rootCmd.PersistentFlags().Lookup("dry-run").Value.String()
+	if format == "json" {
+		actions := plan.Actions()
+		if actions == nil {
+			actions = []plan.Action{}
+		}
+		encoder := json.NewEncoder(planOutput)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(map[string][]plan.Action{"actions": actions})
+	}
+
+	fmt.Println()
+	ui.PrintSection("📝 Dry Run Plan")
+	fmt.Println()
+	if len(plan.Actions()) == 0 {
+		ui.PrintInfo("No changes would be made")
+		fmt.Println()
+		return nil
+	}
+	for i, action := range plan.Actions() {
+		printAction(i+1, action)
+	}
+	ui.PrintSubtle("Nothing was changed. Run again without --dry-run to apply.")
+	fmt.Println()
+	return nil
+}
+
+func printAction(n int, action plan.Action) {
+	prefix := fmt.Sprintf("%d. ", n)
+	switch action.Type {
+	case "command":
+		ui.PrintInfo(prefix + "Run: " + action.Command)
+		if action.Dir != "" {
+			ui.PrintSubtle("   in " + action.Dir)
+		}
+	case "write":
+		ui.PrintInfo(prefix + "Write " + action.Path)
+		if action.Diff != "" {
+			ui.PrintDiff(action.Diff)
+		}
+	case "remove":
+		ui.PrintInfo(prefix + "Remove " + action.Path)
+	case "request":
+		ui.PrintInfo(prefix + action.Method + " " + action.URL)
+		if action.Body != nil {
+			var body strings.Builder
+			encoder := json.NewEncoder(&body)
+			encoder.SetIndent("   ", "  ")
+			encoder.SetEscapeHTML(false)
+			encoder.Encode(action.Body)
+			fmt.Print("   " + body.String())
+		}
+	}
+	if action.Reason != "" {
+		ui.PrintSubtle("   " + strings.TrimSpace(action.Reason))
+	}
+	fmt.Println()
+}
+
+// updatedMessage reports files written, or to be written under --dry-run
+func updatedMessage(what string) string {
+	if plan.DryRun() {
+		return "Would update " + what
+	}
+	return "Updated " + what
+}
+
+// createdMessage reports a file created, or to be created under --dry-run
+func createdMessage(path string) string {
+	if plan.DryRun() {
+		return "Would create " + path
+	}
+	return "Created " + path
+}
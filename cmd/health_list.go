@@ -48,6 +48,7 @@ func runHealthList(cmd *cobra.Command, args []string) error {
 		ui.PrintMuted("   Run 'tracekit init' to set up your project")
 		return nil
 	}
+	applyAPIURLFlag(cmd, cfg)
 
 	ui.PrintSuccess("Configuration loaded")
 	ui.PrintMuted(fmt.Sprintf("   Service: %s", cfg.ServiceName))
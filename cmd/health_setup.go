@@ -12,6 +12,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/yourusername/context.io/cli/internal/config"
+	"github.com/yourusername/context.io/cli/internal/plan"
+	"github.com/yourusername/context.io/cli/internal/state"
 	"github.com/yourusername/context.io/cli/internal/ui"
 )
 
@@ -49,6 +51,7 @@ func runHealthSetup(cmd *cobra.Command, args []string) error {
 		ui.PrintMuted("   Run 'tracekit init' to set up your project")
 		return nil
 	}
+	applyAPIURLFlag(cmd, cfg)
 
 	ui.PrintSuccess("Configuration loaded")
 	ui.PrintMuted(fmt.Sprintf("   Service: %s", cfg.ServiceName))
@@ -151,6 +154,10 @@ func setupPullBasedHealthCheck(cfg *config.Config) error {
 
 	// Determine API URL
 	apiURL := strings.Replace(cfg.Endpoint, "/v1/traces", "", 1)
+	if plan.Request("create health check", "POST", apiURL+"/api/health-checks", bodyBytes) {
+		ui.PrintInfo("Dry run: health check not created")
+		return nil
+	}
 	req, err := http.NewRequest("POST", apiURL+"/api/health-checks", bytes.NewReader(bodyBytes))
 	if err != nil {
 		ui.PrintError(fmt.Sprintf("Failed to create request: %v", err))
@@ -179,6 +186,8 @@ func setupPullBasedHealthCheck(cfg *config.Config) error {
 		ui.PrintError(fmt.Sprintf("Failed to create health check: %s", errorMsg))
 		return nil
 	}
+	id, _ := result["id"].(string)
+	state.RecordResource("health check", id, checkName, "")
 
 	ui.PrintSuccess("Health check created!")
 	fmt.Println()
@@ -2,11 +2,8 @@ package cmd
 
 import (
 	"bufio"
-	"bytes"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -16,10 +13,13 @@ import (
 	"github.com/yourusername/context.io/cli/internal/client"
 	"github.com/yourusername/context.io/cli/internal/config"
 	"github.com/yourusername/context.io/cli/internal/detector"
+	"github.com/yourusername/context.io/cli/internal/plan"
 	"github.com/yourusername/context.io/cli/internal/sdk"
+	"github.com/yourusername/context.io/cli/internal/state"
 	"github.com/yourusername/context.io/cli/internal/trace"
 	"github.com/yourusername/context.io/cli/internal/ui"
 	"github.com/yourusername/context.io/cli/internal/utils"
+	"github.com/yourusername/context.io/cli/internal/webhook"
 )
 
 const CLIVersion = "1.0.0"
@@ -31,21 +31,42 @@ var initCmd = &cobra.Command{
 and automatically configuring your project for monitoring.
 
 This command will:
-  1. Detect your framework (gemvc, Laravel, Express, etc.)
+  1. Detect your framework (gemvc, Laravel, Express, etc.), including
+     every service in a monorepo
   2. Create a TraceKit account (or use existing)
   3. Generate an API key
-  4. Create .env file with configuration
-  5. Provide setup instructions
+  4. Create a .env file with configuration for each service
+  5. For frontend apps, create a public ingest key and offer to inject the
+     browser SDK loader (see 'tracekit rum')
+  6. Offer to pass it to Docker Compose, Kubernetes, Helm or your hosting
+     platform (see 'tracekit deploy')
+  7. Provide setup instructions
+
+If Datadog, New Relic, Sentry or OpenTelemetry is already set up, init
+lists what it found and offers to run TraceKit side by side or to migrate,
+pointing the existing OpenTelemetry exporter at TraceKit.
+
+Running init again is safe. A working API key in .env is reused (--refresh
+signs in again for a new one), and SDKs, webhooks and health checks that
+are already set up are skipped. If email verification fails, the code
+stays valid for 15 minutes: run init again to retry it, or enter 'r' at
+the prompt to send a new one.
 
 Example:
-  tracekit init`,
+  tracekit init
+  tracekit init --refresh
+  tracekit init --existing migrate`,
 	RunE: runInit,
 }
 
 func init() {
 	rootCmd.AddCommand(initCmd)
 	initCmd.Flags().String("email", "", "Your email address")
-	initCmd.Flags().String("api-url", "", "API base URL (default: https://app.tracekit.dev)")
+	initCmd.Flags().Bool("all-services", false, "Configure every service found in the repository without prompting")
+	initCmd.Flags().Bool("no-scan", false, "Only configure the current directory (skip monorepo scan)")
+	initCmd.Flags().String("framework", "", "Use this framework instead of detecting one (see 'tracekit detect')")
+	initCmd.Flags().String("existing", "", "With Datadog, New Relic, Sentry or OpenTelemetry already set up: side-by-side or migrate")
+	initCmd.Flags().Bool("refresh", false, "Sign in again for a new API key instead of reusing the one in .env")
 	initCmd.Flags().Bool("dev", false, "")
 	initCmd.Flags().MarkHidden("dev")
 }
@@ -55,10 +76,14 @@ func runInit(cmd *cobra.Command, args []string) error {
 	ui.PrintBanner()
 	fmt.Println()
 
+	// SDK packages, setup snippets and minimum framework versions
+	loadSDKCatalog(cmd, sdk.CatalogTTL)
+
 	// Step 1: Detect framework
 	ui.PrintSection("🔍 Framework Detection")
 	fmt.Println()
-	framework, err := detector.Detect()
+	cwd, _ := os.Getwd()
+	framework, _, err := detectFramework(cmd, cwd)
 	if err != nil {
 		return fmt.Errorf("failed to detect framework: %w", err)
 	}
@@ -67,25 +92,37 @@ func runInit(cmd *cobra.Command, args []string) error {
 		ui.PrintWarning("No framework detected")
 		ui.PrintMuted("   Continuing with generic setup...")
 	} else {
-		ui.PrintSuccess(fmt.Sprintf("Detected: %s (%s)", framework.Name, framework.Type))
+		details := framework.Type
+		if framework.PackageManager != "" {
+			details += ", " + framework.PackageManager
+		}
+		ui.PrintSuccess(fmt.Sprintf("Detected: %s (%s)", frameworkLabel(framework), details))
+		printUnsupportedWarning(framework)
 	}
 	fmt.Println()
 
-	// Step 2: Get email
-	email, _ := cmd.Flags().GetString("email")
-	if email == "" {
-		email, err = promptEmail()
+	// Get service name from directory (auto-detect, no prompt)
+	serviceName := detector.ServiceName(filepath.Base(cwd))
+
+	// Look for other services in a monorepo; an explicit --framework only
+	// describes the current directory
+	services := []detector.Service{{Path: ".", Dir: cwd, Name: serviceName, Framework: framework}}
+	noScan, _ := cmd.Flags().GetBool("no-scan")
+	if !noScan && !cmd.Flags().Changed("framework") {
+		allServices, _ := cmd.Flags().GetBool("all-services")
+		services = selectServices(cwd, services, allServices)
+	}
+
+	// Look for observability tools already in use, to avoid double instrumentation
+	existingMode, _ := cmd.Flags().GetString("existing")
+	vendorsFound := findVendors(services)
+	if len(vendorsFound) > 0 {
+		existingMode, err = promptExistingTools(vendorsFound, existingMode, len(services) > 1)
 		if err != nil {
 			return err
 		}
 	}
 
-	// Get service name from directory (auto-detect, no prompt)
-	cwd, _ := os.Getwd()
-	serviceName := filepath.Base(cwd)
-	// Sanitize service name: replace spaces with dashes, lowercase
-	serviceName = strings.ToLower(strings.ReplaceAll(serviceName, " ", "-"))
-
 	// Determine API URL
 	apiURL, _ := cmd.Flags().GetString("api-url")
 	useDev, _ := cmd.Flags().GetBool("dev")
@@ -95,70 +132,95 @@ func runInit(cmd *cobra.Command, args []string) error {
 		fmt.Println()
 	}
 
-	// Step 3: Register account
-	ui.PrintSection("📧 Account Creation")
-	fmt.Println()
+	// Step 2: Reuse the configuration of an earlier run, or create an account
 	apiClient := client.NewClient(apiURL)
+	refresh, _ := cmd.Flags().GetBool("refresh")
+	existing := reusableConfig(apiClient, refresh)
+
+	var verifyResp *client.VerifyResponse
+	if existing != nil {
+		apiClient = client.NewClient(existing.GetAPIBase())
+		verifyResp = &client.VerifyResponse{
+			APIKey:       existing.APIKey,
+			ServiceName:  existing.ServiceName,
+			DashboardURL: apiClient.BaseURL,
+		}
+	} else {
+		// Step 3: Register account and verify the email address
+		ui.PrintSection("📧 Account Creation")
+		fmt.Println()
 
-	registerReq := &client.RegisterRequest{
-		Email:            email,
-		OrganizationName: "", // Leave empty to let backend generate a fancy random name
-		ServiceName:      serviceName,
-		Source:           framework.Name,
-		SourceMetadata: map[string]interface{}{
-			"cli_version":       CLIVersion,
-			"framework_version": framework.Version,
-			"platform":          runtime.GOOS + "_" + runtime.GOARCH,
-		},
-	}
-
-	registerResp, err := apiClient.Register(registerReq)
-	if err != nil {
-		return fmt.Errorf("registration failed: %w", err)
-	}
-
-	ui.PrintSuccess(fmt.Sprintf("Verification code sent to %s", email))
-	fmt.Println()
+		email, _ := cmd.Flags().GetString("email")
+		registerReq := &client.RegisterRequest{
+			Email:            email,
+			OrganizationName: "", // Leave empty to let backend generate a fancy random name
+			ServiceName:      serviceName,
+			Source:           framework.Name,
+			SourceMetadata: map[string]interface{}{
+				"cli_version":       CLIVersion,
+				"framework_version": framework.Version,
+				"platform":          runtime.GOOS + "_" + runtime.GOARCH,
+			},
+		}
 
-	// Step 4: Get verification code
-	ui.PrintSection("🔑 Email Verification")
-	fmt.Println()
-	ui.PrintPrompt("Enter 6-digit code:")
-	var code string
-	fmt.Scanln(&code)
-	fmt.Println()
+		verifyResp, err = verifyEmail(apiClient, registerReq, "tracekit init")
+		if err != nil {
+			return err
+		}
+		// Only a key init created goes in the manifest, not a reused one
+		if !plan.DryRun() {
+			state.RecordResource("api key", "", verifyResp.ServiceName, "")
+		}
 
-	// Step 5: Verify and get API key
-	ui.PrintInfo("Verifying...")
-	verifyReq := &client.VerifyRequest{
-		SessionID: registerResp.SessionID,
-		Code:      code,
+		ui.PrintSuccess("Account created!")
+		fmt.Println()
 	}
 
-	verifyResp, err := apiClient.Verify(verifyReq)
-	if err != nil {
-		return fmt.Errorf("verification failed: %w", err)
+	// Step 4: Save TraceKit config to each service's .env
+	multiService := len(services) > 1
+	baseCfg := config.Config{
+		APIKey:                verifyResp.APIKey,
+		Endpoint:              apiClient.BaseURL, // Store base URL only
+		Enabled:               "true",
+		CodeMonitoringEnabled: "true",
 	}
 
-	ui.PrintSuccess("Account created!")
-	fmt.Println()
+	var configured []detector.Service
+	var configs []*config.Config
+	for _, svc := range services {
+		svcCfg := baseCfg
+		svcCfg.ServiceName = svc.Name
+		svcCfg.OTLPExport = migratesToOTLP(vendorsFound, existingMode, svc)
+		envPath := filepath.Join(svc.Path, ".env")
+
+		// Already configured with this key by an earlier run
+		if current, err := config.ReadDir(svc.Dir); err == nil && current.APIKey == svcCfg.APIKey && current.OTLPExport == svcCfg.OTLPExport {
+			ui.PrintSuccess("Using the configuration in " + envPath)
+			configured = append(configured, svc)
+			configs = append(configs, current)
+			continue
+		}
 
-	// Step 6: Save TraceKit config to .env
-	cfg := &config.Config{
-		APIKey:                 verifyResp.APIKey,
-		Endpoint:               apiClient.BaseURL, // Store base URL only
-		ServiceName:            serviceName,
-		Enabled:                "true",
-		CodeMonitoringEnabled:  "true",
+		if err := config.SaveDir(svc.Dir, &svcCfg); err != nil {
+			ui.PrintWarning(fmt.Sprintf("Failed to save %s: %v", envPath, err))
+			fmt.Println()
+			ui.PrintMuted("📝 Manual setup required:")
+			ui.PrintMuted(fmt.Sprintf("   Add to %s: TRACEKIT_API_KEY=%s", envPath, verifyResp.APIKey))
+			fmt.Println()
+			continue
+		}
+
+		if multiService {
+			ui.PrintSuccess(fmt.Sprintf("API key saved to %s (service: %s)", envPath, svc.Name))
+		} else {
+			ui.PrintSuccess("API key saved to " + envPath)
+		}
+		configured = append(configured, svc)
+		configs = append(configs, &svcCfg)
 	}
-	if err := config.Save(cfg); err != nil {
-		ui.PrintWarning(fmt.Sprintf("Failed to save .env file: %v", err))
-		fmt.Println()
-		ui.PrintMuted("📝 Manual setup required:")
-		ui.PrintMuted(fmt.Sprintf("   Add to your .env file: TRACEKIT_API_KEY=%s", verifyResp.APIKey))
-		fmt.Println()
 
-		// Show summary and exit if .env save failed
+	if len(configured) == 0 {
+		// Show summary and exit if no .env could be saved
 		ui.PrintDivider()
 		fmt.Println()
 		summary := fmt.Sprintf("Dashboard:  %s\nAPI Key:    %s\nService:    %s\nPlan:       Hacker (Free - 200k traces/month)",
@@ -168,111 +230,180 @@ func runInit(cmd *cobra.Command, args []string) error {
 		ui.PrintSummaryBox("⚠️  Setup Incomplete", summary)
 		return nil
 	}
-
-	ui.PrintSuccess("API key saved to .env")
 	fmt.Println()
 
-	// Step 7: Send test trace automatically
+	// Webhooks, health checks and status use the first configured service
+	cfg := configs[0]
+
+	// Step 5: Send test trace automatically
 	ui.PrintSection("🧪 Sending Test Trace")
 	fmt.Println()
 	ui.PrintInfo("Verifying your setup...")
 
-	if err := sendTestTraceInternal(cfg); err != nil {
-		ui.PrintWarning(fmt.Sprintf("Test trace failed: %v", err))
-		ui.PrintMuted("   Don't worry, you can run 'tracekit test' later")
-	} else {
-		ui.PrintSuccess("Test trace sent successfully!")
+	for _, svcCfg := range configs {
+		label := "Test trace"
+		if multiService {
+			label = fmt.Sprintf("Test trace for %s", svcCfg.ServiceName)
+		}
+
+		if err := sendTestTraceInternal(svcCfg); err != nil {
+			ui.PrintWarning(fmt.Sprintf("%s failed: %v", label, err))
+			ui.PrintMuted("   Don't worry, you can run 'tracekit test' later")
+		} else {
+			ui.PrintSuccess(label + " sent successfully!")
+		}
 	}
 	fmt.Println()
 
-	// Step 8: Show status automatically
+	// Step 6: Show status automatically
 	ui.PrintSection("📊 Integration Status")
 	fmt.Println()
 
-	if err := showStatusInternal(cfg, apiClient, useDev); err != nil {
+	if plan.DryRun() {
+		ui.PrintMuted("   Skipped in a dry run")
+	} else if err := showStatusInternal(cfg, apiClient, useDev); err != nil {
 		ui.PrintWarning(fmt.Sprintf("Could not fetch status: %v", err))
 	}
 	fmt.Println()
 
-	// Step 9: Prompt for SDK installation
-	if err := promptSDKInstall(framework); err != nil {
-		ui.PrintWarning(fmt.Sprintf("SDK installation skipped: %v", err))
+	// Step 7: Prompt for SDK installation
+	for _, svc := range configured {
+		if migratesToOTLP(vendorsFound, existingMode, svc) {
+			ui.PrintInfo(fmt.Sprintf("%s: existing OpenTelemetry SDK now exports to TraceKit (see .env)", svc.Name))
+			fmt.Println()
+			continue
+		}
+		if err := promptSDKInstall(svc, multiService); err != nil {
+			ui.PrintWarning(fmt.Sprintf("SDK installation skipped: %v", err))
+		}
+		fmt.Println()
+	}
+
+	// Frontend apps get a public ingest key and the browser SDK loader
+	for i, svc := range configured {
+		if !svc.Framework.Browser {
+			continue
+		}
+		if err := setupBrowserMonitoring(svc.Dir, configs[i], false); err != nil {
+			ui.PrintWarning(fmt.Sprintf("Browser monitoring setup skipped: %v", err))
+		}
+		fmt.Println()
+	}
+
+	// Pass the configuration to Docker, Kubernetes and hosting platforms
+	for i, svc := range configured {
+		wireDeployment(svc.Dir, configs[i], false)
 	}
-	fmt.Println()
 
-	// Step 10: Prompt for webhook setup
+	// Step 8: Prompt for webhook setup
 	if err := promptWebhookSetup(cfg, apiClient, useDev); err != nil {
 		ui.PrintWarning(fmt.Sprintf("Webhook setup skipped: %v", err))
 	}
 	fmt.Println()
 
-	// Step 11: Prompt for health check setup
+	// Step 9: Prompt for health check setup
 	if err := promptHealthCheckSetup(cfg, apiClient); err != nil {
 		ui.PrintWarning(fmt.Sprintf("Health check setup skipped: %v", err))
 	}
 	fmt.Println()
 
-	// Step 11: Show final summary and next steps
+	// Step 10: Show final summary and next steps
 	ui.PrintDivider()
 	fmt.Println()
 
-	summary := fmt.Sprintf("Dashboard:  %s\nAPI Key:    %s\nService:    %s\nPlan:       Hacker (Free - 200k traces/month)",
+	serviceSummary := "Service:    " + verifyResp.ServiceName
+	if multiService {
+		var names []string
+		for _, svcCfg := range configs {
+			names = append(names, svcCfg.ServiceName)
+		}
+		serviceSummary = "Services:   " + strings.Join(names, ", ")
+	}
+
+	summary := fmt.Sprintf("Dashboard:  %s\nAPI Key:    %s\n%s\nPlan:       Hacker (Free - 200k traces/month)",
 		verifyResp.DashboardURL,
 		utils.MaskAPIKey(verifyResp.APIKey),
-		verifyResp.ServiceName)
+		serviceSummary)
 
 	ui.PrintSummaryBox("🎉 Setup Complete!", summary)
 	fmt.Println()
 
-	// Next steps based on framework
-	var steps []string
-	switch framework.Type {
-	case "go":
-		steps = []string{
-			"Install SDK: go get github.com/yourusername/context.io/sdk",
-			"Import in your code: import \"github.com/yourusername/context.io/sdk\"",
-			"Initialize: tracekit.Init()",
-			"Visit " + verifyResp.DashboardURL + " to view your test trace",
-		}
-	case "php":
-		steps = []string{
-			"Install SDK: composer require tracekit/sdk",
-			"Require in your code: require 'vendor/autoload.php';",
-			"Initialize: TraceKit\\SDK::init();",
-			"Visit " + verifyResp.DashboardURL + " to view your test trace",
-		}
-	case "node":
-		steps = []string{
-			"Install SDK: npm install tracekit-sdk",
-			"Import in your code: const tracekit = require('tracekit-sdk');",
-			"Initialize: tracekit.init();",
-			"Visit " + verifyResp.DashboardURL + " to view your test trace",
-		}
-	case "python":
-		steps = []string{
-			"Install SDK: pip install tracekit-sdk",
-			"Import in your code: import tracekit",
-			"Initialize: tracekit.init()",
-			"Visit " + verifyResp.DashboardURL + " to view your test trace",
-		}
-	default:
-		steps = []string{
-			"Install the appropriate TraceKit SDK for your language",
-			"Initialize with your API key",
-			"Visit " + verifyResp.DashboardURL + " to view your test trace",
+	// Next steps for the SDK recommended for the framework
+	steps := []string{
+		"Install the appropriate TraceKit SDK for your language",
+		"Initialize with your API key",
+	}
+	if recommended := recommendedSDK(framework); recommended != nil {
+		target := sdkTarget(detector.Service{Dir: cwd, Framework: framework})
+		steps = sdk.SetupSteps(*recommended, framework.Name)
+		if project := (&sdkProject{framework: framework, target: target}); len(project.installed()) == 0 {
+			steps = append([]string{"Install SDK: " + sdk.InstallCommand(*recommended, target)}, steps...)
 		}
 	}
+	steps = append(steps, "Visit "+verifyResp.DashboardURL+" to view your test trace")
 
 	ui.PrintNextSteps(steps)
 
 	return nil
 }
 
+// reusableConfig returns the configuration an earlier run saved to .env
+// when its API key still works and the user keeps it, or nil to sign in
+func reusableConfig(apiClient *client.Client, refresh bool) *config.Config {
+	cfg, err := config.Read()
+	if err != nil || refresh {
+		return nil
+	}
+	// Configured against another TraceKit server (e.g. --dev)
+	if apiClient.BaseURL != client.DefaultBaseURL && cfg.GetAPIBase() != apiClient.BaseURL {
+		return nil
+	}
+
+	ui.PrintSection("🔐 Existing Configuration")
+	fmt.Println()
+
+	checker := client.NewClient(cfg.GetAPIBase())
+	checker.APIKey = cfg.APIKey
+	if _, err := checker.GetStatus(); err != nil {
+		var statusErr *client.StatusError
+		if errors.As(err, &statusErr) && statusErr.Unauthorized() {
+			ui.PrintWarning("The API key in .env is no longer valid")
+			ui.PrintMuted("   Signing in again for a new one")
+			fmt.Println()
+			return nil
+		}
+		ui.PrintWarning(fmt.Sprintf("Could not check the API key in .env: %v", err))
+	} else {
+		ui.PrintSuccess("TraceKit is already configured in .env")
+	}
+	ui.PrintMuted(fmt.Sprintf("   Service: %s", cfg.ServiceName))
+	ui.PrintMuted(fmt.Sprintf("   API Key: %s", utils.MaskAPIKey(cfg.APIKey)))
+	fmt.Println()
+
+	ui.PrintPrompt("Reuse it? Enter n to sign in again for a new API key (Y/n):")
+	var response string
+	fmt.Scanln(&response)
+	response = strings.ToLower(strings.TrimSpace(response))
+	fmt.Println()
+
+	if response == "n" || response == "no" {
+		return nil
+	}
+	return cfg
+}
+
 // promptWebhookSetup prompts user to configure a webhook
 func promptWebhookSetup(cfg *config.Config, apiClient *client.Client, useDev bool) error {
 	ui.PrintSection("🔗 Webhook Setup")
 	fmt.Println()
 
+	// Created by an earlier run
+	if existing, err := config.Read(); err == nil && existing.WebhookID != "" {
+		ui.PrintSuccess(fmt.Sprintf("Webhook already configured (ID: %s)", existing.WebhookID))
+		ui.PrintMuted("   Manage webhooks with: tracekit webhook list")
+		return nil
+	}
+
 	ui.PrintInfo("Set up webhooks for real-time event notifications?")
 	ui.PrintMuted("   Receive instant alerts when events occur:")
 	ui.PrintMuted("   • Health check failures")
@@ -312,17 +443,8 @@ func promptWebhookSetup(cfg *config.Config, apiClient *client.Client, useDev boo
 	url, _ := reader.ReadString('\n')
 	url = strings.TrimSpace(url)
 
-	if url == "" {
-		return fmt.Errorf("webhook URL is required")
-	}
-
-	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
-		return fmt.Errorf("webhook URL must start with https:// or http://")
-	}
-
-	// In production, enforce HTTPS (unless localhost)
-	if !useDev && !strings.HasPrefix(url, "https://") && !strings.Contains(url, "localhost") && !strings.Contains(url, "127.0.0.1") {
-		return fmt.Errorf("webhook URL must use HTTPS in production (got: %s)", url)
+	if err := webhook.ValidateURL(url, useDev); err != nil {
+		return err
 	}
 
 	// Get description (optional)
@@ -333,100 +455,50 @@ func promptWebhookSetup(cfg *config.Config, apiClient *client.Client, useDev boo
 	// Show available events
 	fmt.Println()
 	ui.PrintInfo("Select events to subscribe to:")
-	ui.PrintMuted("   [1] health_check.failed")
-	ui.PrintMuted("   [2] health_check.recovered")
-	ui.PrintMuted("   [3] alert.triggered")
-	ui.PrintMuted("   [4] alert.resolved")
-	ui.PrintMuted("   [5] trace.error")
-	ui.PrintMuted("   [6] anomaly.detected")
+	for i, e := range webhook.Events {
+		ui.PrintMuted(fmt.Sprintf("   [%d] %-24s %s", i+1, e.Name, e.Description))
+	}
 	fmt.Println()
 
 	ui.PrintPrompt("Enter event numbers (comma-separated, e.g., 1,3,5):")
 	eventsInput, _ := reader.ReadString('\n')
-	eventsInput = strings.TrimSpace(eventsInput)
-
-	availableEvents := []string{
-		"health_check.failed",
-		"health_check.recovered",
-		"alert.triggered",
-		"alert.resolved",
-		"trace.error",
-		"anomaly.detected",
-	}
-
-	// Parse selected events
-	selectedEvents := []string{}
-	if eventsInput != "" {
-		selections := strings.Split(eventsInput, ",")
-		for _, sel := range selections {
-			sel = strings.TrimSpace(sel)
-			var eventNum int
-			fmt.Sscanf(sel, "%d", &eventNum)
-			if eventNum >= 1 && eventNum <= len(availableEvents) {
-				selectedEvents = append(selectedEvents, availableEvents[eventNum-1])
-			}
-		}
-	}
-
-	if len(selectedEvents) == 0 {
-		return fmt.Errorf("at least one event must be selected")
-	}
-
-	// Create webhook via API
-	payload := map[string]interface{}{
-		"name":        name,
-		"url":         url,
-		"description": description,
-		"events":      selectedEvents,
-	}
-
-	payloadBytes, err := json.Marshal(payload)
+	selectedEvents, err := webhook.ParseSelection(eventsInput)
 	if err != nil {
-		return fmt.Errorf("failed to create payload: %w", err)
-	}
-
-	// Use the API client's base URL
-	req, err := http.NewRequest("POST", apiClient.BaseURL+"/v1/webhooks", bytes.NewBuffer(payloadBytes))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-API-Key", cfg.APIKey)
-
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
+	// Create webhook via API
+	webhookClient := client.NewClient(apiClient.BaseURL)
+	webhookClient.APIKey = cfg.APIKey
+	created, err := webhookClient.CreateWebhook(&client.WebhookRequest{
+		Name:        name,
+		URL:         url,
+		Description: description,
+		Events:      selectedEvents,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create webhook: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("failed to create webhook: %s", string(body))
+		return err
 	}
-
-	// Parse response
-	var result map[string]interface{}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+	if !plan.DryRun() {
+		state.RecordResource("webhook", created.ID, created.Name, webhookClient.BaseURL+"/v1/webhooks/"+created.ID)
 	}
-
-	webhookID := result["id"].(string)
-	secret := result["secret"].(string)
+	webhookID, secret := created.ID, created.Secret
 
 	// Save to .env
 	envPath := ".env"
-	envFile, err := os.OpenFile(envPath, os.O_APPEND|os.O_WRONLY, 0644)
+	existing, err := os.ReadFile(envPath)
 	if err != nil {
 		ui.PrintWarning(fmt.Sprintf("Could not save webhook to .env: %v", err))
 	} else {
-		defer envFile.Close()
-		envFile.WriteString(fmt.Sprintf("\n# Webhook Configuration\n"))
-		envFile.WriteString(fmt.Sprintf("TRACEKIT_WEBHOOK_ID=%s\n", webhookID))
-		envFile.WriteString(fmt.Sprintf("TRACEKIT_WEBHOOK_URL=%s\n", url))
-		envFile.WriteString(fmt.Sprintf("TRACEKIT_WEBHOOK_SECRET=%s\n", secret))
+		var b strings.Builder
+		b.Write(existing)
+		b.WriteString("\n# Webhook Configuration\n")
+		b.WriteString(fmt.Sprintf("TRACEKIT_WEBHOOK_ID=%s\n", webhookID))
+		b.WriteString(fmt.Sprintf("TRACEKIT_WEBHOOK_URL=%s\n", url))
+		b.WriteString(fmt.Sprintf("TRACEKIT_WEBHOOK_SECRET=%s\n", secret))
+		if err := plan.WriteFile("save webhook settings", envPath, []byte(b.String()), 0644); err != nil {
+			ui.PrintWarning(fmt.Sprintf("Could not save webhook to .env: %v", err))
+		}
 	}
 
 	fmt.Println()
@@ -452,6 +524,17 @@ func promptHealthCheckSetup(cfg *config.Config, apiClient *client.Client) error
 	ui.PrintSection("🏥 Health Check Setup")
 	fmt.Println()
 
+	// Created by an earlier run
+	if checks, err := apiClient.ListHealthChecks(cfg.GetAPIBase(), cfg.APIKey); err == nil {
+		for _, check := range checks {
+			if check.ServiceName == cfg.ServiceName {
+				ui.PrintSuccess(fmt.Sprintf("Health check already configured: %s (%s)", check.CheckName, check.CheckType))
+				ui.PrintMuted("   See all health checks with: tracekit health list")
+				return nil
+			}
+		}
+	}
+
 	ui.PrintInfo("Set up health check monitoring for your service?")
 	ui.PrintMuted("   Monitor your service health with automatic alerts")
 	ui.PrintMuted("   • Pull-based: TraceKit pings your endpoint")
@@ -543,11 +626,16 @@ func setupPullBasedHealthCheckFromInit(cfg *config.Config, apiClient *client.Cli
 	}
 
 	apiURL := strings.Replace(cfg.Endpoint, "/v1/traces", "", 1)
-	if err := apiClient.PostHealthCheck(apiURL, cfg.APIKey, requestBody); err != nil {
+	id, err := apiClient.PostHealthCheck(apiURL, cfg.APIKey, requestBody)
+	if err != nil {
 		ui.PrintError(fmt.Sprintf("Failed to create health check: %v", err))
 		ui.PrintMuted("   Run 'tracekit health setup' to try again")
 		return err
 	}
+	// The API can't delete health checks; rollback lists them
+	if !plan.DryRun() {
+		state.RecordResource("health check", id, checkName, "")
+	}
 
 	ui.PrintSuccess("Health check configured!")
 	fmt.Println()
@@ -585,11 +673,16 @@ func setupPushBasedHealthCheckFromInit(cfg *config.Config, apiClient *client.Cli
 	}
 
 	apiURL := strings.Replace(cfg.Endpoint, "/v1/traces", "", 1)
-	if err := apiClient.PostHealthCheck(apiURL, cfg.APIKey, requestBody); err != nil {
+	id, err := apiClient.PostHealthCheck(apiURL, cfg.APIKey, requestBody)
+	if err != nil {
 		ui.PrintError(fmt.Sprintf("Failed to create health check: %v", err))
 		ui.PrintMuted("   Run 'tracekit health setup' to try again")
 		return err
 	}
+	// The API can't delete health checks; rollback lists them
+	if !plan.DryRun() {
+		state.RecordResource("health check", id, checkName, "")
+	}
 
 	ui.PrintSuccess("Health check configured!")
 	fmt.Println()
@@ -675,26 +768,49 @@ func showStatusInternal(cfg *config.Config, apiClient *client.Client, useDev boo
 	return nil
 }
 
-// promptSDKInstall prompts user to install SDK
-func promptSDKInstall(framework *detector.Framework) error {
-	ui.PrintSection("📦 SDK Installation")
+// promptSDKInstall prompts user to install SDK for a service. showPath adds
+// the service path to the heading when several services are being set up.
+func promptSDKInstall(svc detector.Service, showPath bool) error {
+	framework := svc.Framework
+	if showPath {
+		ui.PrintSection(fmt.Sprintf("📦 SDK Installation: %s (%s)", svc.Path, svc.Name))
+	} else {
+		ui.PrintSection("📦 SDK Installation")
+	}
 	fmt.Println()
 
-	// Get recommended SDK
-	recommendedSDK := sdk.GetRecommendedSDK(framework.Type, framework.Name)
-	if recommendedSDK == nil {
+	// Installed by an earlier run, or by hand
+	project := &sdkProject{dir: svc.Path, framework: framework, target: sdkTarget(svc)}
+	if found := project.installed(); len(found) > 0 {
+		for _, i := range found {
+			label := i.sdk.Name
+			if v := i.version(); v != "" {
+				label += " " + v
+			}
+			ui.PrintSuccess(label + " is already installed")
+		}
+		ui.PrintMuted("   Check for updates with: tracekit sdk outdated")
+		return nil
+	}
+
+	// Get recommended SDK, honouring the SDK named by a custom detector rule
+	recommended := recommendedSDK(framework)
+	if framework.SDK != "" && sdk.GetSDK(framework.SDK) == nil {
+		ui.PrintWarning(fmt.Sprintf("Unknown SDK %q in detector rule for %s", framework.SDK, framework.Name))
+	}
+	if recommended == nil {
 		ui.PrintInfo("No SDK recommendation available for your framework")
 		ui.PrintMuted("   Visit https://docs.tracekit.dev for manual setup")
 		return nil
 	}
 
 	// Show recommendation
-	ui.PrintInfo(fmt.Sprintf("Recommended: %s", recommendedSDK.Name))
-	ui.PrintMuted(fmt.Sprintf("   %s", recommendedSDK.Description))
+	ui.PrintInfo(fmt.Sprintf("Recommended: %s", recommended.Name))
+	ui.PrintMuted(fmt.Sprintf("   %s", recommended.Description))
 	fmt.Println()
 
 	// Prompt user
-	fmt.Println("Install " + recommendedSDK.Name + " now?")
+	fmt.Println("Install " + recommended.Name + " now?")
 	ui.PrintMuted("   Y     = Install recommended SDK")
 	ui.PrintMuted("   n     = Skip installation")
 	ui.PrintMuted("   other = Show all available SDKs")
@@ -707,21 +823,32 @@ func promptSDKInstall(framework *detector.Framework) error {
 
 	if response == "" || response == "y" || response == "yes" {
 		// Install recommended SDK
-		return installSDK(*recommendedSDK)
+		return installSDK(*recommended, sdkTarget(svc))
 	} else if response == "n" || response == "no" {
 		ui.PrintInfo("Skipping SDK installation")
 		fmt.Println()
 		ui.PrintMuted("You can install manually later:")
-		ui.PrintMuted("   " + recommendedSDK.InstallCmd)
+		ui.PrintMuted("   " + sdk.InstallCommand(*recommended, sdkTarget(svc)))
 		return nil
 	} else {
 		// Show all available SDKs
-		return promptSDKSelection()
+		return promptSDKSelection(sdkTarget(svc))
+	}
+}
+
+// recommendedSDK returns the SDK for framework, preferring the one named by a
+// custom detector rule
+func recommendedSDK(framework *detector.Framework) *sdk.SDK {
+	if framework.SDK != "" {
+		if ruleSDK := sdk.GetSDK(framework.SDK); ruleSDK != nil {
+			return ruleSDK
+		}
 	}
+	return sdk.GetRecommendedSDK(framework.Type, framework.Name)
 }
 
 // promptSDKSelection shows all SDKs and lets user choose
-func promptSDKSelection() error {
+func promptSDKSelection(target sdk.Target) error {
 	fmt.Println()
 	ui.PrintInfo("Available SDKs:")
 	fmt.Println()
@@ -746,29 +873,82 @@ func promptSDKSelection() error {
 	}
 
 	selectedSDK := sdks[choice-1]
-	return installSDK(selectedSDK)
+	return installSDK(selectedSDK, target)
+}
+
+// printInstallFailure explains a failed SDK installation, with a fix for
+// known failures and the log of the package manager's output
+func printInstallFailure(err error, installCmd string) {
+	printCommandFailure("Installation", err, installCmd)
 }
 
-// installSDK installs the selected SDK
-func installSDK(selectedSDK sdk.SDK) error {
+// printCommandFailure reports a failed package manager run (an install,
+// upgrade or uninstall) with its diagnosis and the command to run by hand
+func printCommandFailure(action string, err error, command string) {
+	fmt.Println()
+	ui.PrintError(fmt.Sprintf("%s failed: %v", action, err))
+
+	var installErr *sdk.InstallError
+	if errors.As(err, &installErr) {
+		if d := installErr.Diagnosis; d != nil {
+			ui.PrintWarning(d.Problem)
+			for _, fix := range d.Fixes {
+				ui.PrintMuted("   • " + fix)
+			}
+		}
+		if installErr.LogPath != "" {
+			ui.PrintMuted("   Full output: " + installErr.LogPath)
+		}
+	}
+	fmt.Println()
+	ui.PrintMuted("Please run it manually:")
+	ui.PrintMuted("   " + command)
+}
+
+// installSDK installs the selected SDK into target with the project's package manager
+func installSDK(selectedSDK sdk.SDK, target sdk.Target) error {
+	selectedSDK.InstallCmd = sdk.InstallCommand(selectedSDK, target)
+
 	fmt.Println()
 	ui.PrintInfo(fmt.Sprintf("Installing %s...", selectedSDK.Name))
 	ui.PrintMuted("   Running: " + selectedSDK.InstallCmd)
+	if target.WorkspaceRoot != "" {
+		ui.PrintMuted("   Workspace: " + target.WorkspaceRoot)
+	}
 	fmt.Println()
 
-	if err := sdk.Install(selectedSDK); err != nil {
-		ui.PrintError(fmt.Sprintf("Installation failed: %v", err))
-		fmt.Println()
-		ui.PrintMuted("Please install manually:")
-		ui.PrintMuted("   " + selectedSDK.InstallCmd)
+	output := ui.NewOutputPane()
+	logPath, err := sdk.InstallTarget(selectedSDK, target, output)
+	output.Close(err == nil)
+	if err != nil {
+		printInstallFailure(err, selectedSDK.InstallCmd)
 		return err
 	}
 
-	ui.PrintSuccess(fmt.Sprintf("%s installed successfully!", selectedSDK.Name))
+	if plan.DryRun() {
+		ui.PrintInfo(fmt.Sprintf("%s would be installed", selectedSDK.Name))
+	} else {
+		ui.PrintSuccess(fmt.Sprintf("%s installed successfully!", selectedSDK.Name))
+	}
+	if logPath != "" {
+		ui.PrintSubtle("   Log: " + logPath)
+	}
+
+	// Framework configuration files, wired to the .env settings
+	files, err := sdk.WriteConfigFiles(selectedSDK, target)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Could not write configuration: %v", err))
+	}
+	for _, file := range files {
+		ui.PrintSuccess(createdMessage(file.Path))
+		if file.Usage != "" {
+			ui.PrintMuted("   " + file.Usage)
+		}
+	}
 	fmt.Println()
 
 	// Show initialization instructions
-	instructions := sdk.GetInstallInstructions(selectedSDK)
+	instructions := sdk.GetInstallInstructions(selectedSDK, target.Framework)
 	ui.PrintInfo("Next steps:")
 	for _, instruction := range instructions {
 		if instruction == "" {
@@ -780,3 +960,85 @@ func installSDK(selectedSDK sdk.SDK) error {
 
 	return nil
 }
+
+// sdkTarget describes a detected service as an SDK install target
+func sdkTarget(svc detector.Service) sdk.Target {
+	target := sdk.Target{
+		Dir:            svc.Dir,
+		PackageManager: svc.Framework.PackageManager,
+		Framework:      svc.Framework.Name,
+	}
+	if ws := svc.Framework.Workspace; ws != nil {
+		target.WorkspaceRoot = ws.Root
+		target.WorkspaceMember = ws.Member
+		target.WorkspacePackage = ws.Package
+	}
+	return target
+}
+
+// selectServices scans the repository for service roots and asks whether to
+// configure all of them. It returns current unchanged when the scan finds
+// nothing beyond the current directory or the user declines.
+func selectServices(root string, current []detector.Service, all bool) []detector.Service {
+	services, err := detector.DetectServices(root)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Service scan failed: %v", err))
+		fmt.Println()
+		return current
+	}
+	if len(services) == 0 || (len(services) == 1 && services[0].Path == ".") {
+		return current
+	}
+
+	ui.PrintInfo(fmt.Sprintf("Found %d services:", len(services)))
+	fmt.Println()
+
+	rows := make([][]string, 0, len(services))
+	for _, svc := range services {
+		rows = append(rows, []string{svc.Path, svc.Name, frameworkLabel(svc.Framework)})
+	}
+	ui.PrintTable([]string{"PATH", "SERVICE", "FRAMEWORK"}, rows)
+	fmt.Println()
+
+	for _, svc := range services {
+		printUnsupportedWarning(svc.Framework)
+	}
+
+	if !all {
+		ui.PrintPrompt(fmt.Sprintf("Configure all %d services? (Y/n):", len(services)))
+		var response string
+		fmt.Scanln(&response)
+		response = strings.ToLower(strings.TrimSpace(response))
+		fmt.Println()
+
+		if response == "n" || response == "no" {
+			ui.PrintMuted("   Configuring the current directory only")
+			fmt.Println()
+			return current
+		}
+	}
+
+	return services
+}
+
+// frameworkLabel formats a framework name with its resolved version, or the
+// declared constraint when no lockfile was found
+func frameworkLabel(framework *detector.Framework) string {
+	switch {
+	case framework.Version != "":
+		return framework.Name + " " + framework.Version
+	case framework.Constraint != "":
+		return framework.Name + " " + framework.Constraint
+	default:
+		return framework.Name
+	}
+}
+
+// printUnsupportedWarning warns when a framework is older than the SDK supports
+func printUnsupportedWarning(framework *detector.Framework) {
+	if !framework.Unsupported {
+		return
+	}
+	ui.PrintWarning(fmt.Sprintf("%s is not supported by the TraceKit SDK (requires %s or newer)",
+		frameworkLabel(framework), framework.MinVersion))
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/diff"
+	"github.com/yourusername/context.io/cli/internal/instrument"
+	"github.com/yourusername/context.io/cli/internal/sdk"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var instrumentCmd = &cobra.Command{
+	Use:   "instrument [dir]",
+	Short: "Add SDK initialization and middleware to your app's code",
+	Long: `Find the entrypoint of the detected framework and add the code that starts
+the TraceKit SDK and traces requests:
+
+  Gin, Echo, Fiber, chi, gorilla/mux   main.go: tracekit.Init() and r.Use(...)
+  net/http, gRPC                       main.go: tracekit.Init() and the handler
+  Express, Fastify, Koa, hapi          app.js, server.ts, ...: tracekit.init()
+  Django                               settings.py: tracekit.init() and MIDDLEWARE
+  Laravel                              bootstrap/app.php or app/Http/Kernel.php
+  Rails                                config/initializers/tracekit.rb
+  Sinatra                              config.ru or the app file
+
+Go code comes from the signed SDK catalog; with only the built-in catalog
+the steps are shown instead. A diff of every file is shown before anything
+is written. Files that already import or start a TraceKit SDK are left
+alone.
+
+Example:
+  tracekit instrument
+  tracekit instrument services/api --framework gin --yes`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runInstrument,
+}
+
+func init() {
+	rootCmd.AddCommand(instrumentCmd)
+	instrumentCmd.Flags().String("framework", "", "Use this framework instead of detecting one (see 'tracekit detect')")
+	instrumentCmd.Flags().BoolP("yes", "y", false, "Apply the changes without asking")
+}
+
+func runInstrument(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	yes, _ := cmd.Flags().GetBool("yes")
+	loadSDKCatalog(cmd, sdk.CatalogTTL)
+
+	framework, _, err := detectFramework(cmd, dir)
+	if err != nil {
+		return fmt.Errorf("failed to detect framework: %w", err)
+	}
+	// Workspace members are instrumented in their own directory
+	if ws := framework.Workspace; ws != nil {
+		dir = filepath.Join(ws.Root, filepath.FromSlash(ws.Member))
+	}
+
+	ui.PrintSection(fmt.Sprintf("🔧 Instrument: %s", framework.Name))
+	fmt.Println()
+
+	if !instrument.Supported(framework.Name) {
+		ui.PrintWarning(fmt.Sprintf("Automatic instrumentation isn't available for %s", framework.Name))
+		printManualInstructions(framework.Type, framework.Name)
+		return nil
+	}
+
+	plan, err := instrument.NewPlan(dir, framework)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Can't instrument the app: %v", err))
+		printManualInstructions(framework.Type, framework.Name)
+		return nil
+	}
+
+	cwd, _ := os.Getwd()
+	for _, name := range plan.Instrumented {
+		ui.PrintSuccess(relativeTo(cwd, filepath.Join(dir, name)) + " already references TraceKit")
+	}
+	for _, c := range plan.Changes {
+		path := relativeTo(cwd, filepath.Join(dir, c.Path))
+		ui.PrintInfo(fmt.Sprintf("%s: %s", path, c.Description))
+		ui.PrintDiff(diff.Unified(path, c.Before, c.After))
+		fmt.Println()
+	}
+
+	if len(plan.Changes) > 0 {
+		if confirmDeployChanges(len(plan.Changes), yes) {
+			if err := plan.Apply(dir); err != nil {
+				return err
+			}
+			ui.PrintSuccess(updatedMessage(fmt.Sprintf("%d files", len(plan.Changes))))
+		} else {
+			ui.PrintInfo("No files changed")
+			ui.PrintMuted("   Run 'tracekit instrument' later to apply these changes")
+		}
+		fmt.Println()
+	}
+
+	if len(plan.Instructions) > 0 {
+		ui.PrintInfo("Still to do:")
+		for _, line := range plan.Instructions {
+			ui.PrintMuted("   " + line)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// printManualInstructions prints the install and setup steps of the SDK
+// recommended for the framework
+func printManualInstructions(frameworkType, frameworkName string) {
+	recommended := sdk.GetRecommendedSDK(frameworkType, frameworkName)
+	if recommended == nil {
+		ui.PrintMuted("   No TraceKit SDK is available for this project yet")
+		return
+	}
+	for _, line := range sdk.GetInstallInstructions(*recommended, frameworkName) {
+		ui.PrintMuted("   " + line)
+	}
+}
@@ -34,7 +34,6 @@ Example:
 func init() {
 	rootCmd.AddCommand(loginCmd)
 	loginCmd.Flags().String("email", "", "Your email address")
-	loginCmd.Flags().String("api-url", "", "API base URL (default: https://app.tracekit.dev)")
 	loginCmd.Flags().Bool("dev", false, "")
 	loginCmd.Flags().MarkHidden("dev")
 }
@@ -44,18 +43,11 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	ui.PrintBanner()
 	fmt.Println()
 
-	// Step 1: Get email
+	// Step 1: Get email (prompted for while verifying unless --email is given)
 	ui.PrintSection("📧 Account Login")
 	fmt.Println()
 
 	email, _ := cmd.Flags().GetString("email")
-	if email == "" {
-		var err error
-		email, err = promptEmail()
-		if err != nil {
-			return err
-		}
-	}
 
 	// Get service name from directory
 	cwd, _ := os.Getwd()
@@ -84,38 +76,16 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		},
 	}
 
-	registerResp, err := apiClient.Register(registerReq)
+	// Step 3: Verify the emailed code and get API key
+	verifyResp, err := verifyEmail(apiClient, registerReq, "tracekit login")
 	if err != nil {
 		return fmt.Errorf("login failed: %w", err)
 	}
 
-	ui.PrintSuccess(fmt.Sprintf("Verification code sent to %s", email))
-	fmt.Println()
-
-	// Step 3: Get verification code
-	ui.PrintSection("🔑 Email Verification")
-	fmt.Println()
-	ui.PrintPrompt("Enter 6-digit code:")
-	var code string
-	fmt.Scanln(&code)
-	fmt.Println()
-
-	// Step 4: Verify and get API key
-	ui.PrintInfo("Verifying...")
-	verifyReq := &client.VerifyRequest{
-		SessionID: registerResp.SessionID,
-		Code:      code,
-	}
-
-	verifyResp, err := apiClient.Verify(verifyReq)
-	if err != nil {
-		return fmt.Errorf("verification failed: %w", err)
-	}
-
 	ui.PrintSuccess("Login successful!")
 	fmt.Println()
 
-	// Step 5: Save TraceKit config to .env
+	// Step 4: Save TraceKit config to .env
 	cfg := &config.Config{
 		APIKey:                 verifyResp.APIKey,
 		Endpoint:               apiClient.BaseURL + "/v1/traces",
@@ -134,7 +104,7 @@ func runLogin(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 
-	// Step 6: Show summary
+	// Step 5: Show summary
 	ui.PrintDivider()
 	fmt.Println()
 
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/client"
+	"github.com/yourusername/context.io/cli/internal/config"
+	"github.com/yourusername/context.io/cli/internal/diff"
+	"github.com/yourusername/context.io/cli/internal/otelcol"
+	"github.com/yourusername/context.io/cli/internal/plan"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var otelConfigCmd = &cobra.Command{
+	Use:   "otel-config",
+	Short: "Generate OpenTelemetry Collector config that exports to TraceKit",
+	Long: `Print an OpenTelemetry Collector configuration that sends traces to
+TraceKit, for services that already report to a collector you run.
+
+The config has an OTLP receiver, memory_limiter and batch processors and an
+otlphttp exporter to TraceKit that reads the API key from an environment
+variable. --tail-sampling adds a tail_sampling processor that keeps every
+trace with an error or a slow span and a share of the rest (it needs the
+otelcol-contrib distribution).
+
+With --merge, the TraceKit exporter, processors and a traces/tracekit
+pipeline are added to an existing config instead. The pipeline reuses the
+receivers of your traces pipelines; other pipelines are not changed.
+
+Example:
+  tracekit otel-config > otel-collector.yaml
+  tracekit otel-config --tail-sampling --sample-percentage 25
+  tracekit otel-config --merge /etc/otelcol/config.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runOtelConfig,
+}
+
+func init() {
+	rootCmd.AddCommand(otelConfigCmd)
+	otelConfigCmd.Flags().String("merge", "", "Add TraceKit to this collector config file instead of printing a new one")
+	otelConfigCmd.Flags().String("api-key-env", "TRACEKIT_API_KEY", "Environment variable the collector reads the API key from")
+	otelConfigCmd.Flags().Bool("tail-sampling", false, "Keep errors, slow traces and a sample of the rest")
+	otelConfigCmd.Flags().Int("sample-percentage", 10, "Percentage of other traces kept with --tail-sampling")
+	otelConfigCmd.Flags().Int("slow-ms", 1000, "Traces slower than this are always kept with --tail-sampling")
+	otelConfigCmd.Flags().BoolP("yes", "y", false, "Apply the changes without asking")
+}
+
+func runOtelConfig(cmd *cobra.Command, args []string) error {
+	mergePath, _ := cmd.Flags().GetString("merge")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	opts := otelcol.Options{Endpoint: client.DefaultBaseURL}
+	if cfg, err := config.Read(); err == nil && cfg.Endpoint != "" {
+		opts.Endpoint = cfg.GetAPIBase()
+	}
+	if apiURL, _ := cmd.Flags().GetString("api-url"); apiURL != "" {
+		opts.Endpoint = apiURL
+	}
+	opts.APIKeyEnv, _ = cmd.Flags().GetString("api-key-env")
+	opts.TailSampling, _ = cmd.Flags().GetBool("tail-sampling")
+	opts.SamplePercentage, _ = cmd.Flags().GetInt("sample-percentage")
+	opts.SlowThresholdMS, _ = cmd.Flags().GetInt("slow-ms")
+
+	if opts.SamplePercentage < 0 || opts.SamplePercentage > 100 {
+		return fmt.Errorf("--sample-percentage must be between 0 and 100")
+	}
+
+	if mergePath == "" {
+		fmt.Print(otelcol.Generate(opts))
+		return nil
+	}
+	return mergeOtelConfig(mergePath, opts, yes)
+}
+
+// mergeOtelConfig adds TraceKit to the collector config at path, creating
+// it if needed, after showing a diff
+func mergeOtelConfig(path string, opts otelcol.Options, yes bool) error {
+	before, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	after, err := otelcol.Merge(string(before), opts)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	ui.PrintSection("🔭 OpenTelemetry Collector")
+	fmt.Println()
+
+	if after == string(before) {
+		ui.PrintSuccess(path + " already exports to TraceKit")
+		fmt.Println()
+		return nil
+	}
+
+	cwd, _ := os.Getwd()
+	label := relativeTo(cwd, path)
+	ui.PrintInfo(fmt.Sprintf("%s: add the %s exporter and %s pipeline", label, otelcol.Exporter, otelcol.Pipeline))
+	ui.PrintDiff(diff.Unified(label, string(before), after))
+	fmt.Println()
+
+	if !confirmAction("Apply these changes?", yes) {
+		ui.PrintInfo("No files changed")
+		fmt.Println()
+		return nil
+	}
+	if err := plan.WriteFile("export traces to TraceKit", path, []byte(after), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	ui.PrintSuccess(updatedMessage(path))
+	ui.PrintMuted(fmt.Sprintf("   Set %s in the collector's environment and restart it", opts.APIKeyEnv))
+	fmt.Println()
+	return nil
+}
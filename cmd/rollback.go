@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/client"
+	"github.com/yourusername/context.io/cli/internal/config"
+	"github.com/yourusername/context.io/cli/internal/plan"
+	"github.com/yourusername/context.io/cli/internal/sdk"
+	"github.com/yourusername/context.io/cli/internal/state"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var rollbackCmd = &cobra.Command{
+	Use:     "rollback",
+	Aliases: []string{"uninstall"},
+	Short:   "Undo the changes TraceKit made to this project",
+	Long: `Every change the CLI makes to a project is recorded in
+.tracekit/state.json: files written (with backups of what they replaced),
+SDKs installed and webhooks, health checks and keys created in TraceKit.
+
+This command undoes them, newest first:
+
+  files        restored from their backups, or deleted if TraceKit created them
+  SDKs         uninstalled with the project's package manager
+  webhooks     deleted through the API
+
+Files edited since TraceKit wrote them are left alone unless --force is
+given. Anything that can't be undone is listed at the end; changes that
+failed stay in the manifest so the command can be run again.
+
+Example:
+  tracekit rollback
+  tracekit rollback --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runRollback,
+}
+
+func init() {
+	rootCmd.AddCommand(rollbackCmd)
+	rollbackCmd.Flags().BoolP("yes", "y", false, "Undo the changes without asking")
+	rollbackCmd.Flags().Bool("force", false, "Also restore files edited since TraceKit changed them")
+}
+
+// manualStep is a change the CLI can't undo, with what to do instead
+type manualStep string
+
+func (m manualStep) Error() string { return string(m) }
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	yes, _ := cmd.Flags().GetBool("yes")
+	force, _ := cmd.Flags().GetBool("force")
+
+	// Undoing changes isn't itself a change to record
+	state.Pause()
+
+	manifest, err := state.Load()
+	if err != nil {
+		return err
+	}
+	if len(manifest.Changes) == 0 {
+		ui.PrintInfo("Nothing to roll back: no changes recorded in " + filepath.Join(state.Dir, "state.json"))
+		return nil
+	}
+	loadSDKCatalog(cmd, sdk.CatalogTTL)
+
+	ui.PrintSection("↩️  Rollback")
+	fmt.Println()
+	for i := len(manifest.Changes) - 1; i >= 0; i-- {
+		ui.PrintBullet(describeChange(manifest.Changes[i]))
+	}
+	fmt.Println()
+
+	if !confirmAction(fmt.Sprintf("Undo %d changes?", len(manifest.Changes)), yes) {
+		ui.PrintInfo("Nothing changed")
+		return nil
+	}
+	fmt.Println()
+
+	// Read the API key now: restoring .env may remove it
+	cfg, _ := config.ReadDir(state.Root())
+
+	var kept []state.Change
+	var failures []string
+	for i := len(manifest.Changes) - 1; i >= 0; i-- {
+		c := manifest.Changes[i]
+		err := revertChange(c, cfg, force)
+		var manual manualStep
+		switch {
+		case err == nil:
+			ui.PrintSuccess(describeChange(c))
+		case errors.As(err, &manual):
+			// Nothing more the CLI can do, so it isn't kept
+			failures = append(failures, fmt.Sprintf("%s: %v", describeChange(c), err))
+		default:
+			failures = append(failures, fmt.Sprintf("%s: %v", describeChange(c), err))
+			kept = append([]state.Change{c}, kept...)
+		}
+	}
+
+	if !plan.DryRun() {
+		manifest.Changes = kept
+		if err := state.Save(manifest); err != nil {
+			ui.PrintWarning(fmt.Sprintf("Could not update %s: %v", filepath.Join(state.Dir, "state.json"), err))
+		}
+	}
+
+	fmt.Println()
+	if len(failures) > 0 {
+		ui.PrintWarning(fmt.Sprintf("Could not undo %d changes:", len(failures)))
+		for _, f := range failures {
+			ui.PrintMuted("   " + f)
+		}
+		if len(kept) > 0 {
+			ui.PrintMuted(fmt.Sprintf("   %d are kept in %s; run 'tracekit rollback' again once fixed", len(kept), filepath.Join(state.Dir, "state.json")))
+		}
+		fmt.Println()
+	} else if !plan.DryRun() {
+		ui.PrintSuccess("All TraceKit changes undone")
+		fmt.Println()
+	}
+	return nil
+}
+
+// describeChange says how a change is undone
+func describeChange(c state.Change) string {
+	switch c.Type {
+	case "file":
+		switch {
+		case c.Removed:
+			return "Restore deleted " + c.Path
+		case c.Backup == "":
+			return "Delete " + c.Path
+		default:
+			return "Restore " + c.Path
+		}
+	case "package":
+		if c.Dir == "." {
+			return fmt.Sprintf("Uninstall %s from this project", c.SDK)
+		}
+		return fmt.Sprintf("Uninstall %s from %s", c.SDK, c.Dir)
+	case "resource":
+		if c.Kind == "api key" {
+			return "Revoke the API key for " + c.Name
+		}
+		name := c.Kind
+		if c.Name != "" {
+			name += " " + c.Name
+		}
+		if c.ID != "" {
+			name += " (" + c.ID + ")"
+		}
+		return "Delete " + name
+	}
+	return c.Reason
+}
+
+func revertChange(c state.Change, cfg *config.Config, force bool) error {
+	switch c.Type {
+	case "file":
+		return revertFile(c, force)
+	case "package":
+		return revertPackage(c)
+	case "resource":
+		return revertResource(c, cfg)
+	}
+	return fmt.Errorf("unknown change type %q", c.Type)
+}
+
+func revertFile(c state.Change, force bool) error {
+	path := c.AbsPath()
+	_, statErr := os.Stat(path)
+	exists := statErr == nil
+
+	mode := c.Mode
+	if mode == 0 {
+		mode = 0644
+	}
+
+	if c.Removed {
+		if exists && !force {
+			return fmt.Errorf("it exists again (use --force to overwrite it)")
+		}
+		content, err := c.ReadBackup()
+		if err != nil {
+			return fmt.Errorf("backup missing: %w", err)
+		}
+		return plan.WriteFile("roll back", path, content, mode)
+	}
+
+	if c.Backup == "" && !exists {
+		// Created by TraceKit and already deleted
+		return nil
+	}
+	if c.Modified() && !force {
+		return fmt.Errorf("edited since TraceKit changed it (use --force to undo anyway)")
+	}
+	if c.Backup == "" {
+		return plan.Remove("roll back", path)
+	}
+	content, err := c.ReadBackup()
+	if err != nil {
+		return fmt.Errorf("backup missing: %w", err)
+	}
+	return plan.WriteFile("roll back", path, content, mode)
+}
+
+func revertPackage(c state.Change) error {
+	var selected *sdk.SDK
+	for _, s := range sdk.GetAvailableSDKs() {
+		if s.Name == c.SDK {
+			selected = &s
+			break
+		}
+	}
+	if selected == nil {
+		return fmt.Errorf("%s is not in the SDK catalog; remove it with your package manager", c.SDK)
+	}
+
+	dir := c.Dir
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(state.Root(), filepath.FromSlash(dir))
+	}
+	target := sdk.Target{Dir: dir, Framework: c.Framework, PackageManager: c.PackageManager}
+	// Workspace members are removed through the workspace root
+	if ws := c.Workspace; ws != nil {
+		target.WorkspaceRoot, target.WorkspaceMember, target.WorkspacePackage = ws.Root, ws.Member, ws.Package
+	}
+
+	output := ui.NewOutputPane()
+	_, err := sdk.Uninstall(*selected, target, output)
+	output.Close(err == nil)
+	return err
+}
+
+func revertResource(c state.Change, cfg *config.Config) error {
+	if c.DeleteURL == "" {
+		if c.Kind == "api key" {
+			return manualStep("revoke it in the TraceKit dashboard if it is no longer needed")
+		}
+		return manualStep(fmt.Sprintf("the API can't delete a %s; remove it in the TraceKit dashboard", c.Kind))
+	}
+	if cfg == nil {
+		return fmt.Errorf("no API key found in .env")
+	}
+	return client.NewClient(cfg.GetAPIBase()).DeleteResource("roll back", c.DeleteURL, cfg.APIKey)
+}
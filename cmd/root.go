@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/config"
 )
 
 // Version is set by main.go via ldflags
@@ -25,10 +26,26 @@ Examples:
 
 // Execute runs the root command
 func Execute() error {
-	return rootCmd.Execute()
+	if err := rootCmd.Execute(); err != nil {
+		return err
+	}
+	return printPlan()
 }
 
 func init() {
 	// Custom version template
 	rootCmd.SetVersionTemplate(fmt.Sprintf("TraceKit CLI %s\n", Version))
+
+	rootCmd.PersistentFlags().String("api-url", "", "API base URL (default: from .env or https://app.tracekit.dev)")
+	rootCmd.PersistentFlags().String("dry-run", "", "Show what would change without changing anything (text|json)")
+	rootCmd.PersistentFlags().Lookup("dry-run").NoOptDefVal = "text"
+
+	cobra.OnInitialize(startDryRun)
+}
+
+// applyAPIURLFlag points cfg at the --api-url override, if one was given
+func applyAPIURLFlag(cmd *cobra.Command, cfg *config.Config) {
+	if apiURL, _ := cmd.Flags().GetString("api-url"); apiURL != "" {
+		cfg.Endpoint = apiURL
+	}
 }
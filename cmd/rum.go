@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/client"
+	"github.com/yourusername/context.io/cli/internal/config"
+	"github.com/yourusername/context.io/cli/internal/diff"
+	"github.com/yourusername/context.io/cli/internal/plan"
+	"github.com/yourusername/context.io/cli/internal/rum"
+	"github.com/yourusername/context.io/cli/internal/state"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var rumCmd = &cobra.Command{
+	Use:   "rum [dir]",
+	Short: "Set up browser monitoring (RUM) for a frontend app",
+	Long: `Set up real user monitoring for a React, Vue, Angular, Svelte or Vite app.
+
+Browser code can't hold the API key, so this command creates a public ingest
+key for the service. It only accepts browser telemetry and is saved to its own
+block in .env, with the bundler's prefix (VITE_, REACT_APP_, VUE_APP_) so the
+app can read it; the API key stays unexposed.
+
+It then offers to load the SDK, showing the change first:
+  - apps built with Vite, Create React App or Vue CLI: an import in the
+    entry module (src/main.* or src/index.*) reading the .env variables
+  - other apps: the CDN loader in index.html with the public key inline
+
+'tracekit init' runs this step for frontend apps.
+
+Example:
+  tracekit rum
+  tracekit rum apps/web --yes`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRUM,
+}
+
+func init() {
+	rootCmd.AddCommand(rumCmd)
+	rumCmd.Flags().BoolP("yes", "y", false, "Inject the loader without asking")
+}
+
+func runRUM(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	cfg, err := config.ReadDir(dir)
+	if err != nil {
+		ui.PrintError("No TraceKit configuration found in " + filepath.Join(dir, ".env"))
+		ui.PrintMuted("   Run 'tracekit init' to set up your project")
+		return nil
+	}
+
+	return setupBrowserMonitoring(dir, cfg, yes)
+}
+
+// setupBrowserMonitoring creates (or reuses) the public ingest key of the
+// browser app in dir, saves it to .env and offers to inject the loader
+func setupBrowserMonitoring(dir string, cfg *config.Config, yes bool) error {
+	ui.PrintSection(fmt.Sprintf("🌐 Browser Monitoring: %s", cfg.ServiceName))
+	fmt.Println()
+
+	project, err := rum.Inspect(dir)
+	if err != nil {
+		return err
+	}
+
+	browser, err := config.ReadBrowserDir(dir)
+	if err != nil {
+		apiClient := client.NewClient(cfg.GetAPIBase())
+		apiClient.APIKey = cfg.APIKey
+		key, err := apiClient.CreatePublicKey(&client.PublicKeyRequest{ServiceName: cfg.ServiceName})
+		if err != nil {
+			return fmt.Errorf("failed to create public ingest key: %w", err)
+		}
+		if !plan.DryRun() {
+			state.RecordResource("public key", "", key.ServiceName, "")
+		}
+		browser = &config.BrowserConfig{PublicKey: key.PublicKey}
+		ui.PrintSuccess("Public ingest key created (safe to ship in frontend code)")
+	} else {
+		ui.PrintSuccess("Using the public ingest key in .env")
+	}
+	browser.Endpoint = cfg.GetAPIBase()
+	browser.ServiceName = cfg.ServiceName
+	browser.EnvPrefix = project.EnvPrefix
+
+	if err := config.SaveBrowserDir(dir, browser); err != nil {
+		return fmt.Errorf("failed to save .env: %w", err)
+	}
+	var names []string
+	for _, v := range browser.EnvVars() {
+		names = append(names, v.Name)
+	}
+	ui.PrintMuted("   Saved to .env: " + strings.Join(names, ", "))
+	if browser.EnvPrefix != "" {
+		ui.PrintMuted("   TRACEKIT_API_KEY has no " + browser.EnvPrefix + " prefix, so it never reaches the browser")
+	}
+	fmt.Println()
+
+	change, err := project.Plan(browser)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Can't inject the loader: %v", err))
+		ui.PrintMuted("   Install " + rum.Package + " and call init({ publicKey }) in your app entry module")
+		return nil
+	}
+	if change.Before == change.After {
+		ui.PrintSuccess(change.Path + " already loads the TraceKit browser SDK")
+		return nil
+	}
+
+	ui.PrintInfo(fmt.Sprintf("%s: %s", change.Path, change.Description))
+	ui.PrintDiff(diff.Unified(change.Path, change.Before, change.After))
+	fmt.Println()
+
+	if !yes {
+		ui.PrintPrompt(fmt.Sprintf("Apply this change to %s? (y/N):", change.Path))
+		var response string
+		fmt.Scanln(&response)
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response != "y" && response != "yes" {
+			ui.PrintInfo("No files changed")
+			ui.PrintMuted("   Run 'tracekit rum' later to inject the loader")
+			return nil
+		}
+	}
+
+	if err := project.Apply(change); err != nil {
+		return err
+	}
+	ui.PrintSuccess(updatedMessage(change.Path))
+	if project.EnvPrefix != "" {
+		ui.PrintMuted("   Install the SDK if you haven't: " + rum.Package)
+	}
+	return nil
+}
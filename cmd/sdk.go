@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/client"
+	"github.com/yourusername/context.io/cli/internal/config"
+	"github.com/yourusername/context.io/cli/internal/detector"
+	"github.com/yourusername/context.io/cli/internal/sdk"
+)
+
+var sdkCmd = &cobra.Command{
+	Use:   "sdk",
+	Short: "Install, inspect and upgrade the TraceKit SDK of a project",
+	Long: `Manage the TraceKit SDK of a project without rerunning 'tracekit init'.
+
+The framework and package manager are detected the same way init does;
+installed versions are read from lockfiles (composer.lock, package-lock.json,
+pnpm-lock.yaml, yarn.lock, go.mod, poetry.lock, uv.lock, Gemfile.lock, ...).
+
+Available subcommands:
+  install   - Install the recommended (or named) SDK
+  status    - Show which SDK is installed and at which version
+  outdated  - Compare installed SDKs against the latest releases
+  upgrade   - Upgrade the SDK to the latest release
+  uninstall - Remove the SDK
+  catalog   - List the SDKs in the SDK catalog
+
+Example:
+  tracekit sdk status
+  tracekit sdk outdated services/*
+  tracekit sdk upgrade --yes`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		loadSDKCatalog(cmd, sdk.CatalogTTL)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Show help if no subcommand
+		return cmd.Help()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sdkCmd)
+	sdkCmd.PersistentFlags().String("framework", "", "Use this framework instead of detecting one (see 'tracekit detect')")
+
+	sdkCmd.AddCommand(sdkInstallCmd)
+	sdkCmd.AddCommand(sdkStatusCmd)
+	sdkCmd.AddCommand(sdkOutdatedCmd)
+	sdkCmd.AddCommand(sdkUpgradeCmd)
+	sdkCmd.AddCommand(sdkUninstallCmd)
+	sdkCmd.AddCommand(sdkCatalogCmd)
+}
+
+// catalogTimeout bounds the catalog request so offline runs aren't held up
+const catalogTimeout = 5 * time.Second
+
+// loadSDKCatalog fetches the SDK catalog from the API when the cached one is
+// older than maxAge, falling back to the cached or built-in catalog. The
+// catalog's minimum framework versions apply to detection from then on.
+func loadSDKCatalog(cmd *cobra.Command, maxAge time.Duration) (*sdk.Catalog, error) {
+	apiURL := client.DefaultBaseURL
+	if cfg, err := config.Read(); err == nil && cfg.Endpoint != "" {
+		apiURL = cfg.GetAPIBase()
+	}
+	if flagURL, _ := cmd.Flags().GetString("api-url"); flagURL != "" {
+		apiURL = flagURL
+	}
+	if useDev, _ := cmd.Flags().GetBool("dev"); useDev {
+		apiURL = client.DevBaseURL
+	}
+
+	apiClient := client.NewClient(apiURL)
+	apiClient.HTTPClient.Timeout = catalogTimeout
+
+	catalog, err := sdk.LoadCatalog(apiClient.GetSDKCatalog, maxAge)
+	detector.SetMinSupportedVersions(catalog.MinFrameworkVersions)
+	// Being offline is routine, but a catalog that fails verification means
+	// the CLI's keys are out of date; on stderr, as some callers print JSON
+	if errors.Is(err, sdk.ErrUnverifiedCatalog) {
+		fmt.Fprintf(os.Stderr, "⚠️  %v; using the %s catalog\n", err, catalog.Source)
+	}
+	return catalog, err
+}
+
+// sdkProject is a project whose SDK is being managed
+type sdkProject struct {
+	dir       string // As given on the command line
+	framework *detector.Framework
+	target    sdk.Target
+}
+
+// loadSDKProject detects the framework of the project in dir
+func loadSDKProject(cmd *cobra.Command, dir string) (*sdkProject, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	framework, _, err := detectFramework(cmd, abs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to detect framework: %w", dir, err)
+	}
+	target := sdkTarget(detector.Service{Dir: abs, Framework: framework})
+	return &sdkProject{dir: dir, framework: framework, target: target}, nil
+}
+
+// moduleDir is where the project's dependencies are declared; Go workspace
+// members keep theirs in their own go.mod
+func (p *sdkProject) moduleDir() string {
+	if p.framework.Type == "go" && p.target.WorkspaceRoot != "" {
+		return filepath.Join(p.target.WorkspaceRoot, filepath.FromSlash(p.target.WorkspaceMember))
+	}
+	return p.target.Dir
+}
+
+// installedSDK is a catalog SDK found in a project
+type installedSDK struct {
+	sdk sdk.SDK
+	pkg *detector.Package // nil for agents, which aren't dependencies
+}
+
+// version returns the installed version, or "" when it isn't pinned
+func (i installedSDK) version() string {
+	if i.pkg == nil {
+		return ""
+	}
+	return i.pkg.Version
+}
+
+// candidateSDKs returns the catalog SDKs that apply to the project's language
+func (p *sdkProject) candidateSDKs() []sdk.SDK {
+	var sdks []sdk.SDK
+	for _, s := range sdk.GetAvailableSDKs() {
+		if s.Language == p.framework.Type {
+			sdks = append(sdks, s)
+		}
+	}
+	return sdks
+}
+
+// installed returns the TraceKit SDKs the project depends on
+func (p *sdkProject) installed() []installedSDK {
+	var found []installedSDK
+	for _, s := range p.candidateSDKs() {
+		if agent := sdk.AgentPath(s, p.target.Dir); agent != "" {
+			if _, err := os.Stat(agent); err == nil {
+				found = append(found, installedSDK{sdk: s})
+			}
+			continue
+		}
+		if pkg, ok := detector.FindPackage(p.moduleDir(), s.PackageName); ok {
+			found = append(found, installedSDK{sdk: s, pkg: pkg})
+		}
+	}
+	return found
+}
+
+// findSDK returns the SDK named name (by SDK name or package), or the one
+// recommended for the project when name is empty
+func (p *sdkProject) findSDK(name string) (*sdk.SDK, error) {
+	if name == "" {
+		if recommended := sdk.GetRecommendedSDK(p.framework.Type, p.framework.Name); recommended != nil {
+			return recommended, nil
+		}
+		return nil, fmt.Errorf("no TraceKit SDK is available for %s projects", p.framework.Type)
+	}
+
+	if s := sdk.GetSDK(name); s != nil {
+		return s, nil
+	}
+	var names []string
+	for _, s := range sdk.GetAvailableSDKs() {
+		if strings.EqualFold(s.PackageName, name) {
+			return &s, nil
+		}
+		names = append(names, s.Name)
+	}
+	return nil, fmt.Errorf("unknown SDK %q (available: %s)", name, strings.Join(names, ", "))
+}
+
+// selectInstalled returns the installed SDKs matching name (all of them when
+// name is empty)
+func (p *sdkProject) selectInstalled(name string) ([]installedSDK, error) {
+	installed := p.installed()
+	if name == "" {
+		return installed, nil
+	}
+
+	want, err := p.findSDK(name)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range installed {
+		if i.sdk.Name == want.Name {
+			return []installedSDK{i}, nil
+		}
+	}
+	return nil, nil
+}
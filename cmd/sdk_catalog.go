@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/sdk"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var sdkCatalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "List the SDKs in the SDK catalog",
+	Long: `List the SDKs TraceKit can install, with their packages and latest
+releases.
+
+The catalog is fetched from the API (signed, and cached for a day) so
+package names and setup instructions stay current between CLI releases.
+Offline, the cached or built-in catalog is used.
+
+Example:
+  tracekit sdk catalog
+  tracekit sdk catalog --refresh -o json`,
+	Args: cobra.NoArgs,
+	// Loads the catalog itself, honoring --refresh
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	RunE:             runSDKCatalog,
+}
+
+func init() {
+	sdkCatalogCmd.Flags().Bool("refresh", false, "Fetch the catalog even if the cached one is current")
+	sdkCatalogCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+}
+
+func runSDKCatalog(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString("output")
+	if err := validateOutputFormat(output, "table", "json"); err != nil {
+		return err
+	}
+
+	refresh, _ := cmd.Flags().GetBool("refresh")
+	maxAge := sdk.CatalogTTL
+	if refresh {
+		maxAge = 0
+	}
+	catalog, loadErr := loadSDKCatalog(cmd, maxAge)
+	if refresh && loadErr != nil {
+		return loadErr
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(catalog)
+	}
+
+	ui.PrintSection("📚 SDK Catalog")
+	fmt.Println()
+	// Verification failures were reported when loading
+	if loadErr != nil && !errors.Is(loadErr, sdk.ErrUnverifiedCatalog) {
+		ui.PrintWarning(loadErr.Error())
+		ui.PrintMuted("   Showing the " + catalog.Source + " catalog")
+		fmt.Println()
+	}
+	ui.PrintKeyValue("Revision", fmt.Sprintf("%d (%s)", catalog.Revision, catalog.Source))
+	fmt.Println()
+
+	var rows [][]string
+	for _, s := range catalog.SDKs {
+		version := s.Version
+		if version == "" {
+			version = "-"
+		}
+		rows = append(rows, []string{s.Name, s.Language, s.PackageName, version})
+	}
+	ui.PrintTable([]string{"SDK", "LANGUAGE", "PACKAGE", "LATEST"}, rows)
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var sdkInstallCmd = &cobra.Command{
+	Use:   "install [name]",
+	Short: "Install the TraceKit SDK",
+	Long: `Install the SDK recommended for the detected framework, or the named one
+(see 'tracekit sdk status' for names), with the project's package manager.
+
+Example:
+  tracekit sdk install
+  tracekit sdk install Laravel --dir apps/api`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSDKInstall,
+}
+
+func init() {
+	sdkInstallCmd.Flags().String("dir", ".", "Project directory")
+}
+
+func runSDKInstall(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	project, err := loadSDKProject(cmd, dir)
+	if err != nil {
+		return err
+	}
+	selected, err := project.findSDK(name)
+	if err != nil {
+		return err
+	}
+
+	if selected.Language != project.framework.Type {
+		return fmt.Errorf("the %s SDK is for %s projects, but %s is a %s project (see --framework)",
+			selected.Name, selected.Language, dir, project.framework.Type)
+	}
+
+	ui.PrintSection(fmt.Sprintf("📦 SDK: %s", selected.Name))
+	fmt.Println()
+	ui.PrintMuted(fmt.Sprintf("   Framework: %s (%s)", project.framework.Name, project.framework.Type))
+
+	for _, i := range project.installed() {
+		if i.sdk.Name != selected.Name {
+			continue
+		}
+		ui.PrintSuccess(fmt.Sprintf("%s is already installed%s", selected.Name, versionSuffix(i.version())))
+		ui.PrintMuted("   Run 'tracekit sdk upgrade' to move to the latest release")
+		return nil
+	}
+
+	return installSDK(*selected, project.target)
+}
+
+// versionSuffix formats a version for appending to a message
+func versionSuffix(version string) string {
+	if version == "" {
+		return ""
+	}
+	return " (" + version + ")"
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var sdkOutdatedCmd = &cobra.Command{
+	Use:   "outdated [dir...]",
+	Short: "List TraceKit SDKs older than the latest release",
+	Long: `Compare the SDK version each project's lockfile resolves to against the
+latest release in the SDK catalog. Several project directories can be given,
+so one command covers a whole set of repositories.
+
+Example:
+  tracekit sdk outdated
+  tracekit sdk outdated ~/src/* -o json`,
+	RunE: runSDKOutdated,
+}
+
+func init() {
+	sdkOutdatedCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+}
+
+func runSDKOutdated(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString("output")
+	if err := validateOutputFormat(output, "table", "json"); err != nil {
+		return err
+	}
+
+	statuses, err := sdkStatuses(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	var installed []sdkStatus
+	for _, s := range statuses {
+		if s.Installed {
+			installed = append(installed, s)
+		}
+	}
+
+	if output == "json" {
+		if installed == nil {
+			installed = []sdkStatus{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(installed)
+	}
+
+	ui.PrintSection("📦 Outdated TraceKit SDKs")
+	fmt.Println()
+
+	if len(installed) == 0 {
+		ui.PrintInfo("No TraceKit SDK installed")
+		ui.PrintMuted("   Install with: tracekit sdk install")
+		return nil
+	}
+
+	var rows [][]string
+	outdated, unknown := 0, 0
+	for _, s := range installed {
+		state := "ok"
+		switch {
+		case s.Outdated:
+			state = "outdated"
+			outdated++
+		case s.Version == "" || s.Latest == "":
+			state = "unknown"
+			unknown++
+		}
+		current := s.Version
+		if current == "" {
+			current = s.Constraint
+		}
+		rows = append(rows, []string{s.Project, s.SDK, current, s.Latest, ui.StatusText(state)})
+	}
+	ui.PrintTable([]string{"PROJECT", "SDK", "CURRENT", "LATEST", "STATUS"}, rows)
+	fmt.Println()
+
+	if outdated > 0 {
+		ui.PrintWarning(fmt.Sprintf("%d of %d SDKs are outdated", outdated, len(installed)))
+		ui.PrintMuted("   Upgrade with: tracekit sdk upgrade")
+	} else if unknown == 0 {
+		ui.PrintSuccess("All SDKs are up to date")
+	}
+	if unknown > 0 {
+		ui.PrintInfo(fmt.Sprintf("%d of %d SDKs couldn't be compared with the latest release", unknown, len(installed)))
+		ui.PrintMuted("   Fetch the latest releases with: tracekit sdk catalog --refresh")
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/detector"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var sdkStatusCmd = &cobra.Command{
+	Use:   "status [dir...]",
+	Short: "Show the installed TraceKit SDK and its version",
+	Long: `Show which TraceKit SDK each project depends on and the version its
+lockfile resolves to. Several project directories can be given at once.
+
+Example:
+  tracekit sdk status
+  tracekit sdk status services/api services/worker -o json`,
+	RunE: runSDKStatus,
+}
+
+func init() {
+	sdkStatusCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+}
+
+// sdkStatus is the JSON shape of an SDK in a project
+type sdkStatus struct {
+	Project     string `json:"project"`
+	Framework   string `json:"framework"`
+	SDK         string `json:"sdk,omitempty"`
+	Package     string `json:"package,omitempty"`
+	Installed   bool   `json:"installed"`
+	Version     string `json:"version,omitempty"`
+	Constraint  string `json:"constraint,omitempty"`
+	VersionFile string `json:"version_file,omitempty"`
+	Latest      string `json:"latest,omitempty"`
+	Outdated    bool   `json:"outdated"`
+}
+
+// sdkStatuses returns the SDKs of each project in dirs (the current
+// directory when there are none). Projects without one get an entry for the
+// recommended SDK with Installed unset.
+func sdkStatuses(cmd *cobra.Command, dirs []string) ([]sdkStatus, error) {
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	var statuses []sdkStatus
+	for _, dir := range dirs {
+		project, err := loadSDKProject(cmd, dir)
+		if err != nil {
+			return nil, err
+		}
+
+		installed := project.installed()
+		if len(installed) == 0 {
+			status := sdkStatus{Project: dir, Framework: project.framework.Name}
+			if recommended, err := project.findSDK(""); err == nil {
+				status.SDK, status.Package, status.Latest = recommended.Name, recommended.PackageName, recommended.Version
+			}
+			statuses = append(statuses, status)
+			continue
+		}
+
+		for _, i := range installed {
+			status := sdkStatus{
+				Project:   dir,
+				Framework: project.framework.Name,
+				SDK:       i.sdk.Name,
+				Package:   i.sdk.PackageName,
+				Installed: true,
+				Version:   i.version(),
+				Latest:    i.sdk.Version,
+			}
+			if i.pkg != nil {
+				status.Constraint, status.VersionFile = i.pkg.Constraint, i.pkg.VersionFile
+			}
+			status.Outdated = status.Version != "" && status.Latest != "" &&
+				detector.CompareVersions(status.Version, status.Latest) < 0
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
+
+func runSDKStatus(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString("output")
+	if err := validateOutputFormat(output, "table", "json"); err != nil {
+		return err
+	}
+
+	statuses, err := sdkStatuses(cmd, args)
+	if err != nil {
+		return err
+	}
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(statuses)
+	}
+
+	ui.PrintSection("📦 TraceKit SDK Status")
+	fmt.Println()
+
+	var rows [][]string
+	missing := 0
+	for _, s := range statuses {
+		if !s.Installed {
+			missing++
+			rows = append(rows, []string{s.Project, s.Framework, s.SDK, ui.StatusText("not installed"), ""})
+			continue
+		}
+		version := s.Version
+		if version == "" {
+			version = s.Constraint + " (not locked)"
+		}
+		rows = append(rows, []string{s.Project, s.Framework, s.SDK, version, s.VersionFile})
+	}
+	ui.PrintTable([]string{"PROJECT", "FRAMEWORK", "SDK", "VERSION", "FROM"}, rows)
+	fmt.Println()
+
+	if missing > 0 {
+		ui.PrintMuted("   Install with: tracekit sdk install")
+	}
+	return nil
+}
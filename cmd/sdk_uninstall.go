@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/instrument"
+	"github.com/yourusername/context.io/cli/internal/plan"
+	"github.com/yourusername/context.io/cli/internal/sdk"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var sdkUninstallCmd = &cobra.Command{
+	Use:   "uninstall [name]",
+	Short: "Remove the TraceKit SDK",
+	Long: `Remove the installed TraceKit SDK (or only the named one) with the
+project's package manager. Code that still references TraceKit, such as
+what 'tracekit instrument' added, is listed afterwards so it can be removed
+by hand.
+
+Example:
+  tracekit sdk uninstall
+  tracekit sdk uninstall Python --dir services/worker --yes`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSDKUninstall,
+}
+
+func init() {
+	sdkUninstallCmd.Flags().String("dir", ".", "Project directory")
+	sdkUninstallCmd.Flags().BoolP("yes", "y", false, "Uninstall without asking")
+}
+
+func runSDKUninstall(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+	yes, _ := cmd.Flags().GetBool("yes")
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	project, err := loadSDKProject(cmd, dir)
+	if err != nil {
+		return err
+	}
+	installed, err := project.selectInstalled(name)
+	if err != nil {
+		return err
+	}
+	if len(installed) == 0 {
+		ui.PrintInfo("No TraceKit SDK installed")
+		return nil
+	}
+
+	removed := false
+	for _, i := range installed {
+		ui.PrintSection(fmt.Sprintf("🗑  Uninstall: %s", i.sdk.Name))
+		fmt.Println()
+
+		command, err := sdk.UninstallCommand(i.sdk, project.target)
+		if err != nil {
+			ui.PrintWarning(err.Error())
+			continue
+		}
+		ui.PrintMuted("   Running: " + command)
+		fmt.Println()
+
+		if !confirmAction("Remove "+i.sdk.Name+"?", yes) {
+			ui.PrintInfo("Uninstall skipped")
+			continue
+		}
+
+		output := ui.NewOutputPane()
+		logPath, err := sdk.Uninstall(i.sdk, project.target, output)
+		output.Close(err == nil)
+		if err != nil {
+			printCommandFailure("Uninstall", err, command)
+			return err
+		}
+		removed = true
+
+		if plan.DryRun() {
+			ui.PrintInfo(fmt.Sprintf("%s would be removed", i.sdk.Name))
+		} else {
+			ui.PrintSuccess(fmt.Sprintf("%s removed", i.sdk.Name))
+		}
+		if logPath != "" {
+			ui.PrintSubtle("   Log: " + logPath)
+		}
+		fmt.Println()
+	}
+
+	if removed {
+		printRemainingReferences(project)
+	}
+	return nil
+}
+
+// printRemainingReferences lists the files that still set up TraceKit, which
+// won't compile or start without the SDK
+func printRemainingReferences(project *sdkProject) {
+	if !instrument.Supported(project.framework.Name) {
+		return
+	}
+	plan, err := instrument.NewPlan(project.target.Dir, project.framework)
+	if err != nil || len(plan.Instrumented) == 0 {
+		return
+	}
+
+	ui.PrintWarning("These files still reference TraceKit; remove the setup from them:")
+	for _, file := range plan.Instrumented {
+		ui.PrintMuted("   • " + file)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/detector"
+	"github.com/yourusername/context.io/cli/internal/plan"
+	"github.com/yourusername/context.io/cli/internal/sdk"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var sdkUpgradeCmd = &cobra.Command{
+	Use:   "upgrade [name]",
+	Short: "Upgrade the TraceKit SDK to the latest release",
+	Long: `Upgrade the installed TraceKit SDK (or only the named one) to the latest
+release in the SDK catalog, or to --version, with the project's package
+manager. The command is shown before it runs.
+
+Example:
+  tracekit sdk upgrade
+  tracekit sdk upgrade Node.js --version 2.4.1 --yes`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSDKUpgrade,
+}
+
+func init() {
+	sdkUpgradeCmd.Flags().String("dir", ".", "Project directory")
+	sdkUpgradeCmd.Flags().String("version", "", "Version to upgrade to (default: latest)")
+	sdkUpgradeCmd.Flags().BoolP("yes", "y", false, "Upgrade without asking")
+}
+
+func runSDKUpgrade(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+	version, _ := cmd.Flags().GetString("version")
+	yes, _ := cmd.Flags().GetBool("yes")
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	project, err := loadSDKProject(cmd, dir)
+	if err != nil {
+		return err
+	}
+	installed, err := project.selectInstalled(name)
+	if err != nil {
+		return err
+	}
+	if len(installed) == 0 {
+		ui.PrintInfo("No TraceKit SDK installed")
+		ui.PrintMuted("   Install with: tracekit sdk install " + name)
+		return nil
+	}
+
+	for _, i := range installed {
+		if err := upgradeSDK(project, i, version, yes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upgradeSDK upgrades one installed SDK to version (the catalog's latest
+// when empty)
+func upgradeSDK(project *sdkProject, i installedSDK, version string, yes bool) error {
+	ui.PrintSection(fmt.Sprintf("📦 Upgrade: %s", i.sdk.Name))
+	fmt.Println()
+
+	to := version
+	if to == "" {
+		to = i.sdk.Version
+	}
+	current := i.version()
+	if version == "" && current != "" && to != "" && detector.CompareVersions(current, to) >= 0 {
+		ui.PrintSuccess(fmt.Sprintf("%s is up to date (%s)", i.sdk.Name, current))
+		fmt.Println()
+		return nil
+	}
+
+	command, err := sdk.UpgradeCommand(i.sdk, project.target, version)
+	if err != nil {
+		ui.PrintWarning(err.Error())
+		return nil
+	}
+	if current == "" {
+		current = "unknown"
+	}
+	if to == "" {
+		to = "latest"
+	}
+	ui.PrintKeyValue("Version", current+" → "+to)
+	ui.PrintMuted("   Running: " + command)
+	fmt.Println()
+
+	if !confirmAction("Upgrade "+i.sdk.Name+"?", yes) {
+		ui.PrintInfo("Upgrade skipped")
+		return nil
+	}
+
+	output := ui.NewOutputPane()
+	logPath, err := sdk.Upgrade(i.sdk, project.target, version, output)
+	output.Close(err == nil)
+	if err != nil {
+		printCommandFailure("Upgrade", err, command)
+		return err
+	}
+
+	if plan.DryRun() {
+		ui.PrintInfo(fmt.Sprintf("%s would be upgraded", i.sdk.Name))
+	} else {
+		ui.PrintSuccess(fmt.Sprintf("%s upgraded", i.sdk.Name))
+	}
+	if logPath != "" {
+		ui.PrintSubtle("   Log: " + logPath)
+	}
+	fmt.Println()
+	return nil
+}
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"slices"
 
 	"github.com/spf13/cobra"
 	"github.com/yourusername/context.io/cli/internal/client"
 	"github.com/yourusername/context.io/cli/internal/config"
 	"github.com/yourusername/context.io/cli/internal/detector"
+	"github.com/yourusername/context.io/cli/internal/sdk"
 	"github.com/yourusername/context.io/cli/internal/ui"
 	"github.com/yourusername/context.io/cli/internal/utils"
 )
@@ -21,15 +24,17 @@ This command will:
   1. Check your .env file for TraceKit configuration
   2. Verify your API key is valid
   3. Show your integration status
-  4. Display framework detection results
+  4. Display framework detection results and other observability tools
 
 Example:
-  tracekit status`,
+  tracekit status
+  tracekit status --framework fastapi`,
 	RunE: runStatus,
 }
 
 func init() {
 	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().String("framework", "", "Use this framework instead of detecting one (see 'tracekit detect')")
 	statusCmd.Flags().Bool("dev", false, "")
 	statusCmd.Flags().MarkHidden("dev")
 }
@@ -38,6 +43,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	// Print banner
 	ui.PrintBanner()
 	fmt.Println()
+	loadSDKCatalog(cmd, sdk.CatalogTTL)
 
 	// Step 1: Check for .env file and read config
 	ui.PrintSection("📋 Configuration")
@@ -64,19 +70,48 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	ui.PrintSection("🔍 Framework Detection")
 	fmt.Println()
 
-	framework, err := detector.Detect()
+	cwd, _ := os.Getwd()
+	framework, confidence, err := detectFramework(cmd, cwd)
 	if err != nil {
-		ui.PrintWarning("Framework detection failed")
+		ui.PrintWarning(fmt.Sprintf("Framework detection failed: %v", err))
+		framework = &detector.Framework{Name: "generic", Type: "unknown"}
 	} else if framework.Name == "generic" {
 		ui.PrintWarning("No framework detected")
 	} else {
-		ui.PrintSuccess(fmt.Sprintf("Detected: %s (%s)", framework.Name, framework.Type))
+		label := "Detected"
+		if cmd.Flags().Changed("framework") {
+			label = "Framework"
+		}
+		ui.PrintSuccess(fmt.Sprintf("%s: %s (%s)", label, framework.Name, framework.Type))
 		if framework.Version != "" {
 			ui.PrintMuted(fmt.Sprintf("   Version: %s", framework.Version))
 		}
+		if !cmd.Flags().Changed("framework") {
+			ui.PrintMuted(fmt.Sprintf("   Confidence: %s (run 'tracekit detect' for details)", confidenceLabel(confidence)))
+		}
 	}
 	fmt.Println()
 
+	// Existing observability tools (possible double instrumentation)
+	if vendors := detector.DetectVendors(cwd); len(vendors) > 0 {
+		ui.PrintSection("🔭 Other Observability Tools")
+		fmt.Println()
+		printVendors(vendors)
+		fmt.Println()
+		switch {
+		case cfg.OTLPExport:
+			ui.PrintSuccess("OpenTelemetry exports to TraceKit")
+		case hasVendor(vendors, "opentelemetry"):
+			ui.PrintMuted("   Point OpenTelemetry at TraceKit with 'tracekit init --existing migrate'")
+		}
+		for _, v := range vendors {
+			if v.Tracing && v.Key != "opentelemetry" {
+				ui.PrintWarning(fmt.Sprintf("%s also traces requests; TraceKit spans may be duplicated", v.Name))
+			}
+		}
+		fmt.Println()
+	}
+
 	// Step 3: Check API key validity and get integration status
 	ui.PrintSection("🔌 Integration Status")
 	fmt.Println()
@@ -84,6 +119,9 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	// Determine API URL
 	useDev, _ := cmd.Flags().GetBool("dev")
 	apiURL := client.DefaultBaseURL
+	if flagURL, _ := cmd.Flags().GetString("api-url"); flagURL != "" {
+		apiURL = flagURL
+	}
 	if useDev {
 		apiURL = client.DevBaseURL
 		ui.PrintInfo("Using development API: " + apiURL)
@@ -154,6 +192,9 @@ func runStatus(cmd *cobra.Command, args []string) error {
 				"Send a test trace with 'tracekit test'",
 				"Visit https://app.tracekit.dev to view traces",
 			}
+			if step := sdk.MiddlewareStep(framework.Name); step != "" {
+				steps = slices.Insert(steps, 2, step)
+			}
 		} else {
 			steps = []string{
 				"Visit https://app.tracekit.dev to view traces",
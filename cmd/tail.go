@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/client"
+	"github.com/yourusername/context.io/cli/internal/trace"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+const (
+	tailInitialBackoff = 1 * time.Second
+	tailMaxBackoff     = 30 * time.Second
+)
+
+var tailCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "Stream new spans and errors in real time",
+	Long: `Stream spans for a service as they arrive and print a one-line summary
+for each, with its duration and status.
+
+The stream reconnects automatically after network interruptions and resumes
+where it left off. On busy services use --rate to cap how many spans are
+printed per second; skipped spans are counted and reported.
+
+Example:
+  tracekit tail
+  tracekit tail --errors-only
+  tracekit tail --route 'GET /users' --min-duration 250ms
+  tracekit tail --service checkout --rate 5`,
+	RunE: runTail,
+}
+
+func init() {
+	rootCmd.AddCommand(tailCmd)
+	tailCmd.Flags().String("service", "", "Service to tail (default: service from .env)")
+	tailCmd.Flags().Bool("errors-only", false, "Only show spans with an error status")
+	tailCmd.Flags().String("route", "", "Only show spans for this route or span name")
+	tailCmd.Flags().Duration("min-duration", 0, "Only show spans at least this long (e.g. 250ms)")
+	tailCmd.Flags().Int("rate", 20, "Maximum spans printed per second (0 for unlimited)")
+	tailCmd.Flags().Bool("json", false, "Print each span as a JSON line")
+	tailCmd.Flags().Bool("dev", false, "")
+	tailCmd.Flags().MarkHidden("dev")
+}
+
+// tailLimiter caps output to a number of spans per one-second window
+type tailLimiter struct {
+	rate        int
+	windowStart time.Time
+	count       int
+	dropped     int
+}
+
+// allow reports whether a span may be printed now, and how many spans were
+// dropped in the previous window (reported once when a new window opens)
+func (l *tailLimiter) allow(now time.Time) (bool, int) {
+	if l.rate <= 0 {
+		return true, 0
+	}
+
+	var dropped int
+	if now.Sub(l.windowStart) >= time.Second {
+		dropped = l.dropped
+		l.windowStart = now
+		l.count = 0
+		l.dropped = 0
+	}
+
+	if l.count >= l.rate {
+		l.dropped++
+		return false, dropped
+	}
+
+	l.count++
+	return true, dropped
+}
+
+func runTail(cmd *cobra.Command, args []string) error {
+	apiClient, cfg, err := newAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	service, _ := cmd.Flags().GetString("service")
+	if service == "" {
+		service = cfg.ServiceName
+	}
+	errorsOnly, _ := cmd.Flags().GetBool("errors-only")
+	route, _ := cmd.Flags().GetString("route")
+	minDuration, _ := cmd.Flags().GetDuration("min-duration")
+	rate, _ := cmd.Flags().GetInt("rate")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if !asJSON {
+		filters := []string{"service=" + service}
+		if errorsOnly {
+			filters = append(filters, "errors only")
+		}
+		if route != "" {
+			filters = append(filters, "route="+route)
+		}
+		if minDuration > 0 {
+			filters = append(filters, "min duration="+minDuration.String())
+		}
+		ui.PrintInfo(fmt.Sprintf("Tailing spans (%s) - press Ctrl+C to stop", strings.Join(filters, ", ")))
+		fmt.Println()
+	}
+
+	limiter := &tailLimiter{rate: rate}
+	encoder := json.NewEncoder(os.Stdout)
+
+	handle := func(span trace.Span) error {
+		// The server applies the same filters; re-check in case it doesn't
+		if !tailMatches(&span, errorsOnly, route, minDuration) {
+			return nil
+		}
+
+		ok, dropped := limiter.allow(time.Now())
+		if dropped > 0 && !asJSON {
+			ui.PrintMuted(fmt.Sprintf("   … %d spans skipped (limit %d/s)", dropped, rate))
+		}
+		if !ok {
+			return nil
+		}
+
+		if asJSON {
+			return encoder.Encode(span)
+		}
+		fmt.Println(ui.SpanLine(&span))
+		return nil
+	}
+
+	req := &client.StreamRequest{
+		Service:     service,
+		ErrorsOnly:  errorsOnly,
+		Route:       route,
+		MinDuration: minDuration,
+	}
+	backoff := tailInitialBackoff
+
+	for {
+		connectedAt := time.Now()
+		lastEventID, err := apiClient.StreamSpans(ctx, req, handle)
+		req.LastEventID = lastEventID
+
+		if ctx.Err() != nil {
+			if !asJSON {
+				fmt.Println()
+				ui.PrintMuted("Stopped tailing")
+			}
+			return nil
+		}
+
+		var statusErr *client.StatusError
+		if errors.As(err, &statusErr) && !statusErr.Retryable() {
+			return fmt.Errorf("failed to open stream: %w", err)
+		}
+		// Only connection problems are worth a reconnect
+		var handlerErr *client.HandlerError
+		if errors.As(err, &handlerErr) {
+			return fmt.Errorf("failed to write span: %w", handlerErr.Err)
+		}
+
+		// A connection that stayed up for a while resets the backoff
+		if time.Since(connectedAt) > tailMaxBackoff {
+			backoff = tailInitialBackoff
+		}
+
+		if !asJSON {
+			reason := "stream closed"
+			if err != nil {
+				reason = err.Error()
+			}
+			ui.PrintWarning(fmt.Sprintf("Disconnected (%s), reconnecting in %s...", reason, backoff))
+		}
+
+		select {
+		case <-ctx.Done():
+			// Reported at the top of the loop
+			continue
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > tailMaxBackoff {
+			backoff = tailMaxBackoff
+		}
+	}
+}
+
+// tailMatches applies the tail filters to a span
+func tailMatches(span *trace.Span, errorsOnly bool, route string, minDuration time.Duration) bool {
+	if errorsOnly && !span.IsError() {
+		return false
+	}
+
+	if minDuration > 0 && span.Duration < float64(minDuration.Milliseconds()) {
+		return false
+	}
+
+	if route != "" {
+		httpRoute, _ := span.Attributes["http.route"].(string)
+		if span.Name != route && httpRoute != route && !strings.HasSuffix(span.Name, " "+route) {
+			return false
+		}
+	}
+
+	return true
+}
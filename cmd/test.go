@@ -46,6 +46,7 @@ func runTest(cmd *cobra.Command, args []string) error {
 		ui.PrintMuted("   Run 'tracekit init' to set up your project")
 		return nil
 	}
+	applyAPIURLFlag(cmd, cfg)
 
 	ui.PrintSuccess("Configuration loaded")
 	ui.PrintMuted(fmt.Sprintf("   Service: %s", cfg.ServiceName))
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var traceCmd = &cobra.Command{
+	Use:   "trace",
+	Short: "Inspect trace files locally",
+	Long: `Inspect trace files locally without opening the web UI.
+
+Available subcommands:
+  view - Render a trace file as a waterfall in the terminal
+
+Example:
+  tracekit trace view trace.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Show help if no subcommand
+		return cmd.Help()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(traceCmd)
+	traceCmd.AddCommand(traceViewCmd)
+}
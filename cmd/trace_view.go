@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/trace"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var traceViewCmd = &cobra.Command{
+	Use:   "view <file>",
+	Short: "Render a trace file as a waterfall",
+	Long: `Render a local trace file as a collapsible waterfall in the terminal.
+
+The file may contain a single span, an array of spans, or an object with
+a "spans" array. Spans are nested by parent_id, and duration bars are
+scaled to the root span.
+
+Keys:
+  ↑/↓ or j/k   Move between spans
+  enter/space  Collapse or expand the selected span
+  ←/→ or h/l   Collapse / expand
+  tab          Toggle the attribute and event detail pane
+  q            Quit
+
+Example:
+  tracekit trace view trace.json
+  tracekit trace view trace.json --plain`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTraceView,
+}
+
+func init() {
+	traceViewCmd.Flags().Bool("plain", false, "Print a static waterfall instead of the interactive viewer")
+	traceViewCmd.Flags().Int("width", 120, "Output width for --plain")
+}
+
+func runTraceView(cmd *cobra.Command, args []string) error {
+	spans, err := trace.LoadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Fall back to static output when stdout is not a terminal
+	plain, _ := cmd.Flags().GetBool("plain")
+	if plain || !isatty.IsTerminal(os.Stdout.Fd()) {
+		width, _ := cmd.Flags().GetInt("width")
+		fmt.Print(ui.RenderWaterfall(trace.BuildTree(spans), width))
+		return nil
+	}
+
+	return ui.RunWaterfall(spans)
+}
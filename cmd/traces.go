@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/client"
+	"github.com/yourusername/context.io/cli/internal/config"
+)
+
+var tracesCmd = &cobra.Command{
+	Use:   "traces",
+	Short: "Search and retrieve traces from TraceKit",
+	Long: `Search and retrieve traces stored in TraceKit.
+
+Available subcommands:
+  search - Find recent traces matching filters
+  get    - Fetch a single trace by ID or dashboard link
+
+Example:
+  tracekit traces search --service api --since 1h --status error
+  tracekit traces get 4bf92f3577b34da6a3ce929d0e0e4736`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Show help if no subcommand
+		return cmd.Help()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tracesCmd)
+
+	tracesCmd.PersistentFlags().StringP("output", "o", "", "Output format: table, waterfall or json")
+	tracesCmd.PersistentFlags().Bool("dev", false, "Use development API endpoint")
+	tracesCmd.PersistentFlags().MarkHidden("dev")
+
+	tracesCmd.AddCommand(tracesSearchCmd)
+	tracesCmd.AddCommand(tracesGetCmd)
+}
+
+// newAuthenticatedClient builds an API client from the project's .env,
+// honoring the --api-url and --dev flags
+func newAuthenticatedClient(cmd *cobra.Command) (*client.Client, *config.Config, error) {
+	cfg, err := config.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.APIKey == "" {
+		return nil, nil, fmt.Errorf("not authenticated. Run 'tracekit login' first")
+	}
+
+	apiURL := cfg.GetAPIBase()
+	if flagURL, _ := cmd.Flags().GetString("api-url"); flagURL != "" {
+		apiURL = flagURL
+	}
+	if useDev, _ := cmd.Flags().GetBool("dev"); useDev {
+		apiURL = client.DevBaseURL
+	}
+
+	apiClient := client.NewClient(apiURL)
+	apiClient.APIKey = cfg.APIKey
+
+	return apiClient, cfg, nil
+}
+
+// parseSince parses a lookback window such as "30m", "1h" or "7d"
+func parseSince(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return d, nil
+}
+
+// validateOutputFormat checks an --output value against the allowed formats
+func validateOutputFormat(format string, allowed ...string) error {
+	for _, a := range allowed {
+		if format == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %q (expected one of: %s)", format, strings.Join(allowed, ", "))
+}
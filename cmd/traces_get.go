@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/trace"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var tracesGetCmd = &cobra.Command{
+	Use:   "get <trace-id | dashboard-url>",
+	Short: "Fetch a single trace",
+	Long: `Fetch a single trace by ID. A dashboard or alert link to the trace
+is accepted as well, so you can paste it straight from a notification.
+
+Output formats:
+  waterfall - Interactive waterfall in a terminal, static otherwise (default)
+  table     - One line per span
+  json      - Raw trace with all spans (can be re-opened with 'tracekit trace view')
+
+Example:
+  tracekit traces get 4bf92f3577b34da6a3ce929d0e0e4736
+  tracekit traces get https://app.tracekit.dev/traces/4bf92f3577b34da6a3ce929d0e0e4736
+  tracekit traces get 4bf92f3577b34da6a3ce929d0e0e4736 -o json > trace.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTracesGet,
+}
+
+func runTracesGet(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString("output")
+	if output == "" {
+		output = "waterfall"
+	}
+	if err := validateOutputFormat(output, "table", "waterfall", "json"); err != nil {
+		return err
+	}
+
+	apiClient, _, err := newAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	traceID := traceIDFromArg(args[0])
+	result, err := apiClient.GetTrace(traceID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch trace %s: %w", traceID, err)
+	}
+
+	switch output {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+
+	case "table":
+		rows := make([][]string, 0, len(result.Spans))
+		for _, node := range flattenSpanTree(trace.BuildTree(result.Spans)) {
+			span := node.Span
+			status := span.Status.Code
+			if status == "" {
+				status = "unset"
+			}
+			rows = append(rows, []string{
+				strings.Repeat("  ", node.Depth) + span.Name,
+				span.Service.Name,
+				span.Kind,
+				ui.FormatDurationMs(span.Duration),
+				ui.StatusText(status),
+				span.SpanID,
+			})
+		}
+		ui.PrintTable([]string{"SPAN", "SERVICE", "KIND", "DURATION", "STATUS", "SPAN ID"}, rows)
+		return nil
+	}
+
+	if len(result.Spans) == 0 {
+		return fmt.Errorf("trace %s has no spans", traceID)
+	}
+
+	if !isatty.IsTerminal(os.Stdout.Fd()) {
+		fmt.Print(ui.RenderWaterfall(trace.BuildTree(result.Spans), 120))
+		return nil
+	}
+
+	return ui.RunWaterfall(result.Spans)
+}
+
+// traceIDFromArg accepts a bare trace ID or a dashboard link ending in one
+func traceIDFromArg(arg string) string {
+	u, err := url.Parse(arg)
+	if err != nil || u.Scheme == "" {
+		return arg
+	}
+
+	if id := u.Query().Get("trace_id"); id != "" {
+		return id
+	}
+	return path.Base(strings.TrimSuffix(u.Path, "/"))
+}
+
+// flattenSpanTree lists spans depth-first so children follow their parent
+func flattenSpanTree(roots []*trace.SpanNode) []*trace.SpanNode {
+	var nodes []*trace.SpanNode
+	var walk func(*trace.SpanNode)
+	walk = func(node *trace.SpanNode) {
+		nodes = append(nodes, node)
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	for _, root := range roots {
+		walk(root)
+	}
+	return nodes
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/client"
+	"github.com/yourusername/context.io/cli/internal/trace"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+var tracesSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Find recent traces matching filters",
+	Long: `Search recent traces by service, status, duration and name.
+
+Output formats:
+  table     - One line per trace (default)
+  waterfall - Fetch and render every matching trace as a waterfall
+  json      - Raw search results
+
+Example:
+  tracekit traces search --service api --since 1h
+  tracekit traces search --status error --min-duration 500ms --name 'GET /users'
+  tracekit traces search --since 1d -o json`,
+	RunE: runTracesSearch,
+}
+
+func init() {
+	tracesSearchCmd.Flags().String("service", "", "Only traces from this service (default: service from .env)")
+	tracesSearchCmd.Flags().Bool("all-services", false, "Search across all services")
+	tracesSearchCmd.Flags().String("since", "1h", "Lookback window (e.g. 15m, 1h, 7d)")
+	tracesSearchCmd.Flags().String("status", "", "Only traces with this status: ok or error")
+	tracesSearchCmd.Flags().Duration("min-duration", 0, "Only traces at least this long (e.g. 500ms)")
+	tracesSearchCmd.Flags().String("name", "", "Only traces whose root span has this name")
+	tracesSearchCmd.Flags().Int("limit", 20, "Maximum number of traces to return")
+}
+
+func runTracesSearch(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString("output")
+	if output == "" {
+		output = "table"
+	}
+	if err := validateOutputFormat(output, "table", "waterfall", "json"); err != nil {
+		return err
+	}
+
+	apiClient, cfg, err := newAuthenticatedClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	sinceValue, _ := cmd.Flags().GetString("since")
+	since, err := parseSince(sinceValue)
+	if err != nil {
+		return err
+	}
+
+	status, _ := cmd.Flags().GetString("status")
+	if status != "" && status != "ok" && status != "error" {
+		return fmt.Errorf("invalid status %q (expected ok or error)", status)
+	}
+
+	service, _ := cmd.Flags().GetString("service")
+	allServices, _ := cmd.Flags().GetBool("all-services")
+	if service == "" && !allServices {
+		service = cfg.ServiceName
+	}
+
+	minDuration, _ := cmd.Flags().GetDuration("min-duration")
+	name, _ := cmd.Flags().GetString("name")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	result, err := apiClient.SearchTraces(&client.TraceSearchRequest{
+		Service:     service,
+		Since:       since,
+		Status:      status,
+		MinDuration: minDuration,
+		Name:        name,
+		Limit:       limit,
+	})
+	if err != nil {
+		return fmt.Errorf("trace search failed: %w", err)
+	}
+
+	switch output {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+
+	case "waterfall":
+		for i, summary := range result.Traces {
+			traceResp, err := apiClient.GetTrace(summary.TraceID)
+			if err != nil {
+				ui.PrintWarning(fmt.Sprintf("Failed to fetch trace %s: %v", summary.TraceID, err))
+				continue
+			}
+			if i > 0 {
+				fmt.Println()
+			}
+			ui.PrintHighlight(fmt.Sprintf("%s  %s", summary.TraceID, summary.RootName))
+			fmt.Print(ui.RenderWaterfall(trace.BuildTree(traceResp.Spans), 120))
+		}
+		return nil
+	}
+
+	if len(result.Traces) == 0 {
+		ui.PrintWarning("No traces found")
+		ui.PrintMuted("   Try a longer --since window or fewer filters")
+		return nil
+	}
+
+	rows := make([][]string, 0, len(result.Traces))
+	for _, t := range result.Traces {
+		rows = append(rows, []string{
+			t.StartTime.Local().Format("2006-01-02 15:04:05"),
+			t.TraceID,
+			t.ServiceName,
+			t.RootName,
+			ui.FormatDurationMs(t.DurationMs),
+			strconv.Itoa(t.SpanCount),
+			ui.StatusText(t.Status),
+		})
+	}
+	ui.PrintTable([]string{"STARTED", "TRACE ID", "SERVICE", "NAME", "DURATION", "SPANS", "STATUS"}, rows)
+
+	if result.Total > len(result.Traces) {
+		ui.PrintMuted(fmt.Sprintf("Showing %d of %d traces (use --limit to see more)", len(result.Traces), result.Total))
+	}
+
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/yourusername/context.io/cli/internal/client"
 	"github.com/yourusername/context.io/cli/internal/config"
+	"github.com/yourusername/context.io/cli/internal/plan"
 	"github.com/yourusername/context.io/cli/internal/ui"
 )
 
@@ -37,7 +38,6 @@ Example:
 
 func init() {
 	rootCmd.AddCommand(upgradeCmd)
-	upgradeCmd.Flags().String("api-url", "", "API base URL (default: https://app.tracekit.dev)")
 	upgradeCmd.Flags().Bool("dev", false, "")
 	upgradeCmd.Flags().MarkHidden("dev")
 }
@@ -119,6 +119,11 @@ func runUpgrade(cmd *cobra.Command, args []string) error {
 	ui.PrintSection("🔐 Generating secure token...")
 	fmt.Println()
 
+	if plan.Request("create upgrade token", "POST", apiClient.BaseURL+"/v1/auth/upgrade-token", nil) {
+		ui.PrintInfo("Dry run: the upgrade page would open in your browser")
+		return nil
+	}
+
 	tokenResp, err := createUpgradeToken(apiClient)
 	if err != nil {
 		return fmt.Errorf("failed to create upgrade token: %w", err)
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/context.io/cli/internal/detector"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+// How init sets up TraceKit next to observability tools already in use
+const (
+	existingSideBySide = "side-by-side"
+	existingMigrate    = "migrate"
+)
+
+// serviceVendors pairs a service with the observability tools found in it
+type serviceVendors struct {
+	Service detector.Service
+	Vendors []detector.Vendor
+}
+
+// findVendors detects existing observability tools in each service
+func findVendors(services []detector.Service) []serviceVendors {
+	var found []serviceVendors
+	for _, svc := range services {
+		if vendors := detector.DetectVendors(svc.Dir); len(vendors) > 0 {
+			found = append(found, serviceVendors{Service: svc, Vendors: vendors})
+		}
+	}
+	return found
+}
+
+// hasVendor reports whether vendors includes the one with key
+func hasVendor(vendors []detector.Vendor, key string) bool {
+	for _, v := range vendors {
+		if v.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// printVendors lists each tool with the evidence it was found by
+func printVendors(vendors []detector.Vendor) {
+	for _, v := range vendors {
+		ui.PrintBullet(v.Name)
+		for _, e := range v.Evidence {
+			ui.PrintMuted(fmt.Sprintf("     %s: %s", e.File, e.Detail))
+		}
+	}
+}
+
+// promptExistingTools shows the tools found and asks whether to run TraceKit
+// side by side or migrate. mode is the --existing flag, if given.
+func promptExistingTools(found []serviceVendors, mode string, showPath bool) (string, error) {
+	ui.PrintSection("🔭 Existing Observability Tools")
+	fmt.Println()
+
+	for _, sv := range found {
+		if showPath {
+			ui.PrintInfo(fmt.Sprintf("%s (%s)", sv.Service.Path, sv.Service.Name))
+		}
+		printVendors(sv.Vendors)
+	}
+	fmt.Println()
+
+	switch mode {
+	case existingSideBySide, existingMigrate:
+	case "":
+		fmt.Println("How should TraceKit be set up?")
+		ui.PrintMuted("   1 = Side by side: keep the existing tools and add TraceKit")
+		ui.PrintMuted("   2 = Migrate: send OpenTelemetry data to TraceKit and retire the other agents")
+		fmt.Println()
+		ui.PrintPrompt("Your choice (1):")
+
+		var response string
+		fmt.Scanln(&response)
+		switch strings.ToLower(strings.TrimSpace(response)) {
+		case "", "1", "side-by-side":
+			mode = existingSideBySide
+		case "2", "migrate":
+			mode = existingMigrate
+		default:
+			return "", fmt.Errorf("invalid choice %q", response)
+		}
+		fmt.Println()
+	default:
+		return "", fmt.Errorf("invalid --existing value %q (use %s or %s)", mode, existingSideBySide, existingMigrate)
+	}
+
+	printExistingToolChanges(found, mode, showPath)
+	return mode, nil
+}
+
+// printExistingToolChanges lists what init will change, and what is left for
+// the user to do, for the chosen mode
+func printExistingToolChanges(found []serviceVendors, mode string, showPath bool) {
+	ui.PrintInfo("Changes (" + mode + "):")
+
+	for _, sv := range found {
+		if showPath {
+			ui.PrintMuted(fmt.Sprintf("   %s:", sv.Service.Path))
+		}
+
+		var changes, manual []string
+		otel := hasVendor(sv.Vendors, "opentelemetry")
+
+		if mode == existingMigrate && otel {
+			changes = append(changes,
+				".env: set OTEL_EXPORTER_OTLP_ENDPOINT, _HEADERS, _PROTOCOL and OTEL_SERVICE_NAME to send to TraceKit",
+				".env: comment out other OTEL_EXPORTER_OTLP_* settings",
+				"Skip the TraceKit SDK: the existing OpenTelemetry SDK exports to TraceKit")
+		} else {
+			changes = append(changes, ".env: add TraceKit configuration", "Offer to install the TraceKit SDK")
+		}
+
+		for _, v := range sv.Vendors {
+			switch {
+			case !v.Tracing:
+				manual = append(manual, fmt.Sprintf("%s error tracking can stay; turn off its performance tracing to avoid duplicate traces", v.Name))
+			case mode == existingMigrate && v.Key != "opentelemetry":
+				for _, e := range v.Evidence {
+					manual = append(manual, fmt.Sprintf("Remove %s (%s)", e.Detail, e.File))
+				}
+			case mode == existingSideBySide && v.Key == "opentelemetry":
+				manual = append(manual, "OpenTelemetry and the TraceKit SDK will both trace requests; consider '--existing migrate'")
+			case mode == existingSideBySide:
+				manual = append(manual, fmt.Sprintf("%s also traces requests: disable its HTTP auto-instrumentation or expect duplicate spans", v.Name))
+			}
+		}
+
+		for _, c := range changes {
+			ui.PrintMuted("   ✓ " + c)
+		}
+		for _, m := range manual {
+			ui.PrintMuted("   • " + m)
+		}
+	}
+	fmt.Println()
+}
+
+// migratesToOTLP reports whether a service's existing OpenTelemetry SDK is
+// being pointed at TraceKit instead of installing the TraceKit SDK
+func migratesToOTLP(found []serviceVendors, mode string, svc detector.Service) bool {
+	if mode != existingMigrate {
+		return false
+	}
+	for _, sv := range found {
+		if sv.Service.Dir == svc.Dir {
+			return hasVendor(sv.Vendors, "opentelemetry")
+		}
+	}
+	return false
+}
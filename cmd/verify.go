@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/yourusername/context.io/cli/internal/client"
+	"github.com/yourusername/context.io/cli/internal/plan"
+	"github.com/yourusername/context.io/cli/internal/state"
+	"github.com/yourusername/context.io/cli/internal/ui"
+)
+
+// maxCodeAttempts is how many codes can be entered before giving up
+const maxCodeAttempts = 3
+
+// verifyEmail sends a verification code to req.Email (prompting for it when
+// empty) and exchanges the code for an API key. A code sent by an earlier
+// run that failed or was interrupted is resumed instead of sending another.
+// Wrong codes can be retried, "r" sends a new one, and after the last
+// attempt the session is kept so the command can be run again.
+func verifyEmail(apiClient *client.Client, req *client.RegisterRequest, command string) (*client.VerifyResponse, error) {
+	session := pendingSession(apiClient, req.Email)
+	if session != nil {
+		req.Email = session.Email
+	} else {
+		if req.Email == "" {
+			email, err := promptEmail()
+			if err != nil {
+				return nil, err
+			}
+			req.Email = email
+		}
+		var err error
+		if session, err = sendCode(apiClient, req); err != nil {
+			return nil, err
+		}
+	}
+
+	ui.PrintSection("🔑 Email Verification")
+	fmt.Println()
+	if plan.DryRun() {
+		ui.PrintMuted("   Skipped in a dry run")
+		fmt.Println()
+		return apiClient.Verify(&client.VerifyRequest{SessionID: session.SessionID, Code: plan.Placeholder("code")})
+	}
+	ui.PrintMuted("   Enter 'r' to send a new code")
+
+	expired := false
+	for attempt := 1; ; attempt++ {
+		ui.PrintPrompt("Enter 6-digit code:")
+		var code string
+		if _, err := fmt.Scanln(&code); errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("verification cancelled: no code entered")
+		}
+		code = strings.TrimSpace(code)
+		fmt.Println()
+
+		if strings.EqualFold(code, "r") {
+			var err error
+			if session, err = sendCode(apiClient, req); err != nil {
+				return nil, err
+			}
+			attempt, expired = 0, false
+			continue
+		}
+		if expired {
+			ui.PrintWarning("That code has expired; enter 'r' to send a new one")
+			fmt.Println()
+			attempt--
+			continue
+		}
+
+		ui.PrintInfo("Verifying...")
+		resp, err := apiClient.Verify(&client.VerifyRequest{SessionID: session.SessionID, Code: code})
+		if err == nil {
+			state.ClearSession()
+			return resp, nil
+		}
+
+		// Only a rejected code uses up an attempt
+		var statusErr *client.StatusError
+		if !errors.As(err, &statusErr) || statusErr.Retryable() {
+			ui.PrintWarning(fmt.Sprintf("Could not verify the code: %v", err))
+			ui.PrintMuted("   Enter it again to retry, or 'r' to send a new one")
+			fmt.Println()
+			attempt--
+			continue
+		}
+		if statusErr.StatusCode == http.StatusNotFound || statusErr.StatusCode == http.StatusGone {
+			// The session is gone, so no code for it can work
+			state.ClearSession()
+			expired = true
+			ui.PrintWarning("The verification code has expired")
+			ui.PrintMuted("   Enter 'r' to send a new one")
+			fmt.Println()
+			attempt--
+			continue
+		}
+		if attempt >= maxCodeAttempts {
+			return nil, fmt.Errorf("verification failed: %w (run '%s' again within %d minutes to retry this code, or enter 'r' for a new one)",
+				err, command, int(state.SessionTTL.Minutes()))
+		}
+		ui.PrintWarning(fmt.Sprintf("Verification failed: %v", err))
+		left := fmt.Sprintf("%d attempts left", maxCodeAttempts-attempt)
+		if maxCodeAttempts-attempt == 1 {
+			left = "1 attempt left"
+		}
+		ui.PrintMuted("   " + left + "; enter 'r' to send a new code")
+		fmt.Println()
+	}
+}
+
+// pendingSession returns a verification session from an earlier run that
+// the user chooses to resume, or nil
+func pendingSession(apiClient *client.Client, email string) *state.Session {
+	if plan.DryRun() {
+		return nil
+	}
+	session := state.LoadSession()
+	if session == nil || session.APIURL != apiClient.BaseURL {
+		return nil
+	}
+	if session.Expired() || (email != "" && !strings.EqualFold(email, session.Email)) {
+		state.ClearSession()
+		return nil
+	}
+
+	minutes := int(time.Since(session.Created).Minutes())
+	ui.PrintInfo(fmt.Sprintf("A verification code was sent to %s %d minutes ago", session.Email, minutes))
+	ui.PrintPrompt("Enter that code instead of requesting a new one? (Y/n):")
+	var response string
+	fmt.Scanln(&response)
+	response = strings.ToLower(strings.TrimSpace(response))
+	fmt.Println()
+
+	if response == "n" || response == "no" {
+		state.ClearSession()
+		return nil
+	}
+	return session
+}
+
+// sendCode registers req, which emails a verification code, and keeps the
+// session so a later run can resume it
+func sendCode(apiClient *client.Client, req *client.RegisterRequest) (*state.Session, error) {
+	resp, err := apiClient.Register(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send verification code: %w", err)
+	}
+	ui.PrintSuccess(fmt.Sprintf("Verification code sent to %s", req.Email))
+	fmt.Println()
+
+	session := &state.Session{
+		Email:     req.Email,
+		SessionID: resp.SessionID,
+		APIURL:    apiClient.BaseURL,
+		Created:   time.Now().UTC(),
+	}
+	if !plan.DryRun() {
+		if err := state.SaveSession(session); err != nil {
+			ui.PrintWarning(fmt.Sprintf("Could not save the verification session: %v", err))
+		}
+	}
+	return session, nil
+}
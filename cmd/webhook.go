@@ -13,7 +13,7 @@ Webhooks allow you to receive real-time notifications when events occur,
 such as health check failures, alerts being triggered, or traces with errors.
 
 Available subcommands:
-  create - Create a new webhook
+  create - Create a new webhook (interactively, from flags or from a file)
   list   - List all configured webhooks
   delete - Delete a webhook
 
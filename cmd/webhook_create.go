@@ -2,17 +2,20 @@ package cmd
 
 import (
 	"bufio"
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
+	"github.com/yourusername/context.io/cli/internal/client"
 	"github.com/yourusername/context.io/cli/internal/config"
+	"github.com/yourusername/context.io/cli/internal/keyring"
+	"github.com/yourusername/context.io/cli/internal/plan"
+	"github.com/yourusername/context.io/cli/internal/webhook"
 )
 
 var webhookCreateCmd = &cobra.Command{
@@ -20,17 +23,68 @@ var webhookCreateCmd = &cobra.Command{
 	Short: "Create a new webhook",
 	Long: `Create a new webhook to receive event notifications.
 
-You will be prompted to configure:
-- Webhook name
-- Destination URL
-- Event types to subscribe to
+The webhook is defined with flags, a JSON or YAML file (--from-file, or
+'-' for stdin), or both: flags override the file. Anything still missing
+is prompted for when stdin is a terminal; otherwise it is an error.
+
+A file holds the same fields as the flags:
+
+  name: deploy-alerts
+  url: https://example.com/hooks/tracekit
+  description: Page the on-call engineer
+  events: [health_check.failed, alert.triggered]
+
+The signing secret is only returned once. --secret-file writes it to a file
+(mode 0600) and --secret-keyring stores it in the system keyring (macOS
+Keychain, or libsecret through secret-tool on Linux) instead of printing it.
 
 Example:
-  tracekit webhook create`,
+  tracekit webhook create
+  tracekit webhook create --name alerts --url https://example.com/hook \
+    --event health_check.failed --event alert.triggered -o json
+  tracekit webhook create -f webhook.yaml --secret-file .webhook-secret`,
+	Args: cobra.NoArgs,
 	RunE: runWebhookCreate,
 }
 
+func init() {
+	webhookCreateCmd.Flags().String("name", "", "Webhook name")
+	webhookCreateCmd.Flags().String("url", "", "Destination URL (HTTPS, or HTTP for localhost)")
+	webhookCreateCmd.Flags().String("description", "", "Description")
+	webhookCreateCmd.Flags().StringSlice("event", nil, "Event to subscribe to (repeatable)")
+	webhookCreateCmd.Flags().StringP("from-file", "f", "", "Read the webhook from a JSON or YAML file ('-' for stdin)")
+	webhookCreateCmd.Flags().StringP("output", "o", "text", "Output format: text, json")
+	webhookCreateCmd.Flags().String("secret-file", "", "Write the signing secret to this file instead of printing it")
+	webhookCreateCmd.Flags().Bool("secret-keyring", false, "Store the signing secret in the system keyring instead of printing it")
+
+	// The event catalog, shown in the help
+	var events strings.Builder
+	events.WriteString("\n\nEvents:\n")
+	for _, e := range webhook.Events {
+		fmt.Fprintf(&events, "  %-24s %s\n", e.Name, e.Description)
+	}
+	webhookCreateCmd.Long += strings.TrimSuffix(events.String(), "\n")
+}
+
+// createdWebhook is the JSON output of 'webhook create'
+type createdWebhook struct {
+	*client.Webhook
+	SecretFile    string `json:"secret_file,omitempty"`
+	SecretKeyring string `json:"secret_keyring,omitempty"` // service/account
+}
+
 func runWebhookCreate(cmd *cobra.Command, args []string) error {
+	output, _ := cmd.Flags().GetString("output")
+	if err := validateOutputFormat(output, "text", "json"); err != nil {
+		return err
+	}
+	fromFile, _ := cmd.Flags().GetString("from-file")
+	secretFile, _ := cmd.Flags().GetString("secret-file")
+	useKeyring, _ := cmd.Flags().GetBool("secret-keyring")
+	if secretFile != "" && useKeyring {
+		return fmt.Errorf("use either --secret-file or --secret-keyring")
+	}
+
 	// Load config
 	cfg, err := config.Read()
 	if err != nil {
@@ -46,121 +100,85 @@ func runWebhookCreate(cmd *cobra.Command, args []string) error {
 	if useDev {
 		cfg.Endpoint = "http://localhost:8081"
 	}
+	applyAPIURLFlag(cmd, cfg)
 
-	reader := bufio.NewReader(os.Stdin)
-
-	// Get webhook name
-	fmt.Print("\n🔗 Webhook name: ")
-	name, _ := reader.ReadString('\n')
-	name = strings.TrimSpace(name)
-	if name == "" {
-		return fmt.Errorf("webhook name is required")
-	}
-
-	// Get webhook URL
-	if useDev {
-		fmt.Print("📡 Webhook URL (http:// or https://): ")
-	} else {
-		fmt.Print("📡 Webhook URL (HTTPS required, or HTTP for localhost): ")
+	// Read the definition, then let flags override it
+	spec := &webhook.Spec{}
+	if fromFile != "" {
+		if spec, err = webhook.Load(fromFile); err != nil {
+			return err
+		}
 	}
-	url, _ := reader.ReadString('\n')
-	url = strings.TrimSpace(url)
-	if url == "" {
-		return fmt.Errorf("webhook URL is required")
+	if cmd.Flags().Changed("name") {
+		spec.Name, _ = cmd.Flags().GetString("name")
 	}
-
-	// Validate URL format
-	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
-		return fmt.Errorf("webhook URL must start with https:// or http://")
+	if cmd.Flags().Changed("url") {
+		spec.URL, _ = cmd.Flags().GetString("url")
 	}
-
-	// Enforce HTTPS for non-localhost URLs (unless in dev mode)
-	if !useDev && !strings.HasPrefix(url, "https://") && !strings.Contains(url, "localhost") && !strings.Contains(url, "127.0.0.1") {
-		return fmt.Errorf("webhook URL must use HTTPS for non-localhost URLs (got: %s)", url)
+	descriptionSet := cmd.Flags().Changed("description") || spec.Description != ""
+	if cmd.Flags().Changed("description") {
+		spec.Description, _ = cmd.Flags().GetString("description")
 	}
-
-	// Get description (optional)
-	fmt.Print("📝 Description (optional): ")
-	description, _ := reader.ReadString('\n')
-	description = strings.TrimSpace(description)
-
-	// Show available events
-	availableEvents := []string{
-		"health_check.failed",
-		"health_check.recovered",
-		"alert.triggered",
-		"alert.resolved",
-		"trace.error",
-		"anomaly.detected",
-	}
-
-	fmt.Println("\n📋 Available event types:")
-	for i, event := range availableEvents {
-		fmt.Printf("  %d. %s\n", i+1, event)
+	if cmd.Flags().Changed("event") {
+		spec.Events, _ = cmd.Flags().GetStringSlice("event")
 	}
 
-	// Get event selection
-	fmt.Print("\n🎯 Select events (comma-separated numbers, e.g., 1,3,4): ")
-	eventsInput, _ := reader.ReadString('\n')
-	eventsInput = strings.TrimSpace(eventsInput)
-
-	// Parse selected events
-	selectedEvents := []string{}
-	if eventsInput != "" {
-		selections := strings.Split(eventsInput, ",")
-		for _, sel := range selections {
-			sel = strings.TrimSpace(sel)
-			var eventNum int
-			fmt.Sscanf(sel, "%d", &eventNum)
-			if eventNum >= 1 && eventNum <= len(availableEvents) {
-				selectedEvents = append(selectedEvents, availableEvents[eventNum-1])
-			}
+	// Check what was given before prompting for the rest
+	if spec.URL != "" {
+		if err := webhook.ValidateURL(spec.URL, useDev); err != nil {
+			return err
 		}
 	}
-
-	if len(selectedEvents) == 0 {
-		return fmt.Errorf("at least one event must be selected")
+	if len(spec.Events) > 0 {
+		if err := webhook.ValidateEvents(spec.Events); err != nil {
+			return err
+		}
 	}
 
-	// Create webhook payload
-	payload := map[string]interface{}{
-		"name":        name,
-		"url":         url,
-		"description": description,
-		"events":      selectedEvents,
+	// Prompts stay off stdout when it carries JSON
+	var prompts io.Writer = os.Stdout
+	if output == "json" {
+		prompts = os.Stderr
 	}
-
-	payloadBytes, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("failed to create payload: %w", err)
+	interactive := fromFile != "-" && isatty.IsTerminal(os.Stdin.Fd())
+	if err := promptWebhookSpec(spec, !descriptionSet, useDev, interactive, prompts); err != nil {
+		return err
 	}
 
-	// Send request
-	req, err := http.NewRequest("POST", cfg.GetAPIBase()+"/v1/webhooks", bytes.NewBuffer(payloadBytes))
+	apiClient := client.NewClient(cfg.GetAPIBase())
+	apiClient.APIKey = cfg.APIKey
+	created, err := apiClient.CreateWebhook(&client.WebhookRequest{
+		Name:        spec.Name,
+		URL:         spec.URL,
+		Description: spec.Description,
+		Events:      spec.Events,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("X-API-Key", cfg.APIKey)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to create webhook: %w", err)
+	// Keep the secret out of the output when it is saved elsewhere
+	result := createdWebhook{Webhook: created}
+	secret := created.Secret
+	if secret != "" && (secretFile != "" || useKeyring) {
+		if err := saveWebhookSecret(&result, secretFile); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Could not save the webhook secret: %v\n", err)
+		} else {
+			hidden := *created
+			hidden.Secret = ""
+			result.Webhook = &hidden
+		}
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 
-	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("failed to create webhook: %s", string(body))
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
 	}
 
-	// Parse response
-	var result map[string]interface{}
-	if err := json.Unmarshal(body, &result); err != nil {
-		return fmt.Errorf("failed to parse response: %w", err)
+	if plan.DryRun() {
+		fmt.Println("\nDry run: webhook not created")
+		return nil
 	}
 
 	// Display success with secret
@@ -168,13 +186,17 @@ func runWebhookCreate(cmd *cobra.Command, args []string) error {
 	yellow := color.New(color.FgYellow, color.Bold)
 
 	fmt.Println("\n✅ Webhook created successfully!")
-	fmt.Printf("\n📦 Webhook ID: %s\n", result["id"])
-	fmt.Printf("🔗 Name: %s\n", result["name"])
-	fmt.Printf("📡 URL: %s\n", result["url"])
+	fmt.Printf("\n📦 Webhook ID: %s\n", created.ID)
+	fmt.Printf("🔗 Name: %s\n", created.Name)
+	fmt.Printf("📡 URL: %s\n", created.URL)
 
 	// Show secret prominently
-	secret := result["secret"]
-	if secret != nil {
+	switch {
+	case result.SecretFile != "":
+		fmt.Printf("\n🔐 Secret saved to %s\n", result.SecretFile)
+	case result.SecretKeyring != "":
+		fmt.Printf("\n🔐 Secret stored in the system keyring (service %s, account %s)\n", keyring.Service, webhookKeyringAccount(created.ID))
+	case secret != "":
 		yellow.Println("\n⚠️  IMPORTANT: Save this secret securely!")
 		fmt.Printf("🔐 Secret: %s\n", secret)
 		yellow.Println("\nThis secret will only be shown once. You'll need it to verify webhook signatures.")
@@ -190,9 +212,101 @@ func runWebhookCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("\n📋 Subscribed events:\n")
-	for _, event := range selectedEvents {
+	for _, event := range created.Events {
 		green.Printf("  ✓ %s\n", event)
 	}
 
 	return nil
 }
+
+// promptWebhookSpec asks for the values spec is missing. Without a terminal
+// to ask on, a missing value is an error naming its flag.
+func promptWebhookSpec(spec *webhook.Spec, askDescription, useDev, interactive bool, out io.Writer) error {
+	missing := spec.Name == "" || spec.URL == "" || len(spec.Events) == 0
+	if !missing {
+		return nil
+	}
+	if !interactive {
+		switch {
+		case spec.Name == "":
+			return fmt.Errorf("webhook name is required (--name)")
+		case spec.URL == "":
+			return fmt.Errorf("webhook URL is required (--url)")
+		default:
+			return fmt.Errorf("at least one event must be selected (--event)")
+		}
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+
+	// Get webhook name
+	if spec.Name == "" {
+		fmt.Fprint(out, "\n🔗 Webhook name: ")
+		name, _ := reader.ReadString('\n')
+		spec.Name = strings.TrimSpace(name)
+		if spec.Name == "" {
+			return fmt.Errorf("webhook name is required")
+		}
+	}
+
+	// Get webhook URL
+	if spec.URL == "" {
+		if useDev {
+			fmt.Fprint(out, "📡 Webhook URL (http:// or https://): ")
+		} else {
+			fmt.Fprint(out, "📡 Webhook URL (HTTPS required, or HTTP for localhost): ")
+		}
+		url, _ := reader.ReadString('\n')
+		spec.URL = strings.TrimSpace(url)
+		if err := webhook.ValidateURL(spec.URL, useDev); err != nil {
+			return err
+		}
+	}
+
+	// Get description (optional)
+	if askDescription {
+		fmt.Fprint(out, "📝 Description (optional): ")
+		description, _ := reader.ReadString('\n')
+		spec.Description = strings.TrimSpace(description)
+	}
+
+	// Get event selection
+	if len(spec.Events) == 0 {
+		fmt.Fprintln(out, "\n📋 Available event types:")
+		for i, e := range webhook.Events {
+			fmt.Fprintf(out, "  %d. %-24s %s\n", i+1, e.Name, e.Description)
+		}
+		fmt.Fprint(out, "\n🎯 Select events (comma-separated numbers or names, e.g., 1,3,4): ")
+		input, _ := reader.ReadString('\n')
+		events, err := webhook.ParseSelection(input)
+		if err != nil {
+			return err
+		}
+		spec.Events = events
+	}
+	return nil
+}
+
+// saveWebhookSecret writes the new webhook's secret to path, or to the
+// system keyring when path is empty, noting where in result
+func saveWebhookSecret(result *createdWebhook, path string) error {
+	if path != "" {
+		if err := plan.WriteFile("save webhook secret", path, []byte(result.Secret+"\n"), 0600); err != nil {
+			return err
+		}
+		result.SecretFile = path
+		return nil
+	}
+
+	account := webhookKeyringAccount(result.ID)
+	if err := keyring.Set("save webhook secret", account, "TraceKit webhook "+result.Name, result.Secret); err != nil {
+		return err
+	}
+	result.SecretKeyring = keyring.Service + "/" + account
+	return nil
+}
+
+// webhookKeyringAccount is the keyring account holding a webhook's secret
+func webhookKeyringAccount(id string) string {
+	return "webhook-" + id
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/yourusername/context.io/cli/internal/config"
+	"github.com/yourusername/context.io/cli/internal/plan"
 )
 
 var webhookDeleteCmd = &cobra.Command{
@@ -44,6 +45,7 @@ func runWebhookDelete(cmd *cobra.Command, args []string) error {
 	if useDev {
 		cfg.Endpoint = "http://localhost:8081"
 	}
+	applyAPIURLFlag(cmd, cfg)
 
 	// Confirm deletion
 	reader := bufio.NewReader(os.Stdin)
@@ -59,6 +61,10 @@ func runWebhookDelete(cmd *cobra.Command, args []string) error {
 	}
 
 	// Send request
+	if plan.Request("delete webhook", "DELETE", cfg.GetAPIBase()+"/v1/webhooks/"+webhookID, nil) {
+		fmt.Println("Dry run: webhook not deleted")
+		return nil
+	}
 	req, err := http.NewRequest("DELETE", cfg.GetAPIBase()+"/v1/webhooks/"+webhookID, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
@@ -40,6 +40,7 @@ func runWebhookList(cmd *cobra.Command, args []string) error {
 	if useDev {
 		cfg.Endpoint = "http://localhost:8081"
 	}
+	applyAPIURLFlag(cmd, cfg)
 
 	// Send request
 	req, err := http.NewRequest("GET", cfg.GetAPIBase()+"/v1/webhooks", nil)
@@ -6,7 +6,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
+
+	"github.com/yourusername/context.io/cli/internal/plan"
+	"github.com/yourusername/context.io/cli/internal/trace"
 )
 
 const (
@@ -74,6 +79,35 @@ type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// StatusError is returned when the API responds with an unexpected status
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
+}
+
+// Retryable reports whether the request may succeed if retried later
+func (e *StatusError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// Unauthorized reports whether the API key was missing, invalid or revoked
+func (e *StatusError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// newStatusError reads the API's error message from an error response body
+func newStatusError(statusCode int, body []byte) *StatusError {
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error != "" {
+		return &StatusError{StatusCode: statusCode, Message: errResp.Error}
+	}
+	return &StatusError{StatusCode: statusCode, Message: string(body)}
+}
+
 // Register creates a new account and sends verification code
 func (c *Client) Register(req *RegisterRequest) (*RegisterResponse, error) {
 	body, err := json.Marshal(req)
@@ -81,6 +115,10 @@ func (c *Client) Register(req *RegisterRequest) (*RegisterResponse, error) {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	if plan.Request("create account", "POST", c.BaseURL+"/v1/integrate/register", body) {
+		return &RegisterResponse{VerificationRequired: true, SessionID: plan.Placeholder("session")}, nil
+	}
+
 	httpReq, err := http.NewRequest("POST", c.BaseURL+"/v1/integrate/register", bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -103,11 +141,7 @@ func (c *Client) Register(req *RegisterRequest) (*RegisterResponse, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(respBody, &errResp); err == nil {
-			return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, errResp.Error)
-		}
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+		return nil, newStatusError(resp.StatusCode, respBody)
 	}
 
 	var registerResp RegisterResponse
@@ -125,6 +159,10 @@ func (c *Client) Verify(req *VerifyRequest) (*VerifyResponse, error) {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	if plan.Request("verify email and create API key", "POST", c.BaseURL+"/v1/integrate/verify", body) {
+		return &VerifyResponse{APIKey: plan.Placeholder("api key"), ServiceName: plan.Placeholder("service"), DashboardURL: DefaultBaseURL}, nil
+	}
+
 	httpReq, err := http.NewRequest("POST", c.BaseURL+"/v1/integrate/verify", bytes.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -144,11 +182,7 @@ func (c *Client) Verify(req *VerifyRequest) (*VerifyResponse, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(respBody, &errResp); err == nil {
-			return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, errResp.Error)
-		}
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+		return nil, newStatusError(resp.StatusCode, respBody)
 	}
 
 	var verifyResp VerifyResponse
@@ -184,11 +218,7 @@ func (c *Client) GetStatus() (map[string]interface{}, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(respBody, &errResp); err == nil {
-			return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, errResp.Error)
-		}
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+		return nil, newStatusError(resp.StatusCode, respBody)
 	}
 
 	var status map[string]interface{}
@@ -199,16 +229,113 @@ func (c *Client) GetStatus() (map[string]interface{}, error) {
 	return status, nil
 }
 
-// PostHealthCheck creates a new health check configuration
-func (c *Client) PostHealthCheck(apiURL, apiKey string, requestBody map[string]interface{}) error {
+// GetSDKCatalog fetches the signed SDK catalog, returning the response body
+// for the sdk package to verify (no API key required)
+func (c *Client) GetSDKCatalog() ([]byte, error) {
+	resp, err := c.HTTPClient.Get(c.BaseURL + "/v1/sdk/catalog")
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return respBody, nil
+}
+
+// PublicKeyRequest is the request body for creating a public ingest key
+type PublicKeyRequest struct {
+	ServiceName    string   `json:"service_name"`
+	AllowedOrigins []string `json:"allowed_origins,omitempty"`
+}
+
+// PublicKeyResponse is a public ingest key. It can only send browser
+// telemetry for its service, so it is safe to ship in frontend code.
+type PublicKeyResponse struct {
+	PublicKey   string `json:"public_key"`
+	ServiceName string `json:"service_name"`
+}
+
+// CreatePublicKey creates a public ingest key for browser monitoring
+// (requires API key)
+func (c *Client) CreatePublicKey(req *PublicKeyRequest) (*PublicKeyResponse, error) {
+	if body, err := json.Marshal(req); err == nil && plan.Request("create public ingest key", "POST", c.BaseURL+"/v1/integrate/public-keys", body) {
+		return &PublicKeyResponse{PublicKey: plan.Placeholder("public key"), ServiceName: req.ServiceName}, nil
+	}
+
+	var keyResp PublicKeyResponse
+	if err := c.doJSON("POST", "/v1/integrate/public-keys", req, http.StatusCreated, &keyResp); err != nil {
+		return nil, err
+	}
+
+	return &keyResp, nil
+}
+
+// WebhookRequest is the request body for creating a webhook
+type WebhookRequest struct {
+	Name        string   `json:"name"`
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	Events      []string `json:"events"`
+}
+
+// Webhook is a webhook configured in TraceKit. Secret signs its deliveries
+// and is only returned when the webhook is created.
+type Webhook struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	URL         string   `json:"url"`
+	Description string   `json:"description,omitempty"`
+	Events      []string `json:"events"`
+	Enabled     bool     `json:"enabled"`
+	Status      string   `json:"status,omitempty"`
+	Secret      string   `json:"secret,omitempty"`
+	CreatedAt   string   `json:"created_at,omitempty"`
+}
+
+// CreateWebhook creates a webhook (requires API key)
+func (c *Client) CreateWebhook(req *WebhookRequest) (*Webhook, error) {
+	if body, err := json.Marshal(req); err == nil && plan.Request("create webhook", "POST", c.BaseURL+"/v1/webhooks", body) {
+		return &Webhook{
+			ID:          plan.Placeholder("webhook id"),
+			Name:        req.Name,
+			URL:         req.URL,
+			Description: req.Description,
+			Events:      req.Events,
+			Enabled:     true,
+			Secret:      plan.Placeholder("webhook secret"),
+		}, nil
+	}
+
+	var webhook Webhook
+	if err := c.doJSON("POST", "/v1/webhooks", req, http.StatusCreated, &webhook); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return &webhook, nil
+}
+
+// PostHealthCheck creates a new health check configuration, returning its ID
+func (c *Client) PostHealthCheck(apiURL, apiKey string, requestBody map[string]interface{}) (string, error) {
 	body, err := json.Marshal(requestBody)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	if plan.Request("create health check", "POST", apiURL+"/api/health-checks", body) {
+		return plan.Placeholder("health check id"), nil
 	}
 
 	httpReq, err := http.NewRequest("POST", apiURL+"/api/health-checks", bytes.NewReader(body))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
@@ -216,21 +343,223 @@ func (c *Client) PostHealthCheck(apiURL, apiKey string, requestBody map[string]i
 
 	resp, err := c.HTTPClient.Do(httpReq)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return "", fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return "", fmt.Errorf("failed to read response: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(respBody, &errResp); err == nil {
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, errResp.Error)
+		return "", newStatusError(resp.StatusCode, respBody)
+	}
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	if created.ID == "" {
+		return "", fmt.Errorf("health check created without an ID")
+	}
+
+	return created.ID, nil
+}
+
+// HealthCheck is a health check configured in TraceKit
+type HealthCheck struct {
+	ServiceName string `json:"service_name"`
+	CheckName   string `json:"check_name"`
+	CheckType   string `json:"check_type"`
+}
+
+// ListHealthChecks returns the health checks configured for the account
+func (c *Client) ListHealthChecks(apiURL, apiKey string) ([]HealthCheck, error) {
+	httpReq, err := http.NewRequest("GET", apiURL+"/api/health-checks", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("X-API-Key", apiKey)
+
+	resp, err := c.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newStatusError(resp.StatusCode, respBody)
+	}
+
+	var result struct {
+		HealthChecks []HealthCheck `json:"health_checks"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result.HealthChecks, nil
+}
+
+// DeleteResource deletes a resource by the API URL recorded when it was
+// created. A resource that is already gone counts as deleted.
+func (c *Client) DeleteResource(reason, url, apiKey string) error {
+	if plan.Request(reason, "DELETE", url, nil) {
+		return nil
+	}
+
+	httpReq, err := http.NewRequest("DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("X-API-Key", apiKey)
+
+	resp, err := c.HTTPClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	}
+	respBody, _ := io.ReadAll(resp.Body)
+	return newStatusError(resp.StatusCode, respBody)
+}
+
+// TraceSearchRequest holds the filters for a trace search
+type TraceSearchRequest struct {
+	Service     string
+	Since       time.Duration
+	Status      string // "ok", "error" or empty for any
+	MinDuration time.Duration
+	Name        string
+	Limit       int
+}
+
+// TraceSummary is a single trace in search results
+type TraceSummary struct {
+	TraceID     string    `json:"trace_id"`
+	RootName    string    `json:"root_name"`
+	ServiceName string    `json:"service_name"`
+	StartTime   time.Time `json:"start_time"`
+	DurationMs  float64   `json:"duration_ms"`
+	SpanCount   int       `json:"span_count"`
+	ErrorCount  int       `json:"error_count"`
+	Status      string    `json:"status"`
+}
+
+// TraceSearchResponse is the response from a trace search
+type TraceSearchResponse struct {
+	Traces []TraceSummary `json:"traces"`
+	Total  int            `json:"total"`
+}
+
+// TraceResponse is a full trace with all of its spans
+type TraceResponse struct {
+	TraceID string       `json:"trace_id"`
+	Spans   []trace.Span `json:"spans"`
+}
+
+// SearchTraces queries recent traces matching the given filters (requires API key)
+func (c *Client) SearchTraces(req *TraceSearchRequest) (*TraceSearchResponse, error) {
+	query := url.Values{}
+	if req.Service != "" {
+		query.Set("service", req.Service)
+	}
+	if req.Since > 0 {
+		query.Set("since", time.Now().Add(-req.Since).UTC().Format(time.RFC3339))
+	}
+	if req.Status != "" {
+		query.Set("status", req.Status)
+	}
+	if req.MinDuration > 0 {
+		query.Set("min_duration_ms", strconv.FormatInt(req.MinDuration.Milliseconds(), 10))
+	}
+	if req.Name != "" {
+		query.Set("name", req.Name)
+	}
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	var searchResp TraceSearchResponse
+	if err := c.doJSON("GET", "/v1/traces/search?"+query.Encode(), nil, http.StatusOK, &searchResp); err != nil {
+		return nil, err
+	}
+
+	return &searchResp, nil
+}
+
+// GetTrace fetches a single trace with all of its spans (requires API key)
+func (c *Client) GetTrace(traceID string) (*TraceResponse, error) {
+	if traceID == "" {
+		return nil, fmt.Errorf("trace ID required")
+	}
+
+	var traceResp TraceResponse
+	if err := c.doJSON("GET", "/v1/traces/"+url.PathEscape(traceID), nil, http.StatusOK, &traceResp); err != nil {
+		return nil, err
+	}
+
+	return &traceResp, nil
+}
+
+// doJSON sends an authenticated request with an optional JSON body and decodes
+// the JSON response into out (if non-nil)
+func (c *Client) doJSON(method, path string, reqBody interface{}, expectedStatus int, out interface{}) error {
+	if c.APIKey == "" {
+		return fmt.Errorf("API key required")
+	}
+
+	var bodyReader io.Reader
+	if reqBody != nil {
+		body, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
 		}
-		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+		bodyReader = bytes.NewReader(body)
+	}
+
+	httpReq, err := http.NewRequest(method, c.BaseURL+path, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if reqBody != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpReq.Header.Set("X-API-Key", c.APIKey)
+
+	resp, err := c.HTTPClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != expectedStatus {
+		return newStatusError(resp.StatusCode, respBody)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/context.io/cli/internal/trace"
+)
+
+// StreamRequest holds the filters for a live span stream
+type StreamRequest struct {
+	Service     string
+	ErrorsOnly  bool
+	Route       string
+	MinDuration time.Duration
+	LastEventID string // Resume after this event when reconnecting
+}
+
+// StreamSpans opens a server-sent event stream of new spans and calls fn for
+// each one until ctx is cancelled, the server closes the stream or fn returns
+// an error, which is returned as a *HandlerError. It returns the ID of the
+// last event received so callers can resume the stream after a reconnect.
+func (c *Client) StreamSpans(ctx context.Context, req *StreamRequest, fn func(trace.Span) error) (string, error) {
+	if c.APIKey == "" {
+		return req.LastEventID, fmt.Errorf("API key required")
+	}
+
+	query := url.Values{}
+	if req.Service != "" {
+		query.Set("service", req.Service)
+	}
+	if req.ErrorsOnly {
+		query.Set("status", "error")
+	}
+	if req.Route != "" {
+		query.Set("route", req.Route)
+	}
+	if req.MinDuration > 0 {
+		query.Set("min_duration_ms", strconv.FormatInt(req.MinDuration.Milliseconds(), 10))
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/v1/traces/stream?"+query.Encode(), nil)
+	if err != nil {
+		return req.LastEventID, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Accept", "text/event-stream")
+	httpReq.Header.Set("Cache-Control", "no-cache")
+	httpReq.Header.Set("X-API-Key", c.APIKey)
+	if req.LastEventID != "" {
+		httpReq.Header.Set("Last-Event-ID", req.LastEventID)
+	}
+
+	// Streams stay open indefinitely, so don't use the client's request timeout
+	streamClient := &http.Client{Transport: c.HTTPClient.Transport}
+
+	resp, err := streamClient.Do(httpReq)
+	if err != nil {
+		return req.LastEventID, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return req.LastEventID, newStatusError(resp.StatusCode, respBody)
+	}
+
+	lastEventID := req.LastEventID
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	var eventType string
+	var data strings.Builder
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			// Blank line dispatches the buffered event
+			if data.Len() > 0 && (eventType == "" || eventType == "span") {
+				var span trace.Span
+				if err := json.Unmarshal([]byte(data.String()), &span); err == nil {
+					if err := fn(span); err != nil {
+						return lastEventID, &HandlerError{Err: err}
+					}
+				}
+			}
+			eventType = ""
+			data.Reset()
+
+		case strings.HasPrefix(line, ":"):
+			// Comment / keep-alive
+
+		case strings.HasPrefix(line, "id:"):
+			lastEventID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+
+		case strings.HasPrefix(line, "event:"):
+			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteString("\n")
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return lastEventID, fmt.Errorf("stream interrupted: %w", err)
+	}
+
+	return lastEventID, ctx.Err()
+}
+
+// HandlerError is an error from the StreamSpans callback rather than the
+// connection, so reconnecting won't help
+type HandlerError struct {
+	Err error
+}
+
+func (e *HandlerError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
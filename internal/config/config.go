@@ -3,7 +3,10 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+
+	"github.com/yourusername/context.io/cli/internal/plan"
 )
 
 // Config represents TraceKit configuration
@@ -13,14 +16,49 @@ type Config struct {
 	ServiceName           string
 	Enabled               string
 	CodeMonitoringEnabled string
+	OTLPExport            bool   // Point an existing OpenTelemetry SDK's exporter at TraceKit
+	WebhookID             string // Webhook created by 'tracekit init' (not part of the TraceKit block)
+}
+
+// otlpKeys are the OpenTelemetry variables written to the TraceKit block when
+// OTLPExport is set
+var otlpKeys = []string{
+	"OTEL_EXPORTER_OTLP_ENDPOINT",
+	"OTEL_EXPORTER_OTLP_HEADERS",
+	"OTEL_EXPORTER_OTLP_PROTOCOL",
+	"OTEL_SERVICE_NAME",
+}
+
+// EnvVar is a variable in the TraceKit configuration block
+type EnvVar struct {
+	Name   string
+	Value  string
+	Secret bool // Holds a credential; keep it out of images and committed files
+}
+
+// EnvVars returns the variables written to the TraceKit block, in order
+func (c *Config) EnvVars() []EnvVar {
+	vars := []EnvVar{
+		{Name: "TRACEKIT_API_KEY", Value: c.APIKey, Secret: true},
+		{Name: "TRACEKIT_ENDPOINT", Value: c.Endpoint},
+		{Name: "TRACEKIT_SERVICE_NAME", Value: c.ServiceName},
+		{Name: "TRACEKIT_ENABLED", Value: c.Enabled},
+		{Name: "TRACEKIT_CODE_MONITORING_ENABLED", Value: c.CodeMonitoringEnabled},
+	}
+	if c.OTLPExport {
+		vars = append(vars,
+			EnvVar{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: c.GetAPIBase()},
+			EnvVar{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "X-API-Key=" + c.APIKey, Secret: true},
+			EnvVar{Name: "OTEL_EXPORTER_OTLP_PROTOCOL", Value: "http/protobuf"},
+			EnvVar{Name: "OTEL_SERVICE_NAME", Value: c.ServiceName},
+		)
+	}
+	return vars
 }
 
 // GetTraceEndpoint returns the full trace ingestion endpoint
 func (c *Config) GetTraceEndpoint() string {
-	if c.Endpoint == "" {
-		return "https://app.tracekit.dev/v1/traces"
-	}
-	return c.Endpoint + "/v1/traces"
+	return c.GetAPIBase() + "/v1/traces"
 }
 
 // GetAPIBase returns the base API URL for v1 endpoints
@@ -28,12 +66,18 @@ func (c *Config) GetAPIBase() string {
 	if c.Endpoint == "" {
 		return "https://app.tracekit.dev"
 	}
-	return c.Endpoint
+	// Older configs (and 'tracekit login') store the full trace endpoint
+	return strings.TrimSuffix(strings.TrimSuffix(c.Endpoint, "/"), "/v1/traces")
 }
 
 // Read reads TraceKit configuration from .env file
 func Read() (*Config, error) {
-	envPath := ".env"
+	return ReadDir(".")
+}
+
+// ReadDir reads TraceKit configuration from the .env file in dir
+func ReadDir(dir string) (*Config, error) {
+	envPath := filepath.Join(dir, ".env")
 
 	// Check if file exists
 	if _, err := os.Stat(envPath); os.IsNotExist(err) {
@@ -48,6 +92,7 @@ func Read() (*Config, error) {
 
 	// Parse config
 	config := &Config{}
+	otlpEndpoint := ""
 	lines := strings.Split(string(content), "\n")
 
 	for _, line := range lines {
@@ -78,9 +123,16 @@ func Read() (*Config, error) {
 			config.Enabled = value
 		case "TRACEKIT_CODE_MONITORING_ENABLED":
 			config.CodeMonitoringEnabled = value
+		case "OTEL_EXPORTER_OTLP_ENDPOINT":
+			otlpEndpoint = value
+		case "TRACEKIT_WEBHOOK_ID":
+			config.WebhookID = value
 		}
 	}
 
+	// OpenTelemetry export is on when the exporter points at TraceKit
+	config.OTLPExport = otlpEndpoint != "" && otlpEndpoint == config.GetAPIBase()
+
 	// Validate required fields
 	if config.APIKey == "" {
 		return nil, fmt.Errorf("TRACEKIT_API_KEY not found in .env")
@@ -91,17 +143,18 @@ func Read() (*Config, error) {
 
 // Save writes TraceKit configuration to .env file
 func Save(config *Config) error {
-	envPath := ".env"
+	return SaveDir(".", config)
+}
+
+// SaveDir writes TraceKit configuration to the .env file in dir
+func SaveDir(dir string, config *Config) error {
+	envPath := filepath.Join(dir, ".env")
 
 	// TraceKit config block
-	tracekitConfig := fmt.Sprintf(`
-# TraceKit Configuration
-TRACEKIT_API_KEY=%s
-TRACEKIT_ENDPOINT=%s
-TRACEKIT_SERVICE_NAME=%s
-TRACEKIT_ENABLED=%s
-TRACEKIT_CODE_MONITORING_ENABLED=%s
-`, config.APIKey, config.Endpoint, config.ServiceName, config.Enabled, config.CodeMonitoringEnabled)
+	tracekitConfig := "\n# TraceKit Configuration\n"
+	for _, v := range config.EnvVars() {
+		tracekitConfig += v.Name + "=" + v.Value + "\n"
+	}
 
 	// Check if .env exists
 	var existingContent string
@@ -112,43 +165,183 @@ TRACEKIT_CODE_MONITORING_ENABLED=%s
 			return err
 		}
 		existingContent = string(content)
+	}
+
+	// Replace any existing TraceKit section (TRACEKIT_ and OTLP exporter settings)
+	existingContent = replaceBlock(existingContent, "# TraceKit Configuration", tracekitConfig, isBlockLine)
+
+	// The block's OTLP settings replace any set elsewhere in the file
+	if config.OTLPExport {
+		existingContent = commentOutOTLP(existingContent)
+	}
+
+	// Write to file
+	return plan.WriteFile("save TraceKit configuration", envPath, []byte(existingContent), 0644)
+}
+
+// replaceBlock removes the block starting at the header line (the header and
+// the following lines inBlock accepts, plus blank lines) and appends block
+func replaceBlock(content, header, block string, inBlock func(line string) bool) string {
+	if !strings.Contains(content, header) {
+		return content + block
+	}
+
+	lines := strings.Split(content, "\n")
+	var newLines []string
+	skipUntilNextSection := false
 
-		// Check if TraceKit config already exists
-		if strings.Contains(existingContent, "# TraceKit Configuration") {
-			// Replace existing TraceKit section
-			lines := strings.Split(existingContent, "\n")
-			var newLines []string
-			skipUntilNextSection := false
-
-			for _, line := range lines {
-				if strings.Contains(line, "# TraceKit Configuration") {
-					skipUntilNextSection = true
-					continue
-				}
-				if skipUntilNextSection {
-					// Skip lines that start with TRACEKIT_
-					if strings.HasPrefix(strings.TrimSpace(line), "TRACEKIT_") {
-						continue
-					}
-					// Stop skipping when we hit a non-TraceKit line
-					if strings.TrimSpace(line) != "" && !strings.HasPrefix(strings.TrimSpace(line), "TRACEKIT_") {
-						skipUntilNextSection = false
-					}
-				}
-				if !skipUntilNextSection {
-					newLines = append(newLines, line)
-				}
+	for _, line := range lines {
+		if strings.Contains(line, header) {
+			skipUntilNextSection = true
+			continue
+		}
+		if skipUntilNextSection {
+			if inBlock(line) {
+				continue
 			}
-			existingContent = strings.Join(newLines, "\n") + tracekitConfig
-		} else {
-			// Append TraceKit config
-			existingContent += tracekitConfig
+			// Stop skipping when we hit a line from outside the block
+			if strings.TrimSpace(line) != "" {
+				skipUntilNextSection = false
+			}
+		}
+		if !skipUntilNextSection {
+			newLines = append(newLines, line)
 		}
-	} else {
-		// File doesn't exist, create new with TraceKit config
-		existingContent = tracekitConfig
 	}
+	return strings.Join(newLines, "\n") + block
+}
 
-	// Write to file
-	return os.WriteFile(envPath, []byte(existingContent), 0644)
+// isBlockLine reports whether line is a setting written in the TraceKit block
+func isBlockLine(line string) bool {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "TRACEKIT_") {
+		return true
+	}
+	for _, key := range otlpKeys {
+		if strings.HasPrefix(line, key+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// commentOutOTLP comments out OTLP settings outside the TraceKit block so the
+// block's values take effect
+func commentOutOTLP(content string) string {
+	lines := strings.Split(content, "\n")
+	inBlock := false
+	for i, line := range lines {
+		if strings.Contains(line, "# TraceKit Configuration") {
+			inBlock = true
+			continue
+		}
+		if inBlock {
+			if isBlockLine(line) || strings.TrimSpace(line) == "" {
+				continue
+			}
+			inBlock = false
+		}
+
+		trimmed := strings.TrimPrefix(strings.TrimSpace(line), "export ")
+		for _, key := range otlpKeys {
+			if strings.HasPrefix(trimmed, key+"=") {
+				lines[i] = "# " + line + " # replaced by TraceKit"
+				break
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// browserHeader starts the .env block holding a browser app's public settings
+const browserHeader = "# TraceKit Browser Configuration (public)"
+
+// BrowserConfig is the public configuration of a browser app. It is kept in
+// its own .env block, separate from the API key, because bundlers copy the
+// variables it holds into the JavaScript served to users.
+type BrowserConfig struct {
+	PublicKey   string // Public ingest key; only accepts browser telemetry
+	Endpoint    string
+	ServiceName string
+	EnvPrefix   string // Prefix the bundler exposes variables with ("VITE_", "REACT_APP_", ...)
+}
+
+// EnvVars returns the variables written to the browser block. Without a
+// bundler prefix only the key is written; the server block has the rest.
+func (b *BrowserConfig) EnvVars() []EnvVar {
+	vars := []EnvVar{{Name: b.EnvPrefix + "TRACEKIT_PUBLIC_KEY", Value: b.PublicKey}}
+	if b.EnvPrefix != "" {
+		vars = append(vars,
+			EnvVar{Name: b.EnvPrefix + "TRACEKIT_ENDPOINT", Value: b.Endpoint},
+			EnvVar{Name: b.EnvPrefix + "TRACEKIT_SERVICE_NAME", Value: b.ServiceName},
+		)
+	}
+	return vars
+}
+
+// ReadBrowserDir reads the browser block from the .env file in dir
+func ReadBrowserDir(dir string) (*BrowserConfig, error) {
+	content, err := os.ReadFile(filepath.Join(dir, ".env"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read .env file: %w", err)
+	}
+
+	// The block runs from its header to the next blank line or header, and
+	// starts with the public key, which gives the bundler prefix
+	browser := &BrowserConfig{}
+	inBlock, found := false, false
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.Contains(line, browserHeader) {
+			inBlock = true
+			continue
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			inBlock = false
+			continue
+		}
+		key, value, ok := strings.Cut(trimmed, "=")
+		if !inBlock || !ok {
+			continue
+		}
+
+		switch {
+		case !found && strings.HasSuffix(key, "TRACEKIT_PUBLIC_KEY"):
+			browser.PublicKey = value
+			browser.EnvPrefix = strings.TrimSuffix(key, "TRACEKIT_PUBLIC_KEY")
+			found = true
+		case found && key == browser.EnvPrefix+"TRACEKIT_ENDPOINT":
+			browser.Endpoint = value
+		case found && key == browser.EnvPrefix+"TRACEKIT_SERVICE_NAME":
+			browser.ServiceName = value
+		}
+	}
+
+	if browser.PublicKey == "" {
+		return nil, fmt.Errorf("TRACEKIT_PUBLIC_KEY not found in .env")
+	}
+	return browser, nil
+}
+
+// SaveBrowserDir writes the browser block to the .env file in dir, leaving
+// the server block alone
+func SaveBrowserDir(dir string, browser *BrowserConfig) error {
+	envPath := filepath.Join(dir, ".env")
+
+	block := "\n" + browserHeader + "\n"
+	for _, v := range browser.EnvVars() {
+		block += v.Name + "=" + v.Value + "\n"
+	}
+
+	content, err := os.ReadFile(envPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	inBlock := func(line string) bool {
+		key, _, ok := strings.Cut(strings.TrimSpace(line), "=")
+		return ok && strings.Contains(key, "TRACEKIT_")
+	}
+	content = []byte(replaceBlock(string(content), browserHeader, block, inBlock))
+	return plan.WriteFile("save browser monitoring configuration", envPath, content, 0644)
 }
@@ -0,0 +1,199 @@
+package deploy
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// composeFiles are the file names docker compose reads by default
+var composeFiles = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// detectCompose finds compose files with services built from dir
+func detectCompose(dir string) []Target {
+	var targets []Target
+	for _, d := range parentDirs(dir) {
+		for _, name := range composeFiles {
+			file := filepath.Join(d, name)
+			content, err := os.ReadFile(file)
+			if err != nil {
+				continue
+			}
+
+			var doc yaml.Node
+			if yaml.Unmarshal(content, &doc) != nil {
+				continue
+			}
+
+			var services []string
+			var built []string
+			for _, svc := range composeServices(&doc) {
+				context := composeBuildContext(svc.value)
+				if context == "" {
+					continue
+				}
+				built = append(built, svc.name)
+				if filepath.Clean(filepath.Join(d, filepath.FromSlash(context))) == dir {
+					services = append(services, svc.name)
+				}
+			}
+
+			// A compose file next to the project with no explicit match runs it
+			if len(services) == 0 && d == dir {
+				services = built
+			}
+			if len(services) > 0 {
+				targets = append(targets, Target{Kind: "compose", Path: file, Workloads: services})
+			}
+		}
+	}
+	return targets
+}
+
+// yamlEntry is a key and its value in a YAML mapping
+type yamlEntry struct {
+	name  string
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// mappingEntries returns the entries of a mapping node in order
+func mappingEntries(node *yaml.Node) []yamlEntry {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	var entries []yamlEntry
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		entries = append(entries, yamlEntry{node.Content[i].Value, node.Content[i], node.Content[i+1]})
+	}
+	return entries
+}
+
+// mappingValue returns the value for key in a mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for _, e := range mappingEntries(node) {
+		if e.name == key {
+			return e.value
+		}
+	}
+	return nil
+}
+
+func composeServices(doc *yaml.Node) []yamlEntry {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+	return mappingEntries(mappingValue(doc.Content[0], "services"))
+}
+
+// composeBuildContext returns a service's build context ("build: ./api" or
+// "build: {context: ./api}"), or "" for image-only services
+func composeBuildContext(svc *yaml.Node) string {
+	build := mappingValue(svc, "build")
+	switch {
+	case build == nil:
+		return ""
+	case build.Kind == yaml.ScalarNode:
+		return build.Value
+	case mappingValue(build, "context") != nil:
+		return mappingValue(build, "context").Value
+	default:
+		return "."
+	}
+}
+
+// compose adds the project's .env to the env_file list of each service
+func (b *planBuilder) compose(t Target) error {
+	envFile := relPath(filepath.Dir(t.Path), filepath.Join(b.dir, ".env"))
+	if !strings.HasPrefix(envFile, ".") {
+		envFile = "./" + envFile
+	}
+
+	for _, service := range t.Workloads {
+		err := b.edit(t.Path, "load .env into "+strings.Join(t.Workloads, ", "), func(content string) (string, error) {
+			return addComposeEnvFile(content, service, envFile)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addComposeEnvFile inserts envFile into a service's env_file entry, keeping
+// the rest of the file as written
+func addComposeEnvFile(content, service, envFile string) (string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return "", err
+	}
+
+	var svc *yaml.Node
+	for _, e := range composeServices(&doc) {
+		if e.name == service {
+			svc = e.value
+		}
+	}
+	if svc == nil || svc.Kind != yaml.MappingNode || svc.Style&yaml.FlowStyle != 0 || len(svc.Content) == 0 {
+		return "", fmt.Errorf("service %q must be a block mapping to add env_file", service)
+	}
+
+	lines := strings.Split(content, "\n")
+
+	existing := mappingValue(svc, "env_file")
+	switch {
+	case existing == nil:
+		indent := strings.Repeat(" ", svc.Content[0].Column-1)
+		return insertLines(lines, lastLine(svc), indent+"env_file:", indent+"  - "+envFile), nil
+
+	case existing.Kind == yaml.ScalarNode:
+		if path.Clean(existing.Value) == path.Clean(envFile) {
+			return content, nil
+		}
+		// Turn "env_file: x" into a list
+		indent := strings.Repeat(" ", svc.Content[0].Column-1)
+		line := existing.Line - 1
+		lines[line] = indent + "env_file:"
+		return insertLines(lines, existing.Line, indent+"  - "+existing.Value, indent+"  - "+envFile), nil
+
+	case existing.Kind == yaml.SequenceNode && existing.Style&yaml.FlowStyle == 0 && len(existing.Content) > 0:
+		for _, item := range existing.Content {
+			value := item.Value
+			if item.Kind == yaml.MappingNode {
+				if p := mappingValue(item, "path"); p != nil {
+					value = p.Value
+				}
+			}
+			if path.Clean(value) == path.Clean(envFile) {
+				return content, nil
+			}
+		}
+		first := existing.Content[0]
+		prefix := lines[first.Line-1][:first.Column-1]
+		return insertLines(lines, lastLine(existing), prefix+envFile), nil
+
+	default:
+		return "", fmt.Errorf("service %q: add %s to env_file by hand", service, envFile)
+	}
+}
+
+// insertLines inserts new lines after line n (1-based)
+func insertLines(lines []string, n int, insert ...string) string {
+	out := append([]string{}, lines[:n]...)
+	out = append(out, insert...)
+	out = append(out, lines[n:]...)
+	return strings.Join(out, "\n")
+}
+
+// lastLine returns the last line a node (and its children) occupies
+func lastLine(node *yaml.Node) int {
+	last := node.Line
+	for _, child := range node.Content {
+		last = max(last, lastLine(child))
+	}
+	return last
+}
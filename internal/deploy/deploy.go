@@ -0,0 +1,243 @@
+package deploy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yourusername/context.io/cli/internal/config"
+	"github.com/yourusername/context.io/cli/internal/plan"
+)
+
+// Target is a deployment configuration found for a project
+type Target struct {
+	Kind      string   // "dockerfile", "compose", "kubernetes", "helm", "procfile", "fly" or "vercel"
+	Path      string   // Absolute path of the file (the chart directory for helm)
+	Workloads []string // Compose services or Kubernetes workloads that run the project
+}
+
+// Change is an edit to a single file
+type Change struct {
+	Path        string // Absolute path
+	Description string
+	Before      string // Current contents ("" when the file is created)
+	After       string
+}
+
+// Plan is the wiring needed to get TraceKit's variables into deployments
+type Plan struct {
+	Changes      []Change
+	Instructions []string // Steps that can't be written to a file (platform CLIs)
+}
+
+// Detect finds the deployment configuration for the project in dir. Compose
+// files and Kubernetes manifests are also looked for in the parent
+// directories up to the repository root, where monorepos keep them.
+func Detect(dir string) []Target {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil
+	}
+
+	var targets []Target
+	if fileExists(filepath.Join(dir, "Dockerfile")) {
+		targets = append(targets, Target{Kind: "dockerfile", Path: filepath.Join(dir, "Dockerfile")})
+	}
+	targets = append(targets, detectCompose(dir)...)
+	targets = append(targets, detectKubernetes(dir)...)
+	targets = append(targets, detectHelm(dir)...)
+
+	for _, platform := range []struct{ kind, file string }{
+		{"procfile", "Procfile"},
+		{"fly", "fly.toml"},
+		{"vercel", "vercel.json"},
+	} {
+		if path := filepath.Join(dir, platform.file); fileExists(path) {
+			targets = append(targets, Target{Kind: platform.kind, Path: path})
+		}
+	}
+
+	return targets
+}
+
+// NewPlan works out the changes that pass vars (the project's TraceKit
+// block) to each target. service names Kubernetes secrets.
+func NewPlan(dir, service string, targets []Target, vars []config.EnvVar) (*Plan, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &planBuilder{dir: dir, service: service, vars: vars, files: make(map[string]*Change)}
+	kinds := make(map[string]bool)
+	for _, t := range targets {
+		kinds[t.Kind] = true
+	}
+
+	for _, t := range targets {
+		var err error
+		switch t.Kind {
+		case "dockerfile":
+			err = b.dockerfile(t, !kinds["compose"] && !kinds["kubernetes"] && !kinds["helm"])
+		case "compose":
+			err = b.compose(t)
+		case "kubernetes":
+			err = b.kubernetes(t)
+		case "helm":
+			err = b.helm(t)
+		case "procfile":
+			b.procfile()
+		case "fly":
+			b.fly()
+		case "vercel":
+			b.vercel()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", relPath(dir, t.Path), err)
+		}
+	}
+
+	plan := &Plan{Instructions: b.instructions}
+	for _, path := range b.order {
+		if c := b.files[path]; c.Before != c.After {
+			plan.Changes = append(plan.Changes, *c)
+		}
+	}
+	return plan, nil
+}
+
+// Apply writes every change in the plan
+func (p *Plan) Apply() error {
+	for _, c := range p.Changes {
+		if err := plan.WriteFile(c.Description, c.Path, []byte(c.After), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", c.Path, err)
+		}
+	}
+	return nil
+}
+
+// planBuilder accumulates edits so several changes to one file become a
+// single Change
+type planBuilder struct {
+	dir          string
+	service      string
+	vars         []config.EnvVar
+	files        map[string]*Change
+	order        []string
+	instructions []string
+}
+
+// edit applies fn to the pending contents of path
+func (b *planBuilder) edit(path, description string, fn func(content string) (string, error)) error {
+	c, ok := b.files[path]
+	if !ok {
+		content, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		c = &Change{Path: path, Before: string(content), After: string(content)}
+		b.files[path] = c
+		b.order = append(b.order, path)
+	}
+
+	after, err := fn(c.After)
+	if err != nil {
+		return err
+	}
+	if after != c.After {
+		c.After = after
+		if c.Description == "" {
+			c.Description = description
+		} else if !strings.Contains(c.Description, description) {
+			c.Description += "; " + description
+		}
+	}
+	return nil
+}
+
+// ensureLine appends line to a list file (.gitignore, .dockerignore) unless
+// it is already there
+func (b *planBuilder) ensureLine(path, line, description string) error {
+	return b.edit(path, description, func(content string) (string, error) {
+		for _, existing := range strings.Split(content, "\n") {
+			if strings.TrimSpace(existing) == line {
+				return content, nil
+			}
+		}
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		return content + line + "\n", nil
+	})
+}
+
+// envGrep is a shell snippet printing the TraceKit variables from .env
+const envGrep = `grep -E '^(TRACEKIT|OTEL)_' .env`
+
+func (b *planBuilder) dockerfile(t Target, standalone bool) error {
+	ignore := filepath.Join(filepath.Dir(t.Path), ".dockerignore")
+	if err := b.ensureLine(ignore, ".env", "keep .env (and the API key) out of images"); err != nil {
+		return err
+	}
+	if standalone {
+		b.instructions = append(b.instructions,
+			"Pass the TraceKit variables when starting the container:",
+			"  docker run --env-file .env <image>")
+	}
+	return nil
+}
+
+func (b *planBuilder) procfile() {
+	b.instructions = append(b.instructions,
+		"Heroku: set the TraceKit variables on the app:",
+		"  heroku config:set $("+envGrep+" | xargs)")
+}
+
+func (b *planBuilder) fly() {
+	b.instructions = append(b.instructions,
+		"Fly.io: import the TraceKit variables as secrets:",
+		"  "+envGrep+" | fly secrets import")
+}
+
+func (b *planBuilder) vercel() {
+	b.instructions = append(b.instructions,
+		"Vercel: add the TraceKit variables to the production environment:",
+		"  "+envGrep+" | while IFS== read -r k v; do printf %s \"$v\" | vercel env add \"$k\" production; done")
+}
+
+// repoRoot returns the repository containing dir, or dir itself
+func repoRoot(dir string) string {
+	for root := dir; ; root = filepath.Dir(root) {
+		if fileExists(filepath.Join(root, ".git")) {
+			return root
+		}
+		if filepath.Dir(root) == root {
+			return dir
+		}
+	}
+}
+
+// parentDirs returns dir and its parents up to the repository root
+func parentDirs(dir string) []string {
+	root := repoRoot(dir)
+	dirs := []string{dir}
+	for d := dir; d != root && filepath.Dir(d) != d; {
+		d = filepath.Dir(d)
+		dirs = append(dirs, d)
+	}
+	return dirs
+}
+
+func relPath(base, path string) string {
+	rel, err := filepath.Rel(base, path)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,350 @@
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// manifestDirs are where Kubernetes manifests usually live, relative to a
+// project or repository directory
+var manifestDirs = []string{"k8s", "kubernetes", "deploy", "deployment", "manifests", ".k8s"}
+
+// workloadKinds are the Kubernetes kinds whose pods get the TraceKit secret,
+// with the path to their container list
+var workloadKinds = map[string][]string{
+	"Deployment":  {"spec", "template", "spec", "containers"},
+	"StatefulSet": {"spec", "template", "spec", "containers"},
+	"DaemonSet":   {"spec", "template", "spec", "containers"},
+	"Job":         {"spec", "template", "spec", "containers"},
+	"CronJob":     {"spec", "jobTemplate", "spec", "template", "spec", "containers"},
+}
+
+// secretFile is the Secret manifest written next to a project's workloads
+const secretFile = "tracekit-secret.yaml"
+
+// detectKubernetes finds manifests with workloads for the project in dir.
+// Manifests in the project itself are taken as a whole; those further up
+// must name a workload or container after the project directory.
+func detectKubernetes(dir string) []Target {
+	name := strings.ToLower(filepath.Base(dir))
+
+	var targets []Target
+	for _, d := range parentDirs(dir) {
+		files := yamlFiles(d, 0)
+		for _, sub := range manifestDirs {
+			files = append(files, yamlFiles(filepath.Join(d, sub), 2)...)
+		}
+
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				continue
+			}
+
+			var workloads []string
+			for _, w := range parseWorkloads(content) {
+				if d == dir || w.name == name || w.hasContainer(name) {
+					workloads = append(workloads, w.name)
+				}
+			}
+			if len(workloads) > 0 {
+				targets = append(targets, Target{Kind: "kubernetes", Path: file, Workloads: workloads})
+			}
+		}
+	}
+	return targets
+}
+
+// yamlFiles lists .yaml/.yml files in dir, descending depth levels. Helm
+// charts are skipped since their templates aren't plain manifests.
+func yamlFiles(dir string, depth int) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil || fileExists(filepath.Join(dir, "Chart.yaml")) {
+		return nil
+	}
+
+	var files []string
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		switch {
+		case e.IsDir():
+			if depth > 0 && !strings.HasPrefix(e.Name(), ".") && e.Name() != "node_modules" {
+				files = append(files, yamlFiles(path, depth-1)...)
+			}
+		case strings.HasSuffix(e.Name(), ".yaml"), strings.HasSuffix(e.Name(), ".yml"):
+			if e.Name() != secretFile && !strings.HasPrefix(e.Name(), "docker-compose") && !strings.HasPrefix(e.Name(), "compose.") {
+				files = append(files, path)
+			}
+		}
+	}
+	return files
+}
+
+// workload is a Kubernetes workload in a manifest
+type workload struct {
+	name       string
+	containers []*yaml.Node
+}
+
+func (w workload) hasContainer(name string) bool {
+	for _, c := range w.containers {
+		if n := mappingValue(c, "name"); n != nil && strings.ToLower(n.Value) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// parseWorkloads returns the workloads in a (possibly multi-document)
+// manifest. Line numbers in the nodes are relative to the whole file.
+func parseWorkloads(content []byte) []workload {
+	var workloads []workload
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var doc yaml.Node
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil || len(doc.Content) == 0 {
+			// Not YAML we understand (e.g. a template); ignore the rest
+			break
+		}
+
+		root := doc.Content[0]
+		kind := mappingValue(root, "kind")
+		if kind == nil {
+			continue
+		}
+		path, ok := workloadKinds[kind.Value]
+		if !ok {
+			continue
+		}
+
+		w := workload{}
+		if name := mappingValue(mappingValue(root, "metadata"), "name"); name != nil {
+			w.name = name.Value
+		}
+
+		node := root
+		for _, key := range path {
+			node = mappingValue(node, key)
+		}
+		if node != nil && node.Kind == yaml.SequenceNode {
+			w.containers = node.Content
+		}
+		workloads = append(workloads, w)
+	}
+
+	return workloads
+}
+
+// kubernetes writes a Secret next to the manifest and adds it to every
+// container of the project's workloads through envFrom
+func (b *planBuilder) kubernetes(t Target) error {
+	secret := secretName(b.service)
+	manifestDir := filepath.Dir(t.Path)
+
+	err := b.edit(filepath.Join(manifestDir, secretFile), "Secret "+secret+" with the TraceKit variables", func(string) (string, error) {
+		return b.secretManifest(secret), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := b.ensureLine(filepath.Join(manifestDir, ".gitignore"), secretFile, "keep the Secret (API key) out of git"); err != nil {
+		return err
+	}
+
+	wanted := make(map[string]bool)
+	for _, name := range t.Workloads {
+		wanted[name] = true
+	}
+
+	description := "envFrom secret " + secret + " in " + strings.Join(t.Workloads, ", ")
+	err = b.edit(t.Path, description, func(content string) (string, error) {
+		// Insert one container at a time, re-reading positions after each
+		for {
+			updated, changed, err := addEnvFrom(content, wanted, secret)
+			if err != nil || !changed {
+				return content, err
+			}
+			content = updated
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	b.instructions = append(b.instructions,
+		"Kubernetes: create the Secret before rolling out ("+relPath(b.dir, filepath.Join(manifestDir, secretFile))+" is git-ignored):",
+		"  kubectl apply -f "+relPath(b.dir, filepath.Join(manifestDir, secretFile)),
+		"  or: kubectl create secret generic "+secret+" --from-env-file=<("+envGrep+")")
+	return nil
+}
+
+// addEnvFrom adds a secretRef to the first wanted container that lacks one
+func addEnvFrom(content string, wanted map[string]bool, secret string) (string, bool, error) {
+	lines := strings.Split(content, "\n")
+
+	for _, w := range parseWorkloads([]byte(content)) {
+		if !wanted[w.name] {
+			continue
+		}
+
+		for _, c := range w.containers {
+			if c.Kind != yaml.MappingNode || c.Style&yaml.FlowStyle != 0 {
+				return "", false, fmt.Errorf("workload %q: containers must be block mappings", w.name)
+			}
+
+			envFrom := mappingValue(c, "envFrom")
+			if hasSecretRef(envFrom, secret) {
+				continue
+			}
+
+			indent := strings.Repeat(" ", c.Column-1)
+			if envFrom == nil {
+				return insertLines(lines, lastLine(c),
+					indent+"envFrom:",
+					indent+"  - secretRef:",
+					indent+"      name: "+secret), true, nil
+			}
+
+			if envFrom.Kind != yaml.SequenceNode || envFrom.Style&yaml.FlowStyle != 0 || len(envFrom.Content) == 0 {
+				return "", false, fmt.Errorf("workload %q: add secretRef %s to envFrom by hand", w.name, secret)
+			}
+			first := envFrom.Content[0]
+			prefix := lines[first.Line-1][:first.Column-1]
+			itemIndent := strings.Repeat(" ", first.Column-1)
+			return insertLines(lines, lastLine(envFrom),
+				prefix+"secretRef:",
+				itemIndent+"  name: "+secret), true, nil
+		}
+	}
+
+	return content, false, nil
+}
+
+func hasSecretRef(envFrom *yaml.Node, secret string) bool {
+	if envFrom == nil {
+		return false
+	}
+	for _, item := range envFrom.Content {
+		if name := mappingValue(mappingValue(item, "secretRef"), "name"); name != nil && name.Value == secret {
+			return true
+		}
+	}
+	return false
+}
+
+// secretManifest renders the Secret holding the TraceKit variables
+func (b *planBuilder) secretManifest(name string) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "# TraceKit configuration for %s. Contains the API key: don't commit it.\n", b.service)
+	fmt.Fprintf(&out, "apiVersion: v1\nkind: Secret\nmetadata:\n  name: %s\ntype: Opaque\nstringData:\n", name)
+	for _, v := range b.vars {
+		fmt.Fprintf(&out, "  %s: %q\n", v.Name, v.Value)
+	}
+	return out.String()
+}
+
+var invalidSecretChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// secretName returns a valid Secret name for a service
+func secretName(service string) string {
+	name := strings.Trim(invalidSecretChars.ReplaceAllString(strings.ToLower(service), "-"), "-")
+	if name == "" {
+		return "tracekit"
+	}
+	return name + "-tracekit"
+}
+
+// detectHelm finds Helm charts in the project: ./Chart.yaml, chart/, helm/,
+// charts/* or helm/*
+func detectHelm(dir string) []Target {
+	var targets []Target
+	for _, pattern := range []string{".", "chart", "helm", "charts/*", "helm/*"} {
+		matches, _ := filepath.Glob(filepath.Join(dir, pattern, "Chart.yaml"))
+		for _, chart := range matches {
+			targets = append(targets, Target{Kind: "helm", Path: filepath.Dir(chart)})
+		}
+	}
+	return targets
+}
+
+// tracekitValuesPattern matches a top-level tracekit key in values.yaml
+var tracekitValuesPattern = regexp.MustCompile(`(?m)^tracekit:`)
+
+// helm adds a tracekit section to values.yaml and a Secret template fed by
+// it. The API key is left for --set so it stays out of the chart.
+func (b *planBuilder) helm(t Target) error {
+	err := b.edit(filepath.Join(t.Path, "values.yaml"), "tracekit values", func(content string) (string, error) {
+		if tracekitValuesPattern.MatchString(content) {
+			return content, nil
+		}
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+
+		var section strings.Builder
+		section.WriteString("\n# TraceKit (set the API key at install: --set tracekit.apiKey=...)\ntracekit:\n  apiKey: \"\"\n")
+		for _, v := range b.vars {
+			if !v.Secret {
+				fmt.Fprintf(&section, "  %s: %q\n", valuesKey(v.Name), v.Value)
+			}
+		}
+		return content + section.String(), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = b.edit(filepath.Join(t.Path, "templates", secretFile), "TraceKit Secret template", func(string) (string, error) {
+		var out strings.Builder
+		out.WriteString("apiVersion: v1\nkind: Secret\nmetadata:\n  name: {{ .Release.Name }}-tracekit\ntype: Opaque\nstringData:\n")
+		for _, v := range b.vars {
+			switch v.Name {
+			case "TRACEKIT_API_KEY":
+				fmt.Fprintf(&out, "  %s: {{ required \"tracekit.apiKey is required\" .Values.tracekit.apiKey | quote }}\n", v.Name)
+			case "OTEL_EXPORTER_OTLP_HEADERS":
+				fmt.Fprintf(&out, "  %s: {{ printf \"X-API-Key=%%s\" .Values.tracekit.apiKey | quote }}\n", v.Name)
+			default:
+				fmt.Fprintf(&out, "  %s: {{ .Values.tracekit.%s | quote }}\n", v.Name, valuesKey(v.Name))
+			}
+		}
+		return out.String(), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	chart := relPath(b.dir, t.Path)
+	b.instructions = append(b.instructions,
+		"Helm ("+chart+"): add to the container spec in your workload template:",
+		"  envFrom:",
+		"    - secretRef:",
+		"        name: {{ .Release.Name }}-tracekit",
+		"  then: helm upgrade --install <release> "+chart+" --set tracekit.apiKey=$TRACEKIT_API_KEY")
+	return nil
+}
+
+// valuesKey turns a variable name into a camelCase values key
+// (TRACEKIT_SERVICE_NAME -> serviceName, OTEL_SERVICE_NAME -> otelServiceName)
+func valuesKey(name string) string {
+	parts := strings.Split(strings.ToLower(strings.TrimPrefix(name, "TRACEKIT_")), "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
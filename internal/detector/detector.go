@@ -1,16 +1,85 @@
 package detector
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
 // Framework represents a detected framework
 type Framework struct {
-	Name    string // "gemvc", "laravel", "express", "django", etc.
-	Version string // Framework version (if detectable)
-	Type    string // "go", "php", "node", "python", etc.
+	Name           string     // "gemvc", "laravel", "express", "django", etc.
+	Version        string     // Exact resolved version from a lockfile or pin (if detectable)
+	Constraint     string     // Version constraint declared in the manifest
+	Type           string     // "go", "php", "node", "python", etc.
+	PackageManager string     // Tool that manages dependencies ("poetry", "uv", "pipenv", "pip", ...)
+	Workspace      *Workspace // Node.js or Go workspace the project belongs to (nil if none)
+	MinVersion     string     // Oldest version supported by the TraceKit SDK
+	Unsupported    bool       // Version is older than MinVersion
+	SDK            string     // SDK to recommend instead of the framework default (custom rules)
+	Browser        bool       // Frontend-only app that runs in the browser (React, Vue, ...)
+}
+
+// Evidence is a single observation supporting a candidate
+type Evidence struct {
+	File   string `json:"file"`   // File the observation came from, relative to the project directory
+	Detail string `json:"detail"` // What was found, e.g. "requires express ^4.18.2"
+}
+
+// Candidate is a framework that may apply to a directory
+type Candidate struct {
+	Framework  *Framework
+	Confidence int // 0-100
+	Evidence   []Evidence
+	Detector   string   // Name of the detector that produced the candidate
+	Priority   int      // Priority of that detector; higher ranks first
+	Warnings   []string // Problems that kept the detector from looking further
+}
+
+// Confidence contributions
+const (
+	scoreDependency    = 70 // Framework declared as a runtime dependency
+	scoreDevDependency = 60 // Declared only as a dev dependency (meta-frameworks)
+	scoreIndirect      = 30 // Go module required only transitively
+	scoreResolved      = 20 // Version confirmed by a lockfile, pin or build file
+	scoreMarker        = 10 // Framework-specific file present (artisan, next.config.js, ...)
+	scoreSource        = 50 // Framework used in source code without a dependency (net/http)
+	scoreLanguage      = 30 // Only a language manifest, no framework
+)
+
+// frameworkMarkers are files (or globs) whose presence corroborates a framework
+var frameworkMarkers = map[string][]string{
+	"laravel":   {"artisan"},
+	"symfony":   {"symfony.lock", "bin/console"},
+	"nextjs":    {"next.config.js", "next.config.mjs", "next.config.ts"},
+	"nestjs":    {"nest-cli.json"},
+	"nuxt":      {"nuxt.config.ts", "nuxt.config.js"},
+	"remix":     {"remix.config.js"},
+	"sveltekit": {"svelte.config.js"},
+	"astro":     {"astro.config.mjs", "astro.config.ts"},
+	"django":    {"manage.py"},
+	"rails":     {"config/routes.rb", "bin/rails"},
+	"phoenix":   {"lib/*_web.ex"},
+	"angular":   {"angular.json"},
+	"vite":      {"vite.config.ts", "vite.config.js", "vite.config.mjs"},
+}
+
+// knownFrameworks maps every framework name the detector can report to its type
+var knownFrameworks = map[string]string{
+	"gin": "go", "echo": "go", "fiber": "go", "chi": "go", "gorilla-mux": "go", "net-http": "go",
+	"grpc": "go", "connect": "go", "go": "go",
+	"gemvc": "php", "laravel": "php", "symfony": "php", "php": "php",
+	"nestjs": "node", "nextjs": "node", "nuxt": "node", "remix": "node", "sveltekit": "node",
+	"astro": "node", "fastify": "node", "koa": "node", "hapi": "node", "express": "node", "node": "node",
+	"angular": "node", "react": "node", "vue": "node", "svelte": "node", "vite": "node",
+	"django": "python", "flask": "python", "fastapi": "python", "python": "python",
+	"rails": "ruby", "sinatra": "ruby", "ruby": "ruby",
+	"spring-boot": "java", "quarkus": "java", "micronaut": "java", "java": "java",
+	"aspnetcore": "dotnet", "dotnet": "dotnet",
+	"axum": "rust", "actix-web": "rust", "rocket": "rust", "rust": "rust",
+	"phoenix": "elixir", "elixir": "elixir",
 }
 
 // Detect attempts to detect the framework in the current directory
@@ -20,226 +89,295 @@ func Detect() (*Framework, error) {
 		return nil, err
 	}
 
-	// Check for Go frameworks
-	if fileExists(filepath.Join(cwd, "go.mod")) {
-		return detectGoFramework(cwd)
-	}
-
-	// Check for PHP frameworks
-	if fileExists(filepath.Join(cwd, "composer.json")) {
-		return detectPHPFramework(cwd)
-	}
-
-	// Check for Node.js frameworks
-	if fileExists(filepath.Join(cwd, "package.json")) {
-		return detectNodeFramework(cwd)
-	}
+	return DetectDir(cwd)
+}
 
-	// Check for Python frameworks
-	if fileExists(filepath.Join(cwd, "requirements.txt")) || fileExists(filepath.Join(cwd, "pyproject.toml")) {
-		return detectPythonFramework(cwd)
+// DetectDir attempts to detect the framework in dir, returning the most
+// likely candidate
+func DetectDir(dir string) (*Framework, error) {
+	candidates, err := DetectCandidates(dir)
+	if err != nil {
+		return nil, err
 	}
 
-	// Check for Ruby frameworks
-	if fileExists(filepath.Join(cwd, "Gemfile")) {
-		return detectRubyFramework(cwd)
+	if len(candidates) == 0 {
+		// No framework detected - return generic
+		return &Framework{
+			Name: "generic",
+			Type: "unknown",
+		}, nil
 	}
 
-	// No framework detected - return generic
-	return &Framework{
-		Name: "generic",
-		Type: "unknown",
-	}, nil
+	return candidates[0].Framework, nil
 }
 
-func detectGoFramework(dir string) (*Framework, error) {
-	goModPath := filepath.Join(dir, "go.mod")
-	content, err := os.ReadFile(goModPath)
+// DetectCandidates returns every framework that may apply to dir, most
+// likely first. Each registered detector that applies to dir contributes its
+// frameworks, along with any custom rules from .tracekit/detectors.yaml or
+// the user config. Candidates are ranked by detector priority, then
+// confidence.
+func DetectCandidates(dir string) ([]Candidate, error) {
+	rules, err := loadRules(dir)
 	if err != nil {
 		return nil, err
 	}
 
-	goModContent := string(content)
+	var candidates []Candidate
+	for _, d := range append(Detectors(), rules...) {
+		found, err := d.Detect(dir)
+		if err != nil {
+			return nil, fmt.Errorf("%s detector: %w", d.Name(), err)
+		}
+		for _, c := range found {
+			c.Detector = d.Name()
+			c.Priority = d.Priority()
+			candidates = append(candidates, c)
+		}
+	}
 
-	// Check for Gin
-	if strings.Contains(goModContent, "github.com/gin-gonic/gin") {
-		return &Framework{
-			Name: "gin",
-			Type: "go",
-		}, nil
+	inheritProjectDetails(candidates)
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Priority != candidates[j].Priority {
+			return candidates[i].Priority > candidates[j].Priority
+		}
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+
+	return candidates, nil
+}
+
+// inheritProjectDetails fills in the package manager and workspace of
+// candidates that don't know them (custom rules) from a built-in candidate
+// of the same language
+func inheritProjectDetails(candidates []Candidate) {
+	for i := range candidates {
+		fw := candidates[i].Framework
+		if fw.PackageManager != "" {
+			continue
+		}
+		for _, other := range candidates {
+			if other.Framework.Type == fw.Type && other.Framework.PackageManager != "" {
+				fw.PackageManager = other.Framework.PackageManager
+				fw.Workspace = other.Framework.Workspace
+				break
+			}
+		}
 	}
+}
 
-	// Check for Echo
-	if strings.Contains(goModContent, "github.com/labstack/echo") {
-		return &Framework{
-			Name: "echo",
-			Type: "go",
-		}, nil
+// Override returns the named framework for dir without running detection
+// heuristics. Version and package manager details are filled in from the
+// matching candidate when the framework is found in dir. Custom rule names
+// are accepted when the rule matches dir.
+func Override(dir, name string) (*Framework, error) {
+	name = strings.ToLower(name)
+	candidates, _ := DetectCandidates(dir)
+	for _, c := range candidates {
+		if strings.ToLower(c.Framework.Name) == name {
+			return c.Framework, nil
+		}
 	}
 
-	// Check for Fiber
-	if strings.Contains(goModContent, "github.com/gofiber/fiber") {
-		return &Framework{
-			Name: "fiber",
-			Type: "go",
-		}, nil
+	frameworkType, ok := knownFrameworks[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown framework %q (known: %s)", name, strings.Join(KnownFrameworks(), ", "))
 	}
 
-	// Generic Go project
-	return &Framework{
-		Name: "go",
-		Type: "go",
-	}, nil
+	fw := &Framework{Name: name, Type: frameworkType, MinVersion: minSupportedVersions[name], Browser: isFrontendFramework(name)}
+	for _, c := range candidates {
+		if c.Framework.Type == frameworkType {
+			fw.PackageManager = c.Framework.PackageManager
+			fw.Workspace = c.Framework.Workspace
+			break
+		}
+	}
+	return fw, nil
 }
 
-func detectPHPFramework(dir string) (*Framework, error) {
-	composerPath := filepath.Join(dir, "composer.json")
-	content, err := os.ReadFile(composerPath)
-	if err != nil {
-		return nil, err
+// KnownFrameworks returns the sorted built-in framework names accepted by
+// Override
+func KnownFrameworks() []string {
+	names := make([]string, 0, len(knownFrameworks))
+	for name := range knownFrameworks {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	return names
+}
 
-	composerContent := string(content)
-
-	// Check for GemVC (PHP framework)
-	if strings.Contains(composerContent, "gemvc/library") {
-		return &Framework{
-			Name: "gemvc",
-			Type: "php",
-		}, nil
+// newFramework builds a Framework and checks its version against the
+// minimum supported by the SDK
+func newFramework(name, frameworkType, version, constraint string) *Framework {
+	fw := &Framework{
+		Name:       name,
+		Version:    version,
+		Constraint: constraint,
+		Type:       frameworkType,
+		MinVersion: minSupportedVersions[name],
 	}
 
-	// Check for Laravel
-	if strings.Contains(composerContent, "laravel/framework") {
-		return &Framework{
-			Name: "laravel",
-			Type: "php",
-		}, nil
+	if fw.MinVersion == "" {
+		return fw
 	}
 
-	// Check for Symfony
-	if strings.Contains(composerContent, "symfony/symfony") {
-		return &Framework{
-			Name: "symfony",
-			Type: "php",
-		}, nil
+	// Without a lockfile, judge by the lowest version the constraint allows
+	check := version
+	if check == "" {
+		check = lowerBound(constraint)
+	}
+	if check != "" && compareVersions(check, fw.MinVersion) < 0 {
+		fw.Unsupported = true
 	}
 
-	// Generic PHP project
-	return &Framework{
-		Name: "php",
-		Type: "php",
-	}, nil
+	return fw
 }
 
-func detectNodeFramework(dir string) (*Framework, error) {
-	packagePath := filepath.Join(dir, "package.json")
-	content, err := os.ReadFile(packagePath)
-	if err != nil {
-		return nil, err
+// dependencyCandidate scores a framework found through a dependency declared
+// in manifest. versionFile is where fw.Version was read from, if anywhere.
+func dependencyCandidate(dir string, fw *Framework, dep Dependency, manifest, versionFile string) Candidate {
+	score := scoreDependency
+	detail := "requires"
+	switch {
+	case dep.Indirect:
+		score = scoreIndirect
+		detail = "requires (indirect)"
+	case dep.Dev:
+		score = scoreDevDependency
+		detail = "requires (dev)"
 	}
 
-	packageContent := string(content)
-
-	// Check for Express
-	if strings.Contains(packageContent, "\"express\"") {
-		return &Framework{
-			Name: "express",
-			Type: "node",
-		}, nil
+	c := Candidate{
+		Framework:  fw,
+		Confidence: score,
+		Evidence: []Evidence{{
+			File:   manifest,
+			Detail: strings.TrimSpace(fmt.Sprintf("%s %s %s", detail, dep.Name, dep.Constraint)),
+		}},
 	}
 
-	// Check for Next.js
-	if strings.Contains(packageContent, "\"next\"") {
-		return &Framework{
-			Name: "nextjs",
-			Type: "node",
-		}, nil
+	if fw.Version != "" && versionFile != "" {
+		c.Confidence += scoreResolved
+		c.Evidence = append(c.Evidence, Evidence{File: versionFile, Detail: "version " + fw.Version})
 	}
 
-	// Check for NestJS
-	if strings.Contains(packageContent, "@nestjs/core") {
-		return &Framework{
-			Name: "nestjs",
-			Type: "node",
-		}, nil
+	addMarkerEvidence(dir, &c)
+	return c
+}
+
+// languageCandidate is the generic fallback for a language manifest with no
+// recognised framework, or a fallback alongside framework candidates
+func languageCandidate(fw *Framework, manifest string) Candidate {
+	return Candidate{
+		Framework:  fw,
+		Confidence: scoreLanguage,
+		Evidence:   []Evidence{{File: manifest, Detail: fw.Type + " project manifest"}},
 	}
+}
 
-	// Generic Node.js project
-	return &Framework{
-		Name: "node",
-		Type: "node",
-	}, nil
+// malformedManifest is the warning for a manifest whose dependencies
+// couldn't be read
+func malformedManifest(manifest string, err error) string {
+	return fmt.Sprintf("%s is malformed, so its frameworks weren't detected: %v", manifest, err)
 }
 
-func detectPythonFramework(dir string) (*Framework, error) {
-	// Check requirements.txt
-	reqPath := filepath.Join(dir, "requirements.txt")
-	if fileExists(reqPath) {
-		content, err := os.ReadFile(reqPath)
-		if err == nil {
-			reqContent := string(content)
+// addMarkerEvidence raises confidence when a framework-specific file exists
+func addMarkerEvidence(dir string, c *Candidate) {
+	for _, marker := range frameworkMarkers[c.Framework.Name] {
+		matches := globFiles(dir, filepath.FromSlash(marker))
+		if len(matches) == 0 {
+			continue
+		}
 
-			if strings.Contains(reqContent, "Django") {
-				return &Framework{
-					Name: "django",
-					Type: "python",
-				}, nil
-			}
+		rel, _ := filepath.Rel(dir, matches[0])
+		c.Evidence = append(c.Evidence, Evidence{File: filepath.ToSlash(rel), Detail: "framework file present"})
+		c.Confidence = min(c.Confidence+scoreMarker, 100)
+		return
+	}
+}
 
-			if strings.Contains(reqContent, "Flask") {
-				return &Framework{
-					Name: "flask",
-					Type: "python",
-				}, nil
-			}
+func phpCandidates(dir string) ([]Candidate, error) {
+	content, err := os.ReadFile(filepath.Join(dir, "composer.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	// A malformed composer.json still makes a PHP project
+	deps, err := parseComposerJSON(content)
+	var warnings []string
+	if err != nil {
+		warnings = append(warnings, malformedManifest("composer.json", err))
+	}
+
+	var locked map[string]string
+	if lock, err := os.ReadFile(filepath.Join(dir, "composer.lock")); err == nil {
+		locked = parseComposerLock(lock)
+	}
+
+	frameworks := []struct {
+		name     string
+		packages []string
+	}{
+		{"gemvc", []string{"gemvc/library"}},
+		{"laravel", []string{"laravel/framework"}},
+		{"symfony", []string{"symfony/symfony", "symfony/framework-bundle"}},
+	}
 
-			if strings.Contains(reqContent, "fastapi") {
-				return &Framework{
-					Name: "fastapi",
-					Type: "python",
-				}, nil
+	var candidates []Candidate
+	for _, fw := range frameworks {
+		for _, pkg := range fw.packages {
+			dep, ok := deps[pkg]
+			if !ok || dep.Dev {
+				continue
 			}
+			framework := newFramework(fw.name, "php", locked[pkg], dep.Constraint)
+			framework.PackageManager = "composer"
+			candidates = append(candidates, dependencyCandidate(dir, framework, dep, "composer.json", "composer.lock"))
+			break
 		}
 	}
 
-	// Generic Python project
-	return &Framework{
-		Name: "python",
-		Type: "python",
-	}, nil
+	// Generic PHP project
+	php := languageCandidate(&Framework{Name: "php", Type: "php", PackageManager: "composer"}, "composer.json")
+	php.Warnings = warnings
+	candidates = append(candidates, php)
+	return candidates, nil
 }
 
-func detectRubyFramework(dir string) (*Framework, error) {
-	gemfilePath := filepath.Join(dir, "Gemfile")
-	content, err := os.ReadFile(gemfilePath)
+func rubyCandidates(dir string) ([]Candidate, error) {
+	content, err := os.ReadFile(filepath.Join(dir, "Gemfile"))
 	if err != nil {
 		return nil, err
 	}
 
-	gemfileContent := string(content)
+	deps := parseGemfile(content)
 
-	// Check for Rails
-	if strings.Contains(gemfileContent, "rails") {
-		return &Framework{
-			Name: "rails",
-			Type: "ruby",
-		}, nil
+	var locked map[string]string
+	if lock, err := os.ReadFile(filepath.Join(dir, "Gemfile.lock")); err == nil {
+		locked = parseGemfileLock(lock)
 	}
 
-	// Check for Sinatra
-	if strings.Contains(gemfileContent, "sinatra") {
-		return &Framework{
-			Name: "sinatra",
-			Type: "ruby",
-		}, nil
+	var candidates []Candidate
+	for _, name := range []string{"rails", "sinatra"} {
+		if dep, ok := deps[name]; ok {
+			framework := newFramework(name, "ruby", locked[name], dep.Constraint)
+			framework.PackageManager = "bundler"
+			candidates = append(candidates, dependencyCandidate(dir, framework, dep, "Gemfile", "Gemfile.lock"))
+		}
 	}
 
 	// Generic Ruby project
-	return &Framework{
-		Name: "ruby",
-		Type: "ruby",
-	}, nil
+	candidates = append(candidates, languageCandidate(&Framework{Name: "ruby", Type: "ruby", PackageManager: "bundler"}, "Gemfile"))
+	return candidates, nil
+}
+
+func hasJVMBuild(dir string) bool {
+	for _, name := range []string{"pom.xml", "build.gradle", "build.gradle.kts"} {
+		if fileExists(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	return false
 }
 
 func fileExists(path string) bool {
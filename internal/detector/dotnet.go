@@ -0,0 +1,137 @@
+package detector
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// csproj is the subset of an MSBuild project file the detector reads
+type csproj struct {
+	Sdk            string `xml:"Sdk,attr"`
+	PropertyGroups []struct {
+		TargetFramework  string `xml:"TargetFramework"`
+		TargetFrameworks string `xml:"TargetFrameworks"`
+	} `xml:"PropertyGroup"`
+	ItemGroups []struct {
+		PackageReferences []struct {
+			Include string `xml:"Include,attr"`
+		} `xml:"PackageReference"`
+		FrameworkReferences []struct {
+			Include string `xml:"Include,attr"`
+		} `xml:"FrameworkReference"`
+	} `xml:"ItemGroup"`
+}
+
+// slnProjectPattern matches a project entry in a .sln file:
+// Project("{...}") = "Api", "src\Api\Api.csproj", "{...}"
+var slnProjectPattern = regexp.MustCompile(`Project\("[^"]*"\)\s*=\s*"[^"]*",\s*"([^"]+\.csproj)"`)
+
+// targetFrameworkPattern extracts the runtime version from a target
+// framework moniker such as net8.0 or netcoreapp3.1
+var targetFrameworkPattern = regexp.MustCompile(`^net(?:coreapp)?(\d+\.\d+)`)
+
+// hasDotnetProject reports whether dir holds a .NET project or solution
+func hasDotnetProject(dir string) bool {
+	return len(globFiles(dir, "*.csproj")) > 0 || len(globFiles(dir, "*.sln")) > 0
+}
+
+func dotnetCandidates(dir string) ([]Candidate, error) {
+	projects := globFiles(dir, "*.csproj")
+
+	// A solution directory without its own project: follow the .sln entries
+	if len(projects) == 0 {
+		for _, sln := range globFiles(dir, "*.sln") {
+			content, err := os.ReadFile(sln)
+			if err != nil {
+				return nil, err
+			}
+			for _, match := range slnProjectPattern.FindAllStringSubmatch(string(content), -1) {
+				path := filepath.Join(dir, filepath.FromSlash(strings.ReplaceAll(match[1], `\`, "/")))
+				projects = append(projects, path)
+			}
+		}
+	}
+
+	var candidates []Candidate
+	manifest := ""
+	for _, path := range projects {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var project csproj
+		if err := xml.Unmarshal(content, &project); err != nil {
+			return nil, err
+		}
+
+		rel, _ := filepath.Rel(dir, path)
+		rel = filepath.ToSlash(rel)
+		if manifest == "" {
+			manifest = rel
+		}
+
+		reference := project.webReference()
+		if reference == "" {
+			continue
+		}
+
+		framework := newFramework("aspnetcore", "dotnet", project.runtimeVersion(), "")
+		framework.PackageManager = "dotnet"
+		candidates = append(candidates, dependencyCandidate(dir, framework, Dependency{Name: reference}, rel, rel))
+		break
+	}
+
+	// Generic .NET project
+	candidates = append(candidates, languageCandidate(&Framework{
+		Name:           "dotnet",
+		Type:           "dotnet",
+		PackageManager: "dotnet",
+	}, manifest))
+	return candidates, nil
+}
+
+// webReference returns the SDK or reference that makes the project an
+// ASP.NET Core app, or an empty string if it isn't one
+func (p *csproj) webReference() string {
+	if p.Sdk == "Microsoft.NET.Sdk.Web" {
+		return p.Sdk
+	}
+	for _, group := range p.ItemGroups {
+		for _, ref := range group.FrameworkReferences {
+			if ref.Include == "Microsoft.AspNetCore.App" {
+				return ref.Include
+			}
+		}
+		for _, ref := range group.PackageReferences {
+			if strings.HasPrefix(ref.Include, "Microsoft.AspNetCore.") {
+				return ref.Include
+			}
+		}
+	}
+	return ""
+}
+
+// runtimeVersion returns the .NET version of the first target framework
+// (e.g. "8.0" for net8.0)
+func (p *csproj) runtimeVersion() string {
+	for _, group := range p.PropertyGroups {
+		tfm := group.TargetFramework
+		if tfm == "" {
+			tfm, _, _ = strings.Cut(group.TargetFrameworks, ";")
+		}
+		if match := targetFrameworkPattern.FindStringSubmatch(strings.TrimSpace(tfm)); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// globFiles returns the files in dir matching pattern, ignoring errors
+func globFiles(dir, pattern string) []string {
+	matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+	return matches
+}
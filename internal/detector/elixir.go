@@ -0,0 +1,44 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var (
+	// {:phoenix, "~> 1.7.10"}
+	mixDepPattern = regexp.MustCompile(`\{\s*:phoenix\s*,\s*"([^"]+)"`)
+	// "phoenix": {:hex, :phoenix, "1.7.10", ...}
+	mixLockPattern = regexp.MustCompile(`"phoenix"\s*:\s*\{\s*:hex\s*,\s*:phoenix\s*,\s*"([^"]+)"`)
+)
+
+func elixirCandidates(dir string) ([]Candidate, error) {
+	content, err := os.ReadFile(filepath.Join(dir, "mix.exs"))
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []Candidate
+	if match := mixDepPattern.FindSubmatch(content); match != nil {
+		version := ""
+		if lock, err := os.ReadFile(filepath.Join(dir, "mix.lock")); err == nil {
+			if locked := mixLockPattern.FindSubmatch(lock); locked != nil {
+				version = string(locked[1])
+			}
+		}
+
+		fw := newFramework("phoenix", "elixir", version, string(match[1]))
+		fw.PackageManager = "mix"
+		dep := Dependency{Name: "phoenix", Constraint: string(match[1])}
+		candidates = append(candidates, dependencyCandidate(dir, fw, dep, "mix.exs", "mix.lock"))
+	}
+
+	// Generic Elixir project
+	candidates = append(candidates, languageCandidate(&Framework{
+		Name:           "elixir",
+		Type:           "elixir",
+		PackageManager: "mix",
+	}, "mix.exs"))
+	return candidates, nil
+}
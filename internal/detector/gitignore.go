@@ -0,0 +1,143 @@
+package detector
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ignoreRule is a single compiled .gitignore pattern
+type ignoreRule struct {
+	base    string // Directory containing the .gitignore, relative to the scan root
+	pattern *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// ignoreMatcher evaluates .gitignore rules collected while walking a tree.
+// Later rules take precedence, so a nested .gitignore can re-include paths.
+type ignoreMatcher struct {
+	rules []ignoreRule
+}
+
+// load adds the rules from dir/.gitignore, where rel is dir relative to the
+// scan root. A missing file is not an error.
+func (m *ignoreMatcher) load(dir, rel string) {
+	file, err := os.Open(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreRule(scanner.Text(), rel); ok {
+			m.rules = append(m.rules, rule)
+		}
+	}
+}
+
+// ignored reports whether rel (slash-separated, relative to the scan root)
+// is excluded
+func (m *ignoreMatcher) ignored(rel string, isDir bool) bool {
+	ignored := false
+	for _, rule := range m.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		path := rel
+		if rule.base != "." {
+			var ok bool
+			path, ok = strings.CutPrefix(rel, rule.base+"/")
+			if !ok {
+				continue
+			}
+		}
+
+		if rule.pattern.MatchString(path) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+func parseIgnoreRule(line, base string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	rule := ignoreRule{base: filepath.ToSlash(base)}
+
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	}
+	line = strings.TrimPrefix(line, `\`)
+
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	// Patterns containing a slash are anchored to the .gitignore directory;
+	// others match a name at any depth
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	expr := globToRegexp(line)
+	if anchored {
+		expr = "^" + expr + "$"
+	} else {
+		expr = "(^|/)" + expr + "$"
+	}
+
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.pattern = pattern
+
+	return rule, true
+}
+
+// globToRegexp translates a gitignore glob into a regular expression
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "/**"):
+			b.WriteString("(/.*)?")
+			i += 2
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
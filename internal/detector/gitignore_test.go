@@ -0,0 +1,63 @@
+package detector
+
+import "testing"
+
+func TestIgnoreMatcher(t *testing.T) {
+	// Rules from a root .gitignore and one in web/, in load order
+	rules := []struct{ line, base string }{
+		{"# build output", "."},
+		{"node_modules/", "."},
+		{"*.log", "."},
+		{"!keep.log", "."},
+		{"/dist", "."},
+		{"docs/**/generated", "."},
+		{"tmp?", "."},
+		{"cache[0-9]", "."},
+		{"build", "web"},
+	}
+	var m ignoreMatcher
+	for _, r := range rules {
+		if rule, ok := parseIgnoreRule(r.line, r.base); ok {
+			m.rules = append(m.rules, rule)
+		}
+	}
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"node_modules", true, true},
+		{"web/node_modules", true, true},
+		{"node_modules", false, false},
+		{"app.log", false, true},
+		{"logs/app.log", false, true},
+		{"keep.log", false, false},
+		{"dist", true, true},
+		{"web/dist", true, false},
+		{"docs/generated", true, true},
+		{"docs/api/v1/generated", true, true},
+		{"generated", true, false},
+		{"tmp1", true, true},
+		{"tmp12", true, false},
+		{"cache7", true, true},
+		{"cachex", true, false},
+		{"web/build", true, true},
+		{"web/src/build", true, true},
+		{"build", true, false},
+		{"src/main.go", false, false},
+	}
+	for _, tt := range tests {
+		if got := m.ignored(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("ignored(%q, dir=%v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestParseIgnoreRuleSkipsBlankAndComments(t *testing.T) {
+	for _, line := range []string{"", "   ", "# comment", "/", "!"} {
+		if _, ok := parseIgnoreRule(line, "."); ok {
+			t.Errorf("parseIgnoreRule(%q) returned a rule", line)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package detector
+
+import (
+	"bufio"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// goFrameworks are checked in order: web frameworks and routers first, then
+// RPC servers. Major versions v2+ live under a /vN module path suffix.
+var goFrameworks = []struct {
+	name    string
+	modules []string
+	router  bool // Serves HTTP; rules out the net/http fallback
+}{
+	{"gin", []string{"github.com/gin-gonic/gin"}, true},
+	{"echo", []string{"github.com/labstack/echo"}, true},
+	{"fiber", []string{"github.com/gofiber/fiber"}, true},
+	{"chi", []string{"github.com/go-chi/chi"}, true},
+	{"gorilla-mux", []string{"github.com/gorilla/mux"}, true},
+	{"connect", []string{"connectrpc.com/connect", "github.com/bufbuild/connect-go"}, true},
+	{"grpc", []string{"google.golang.org/grpc"}, false},
+}
+
+// hasGoProject reports whether dir is a Go module or workspace
+func hasGoProject(dir string) bool {
+	return fileExists(filepath.Join(dir, "go.mod")) || fileExists(filepath.Join(dir, "go.work"))
+}
+
+// goModule is a module of the project being detected
+type goModule struct {
+	dir  string // Relative to the project ("." for the project itself)
+	path string // Module path
+	deps map[string]Dependency
+}
+
+func goCandidates(dir string) ([]Candidate, error) {
+	modules, workspace, err := goModules(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []Candidate
+	found := make(map[string]int) // Framework name -> index in candidates
+	hasRouter := make(map[string]bool)
+
+	for _, m := range modules {
+		manifest := path.Join(m.dir, "go.mod")
+		for _, fw := range goFrameworks {
+			dep, ok := findGoModule(m.deps, fw.modules)
+			if !ok {
+				continue
+			}
+			if fw.router && !dep.Indirect {
+				hasRouter[m.dir] = true
+			}
+
+			// The same framework in several workspace modules adds evidence
+			if i, ok := found[fw.name]; ok {
+				candidates[i].Evidence = append(candidates[i].Evidence, Evidence{File: manifest, Detail: "requires " + dep.Name + " " + dep.Constraint})
+				continue
+			}
+
+			// go.mod versions are exact (minimal version selection)
+			version := strings.TrimSuffix(strings.TrimPrefix(dep.Constraint, "v"), "+incompatible")
+			framework := newFramework(fw.name, "go", version, dep.Constraint)
+			framework.Workspace = goWorkspace(dir, workspace, m)
+			found[fw.name] = len(candidates)
+			candidates = append(candidates, dependencyCandidate(dir, framework, dep, manifest, manifest))
+		}
+	}
+
+	// Routers built on the standard library need no dependency
+	for _, m := range modules {
+		if hasRouter[m.dir] {
+			continue
+		}
+		if file := findNetHTTPServer(filepath.Join(dir, m.dir)); file != "" {
+			candidates = append(candidates, Candidate{
+				Framework:  &Framework{Name: "net-http", Type: "go", Workspace: goWorkspace(dir, workspace, m)},
+				Confidence: scoreSource,
+				Evidence:   []Evidence{{File: path.Join(m.dir, file), Detail: "serves HTTP with net/http"}},
+			})
+			break
+		}
+	}
+
+	// Generic Go project
+	manifest := "go.mod"
+	if workspace {
+		manifest = "go.work"
+	}
+	generic := &Framework{Name: "go", Type: "go"}
+	if len(modules) > 0 {
+		generic.Workspace = goWorkspace(dir, workspace, modules[0])
+	}
+	candidates = append(candidates, languageCandidate(generic, manifest))
+	return candidates, nil
+}
+
+// goModules returns the module in dir, or the modules of the go.work
+// workspace rooted at dir. workspace reports whether go.work was used.
+func goModules(dir string) (modules []goModule, workspace bool, err error) {
+	dirs := []string{"."}
+	if content, err := os.ReadFile(filepath.Join(dir, "go.work")); err == nil {
+		dirs = parseGoWork(content)
+		workspace = true
+	}
+
+	for _, d := range dirs {
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(d), "go.mod"))
+		if err != nil {
+			if !workspace {
+				return nil, false, err
+			}
+			// A missing workspace module is go's problem to report
+			continue
+		}
+		modules = append(modules, goModule{dir: d, path: goModulePath(content), deps: parseGoMod(content)})
+	}
+	return modules, workspace, nil
+}
+
+// goWorkspace describes module's place in the go.work workspace at root
+func goWorkspace(root string, workspace bool, m goModule) *Workspace {
+	if !workspace {
+		return enclosingGoWorkspace(root, m.path)
+	}
+	member := m.dir
+	if member == "." {
+		member = ""
+	}
+	return &Workspace{Root: root, Member: member, Package: m.path}
+}
+
+// enclosingGoWorkspace returns the go.work workspace in a parent of dir that
+// uses it, or nil. The search stops at the repository root.
+func enclosingGoWorkspace(dir, modulePath string) *Workspace {
+	for root := filepath.Dir(dir); root != dir; dir, root = root, filepath.Dir(root) {
+		if content, err := os.ReadFile(filepath.Join(root, "go.work")); err == nil {
+			rel, err := filepath.Rel(root, dir)
+			if err == nil && slices.Contains(parseGoWork(content), filepath.ToSlash(rel)) {
+				return &Workspace{Root: root, Member: filepath.ToSlash(rel), Package: modulePath}
+			}
+			return nil
+		}
+		if fileExists(filepath.Join(root, ".git")) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// findGoModule returns the dependency on any of modules (or their /vN major
+// versions)
+func findGoModule(deps map[string]Dependency, modules []string) (Dependency, bool) {
+	for _, name := range sortedKeys(deps) {
+		for _, module := range modules {
+			if name == module || isGoMajorVersion(name, module) {
+				return deps[name], true
+			}
+		}
+	}
+	return Dependency{}, false
+}
+
+// isGoMajorVersion reports whether module is a /vN major version of base
+func isGoMajorVersion(module, base string) bool {
+	suffix, ok := strings.CutPrefix(module, base+"/v")
+	if !ok || suffix == "" {
+		return false
+	}
+	return strings.Trim(suffix, "0123456789") == ""
+}
+
+// parseGoWork returns the module directories listed by use directives in a
+// go.work file, as slash-separated paths relative to it
+func parseGoWork(content []byte) []string {
+	var dirs []string
+	seen := make(map[string]bool)
+	inBlock := false
+
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "":
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case line == "use (":
+			inBlock = true
+			continue
+		case strings.HasPrefix(line, "use "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "use "))
+		case !inBlock:
+			continue
+		}
+
+		dir := path.Clean(strings.Trim(line, `"`+"`"))
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
+
+// goModulePath returns the module path declared in go.mod
+func goModulePath(content []byte) string {
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		if rest, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(rest), `"`)
+		}
+	}
+	return ""
+}
+
+// netHTTPServer matches code that serves HTTP with the standard library
+var netHTTPServer = regexp.MustCompile(`\bhttp\.(ListenAndServe(TLS)?\(|NewServeMux\(|Server\{)`)
+
+// Limits for the net/http source scan
+const (
+	maxSourceDepth = 3
+	maxSourceFiles = 300
+)
+
+// findNetHTTPServer returns the first non-test Go file in dir (relative,
+// slash-separated) that starts a net/http server, or "" if there is none.
+// Nested modules, vendor and testdata are not scanned.
+func findNetHTTPServer(dir string) string {
+	var match string
+	scanned := 0
+
+	filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, p)
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if rel == "." {
+				return nil
+			}
+			name := d.Name()
+			if strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata" ||
+				strings.Count(rel, "/") >= maxSourceDepth || fileExists(filepath.Join(p, "go.mod")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(rel, ".go") || strings.HasSuffix(rel, "_test.go") {
+			return nil
+		}
+		if scanned++; scanned > maxSourceFiles {
+			return filepath.SkipAll
+		}
+
+		content, err := os.ReadFile(p)
+		if err == nil && netHTTPServer.Match(content) {
+			match = rel
+			return filepath.SkipAll
+		}
+		return nil
+	})
+
+	return match
+}
@@ -0,0 +1,265 @@
+package detector
+
+import (
+	"bufio"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// jvmArtifact is a Maven coordinate, or a Gradle plugin ID stored as GroupID
+type jvmArtifact struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Scope      string `xml:"scope"`
+}
+
+// jvmProject is the merged view of a Maven or Gradle build
+type jvmProject struct {
+	artifacts      []jvmArtifact
+	properties     map[string]string
+	packageManager string
+}
+
+// jvmFrameworks maps each framework to the coordinates that identify it and
+// where its platform version is declared
+var jvmFrameworks = []struct {
+	name       string
+	group      string   // Group ID (or plugin ID) prefix
+	artifacts  []string // Artifacts whose version is the framework version
+	properties []string // Build properties holding the framework version
+}{
+	{
+		name:       "spring-boot",
+		group:      "org.springframework.boot",
+		artifacts:  []string{"spring-boot-starter-parent", "spring-boot-dependencies", "spring-boot-maven-plugin", gradlePluginArtifact},
+		properties: []string{"spring-boot.version", "springBootVersion"},
+	},
+	{
+		name:       "quarkus",
+		group:      "io.quarkus",
+		artifacts:  []string{"quarkus-bom", "quarkus-universe-bom", "quarkus-maven-plugin", gradlePluginArtifact},
+		properties: []string{"quarkus.platform.version", "quarkusPlatformVersion"},
+	},
+	{
+		name:       "micronaut",
+		group:      "io.micronaut",
+		artifacts:  []string{"micronaut-parent", "micronaut-platform", "micronaut-bom"},
+		properties: []string{"micronaut.version", "micronautVersion"},
+	},
+}
+
+// gradlePluginArtifact marks a jvmArtifact parsed from a Gradle plugins block
+const gradlePluginArtifact = "gradle-plugin"
+
+func jvmCandidates(dir string) ([]Candidate, error) {
+	var project *jvmProject
+	var err error
+	manifest := "pom.xml"
+	if fileExists(filepath.Join(dir, manifest)) {
+		project, err = loadMavenProject(dir)
+	} else {
+		manifest = "build.gradle"
+		if fileExists(filepath.Join(dir, "build.gradle.kts")) {
+			manifest = "build.gradle.kts"
+		}
+		project, err = loadGradleProject(dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []Candidate
+	for _, fw := range jvmFrameworks {
+		var matched *jvmArtifact
+		version := ""
+
+		for i, artifact := range project.artifacts {
+			if artifact.Scope == "test" || !strings.HasPrefix(artifact.GroupID, fw.group) {
+				continue
+			}
+			if matched == nil {
+				matched = &project.artifacts[i]
+			}
+
+			for _, name := range fw.artifacts {
+				if artifact.ArtifactID == name && version == "" {
+					version = project.resolve(artifact.Version)
+					matched = &project.artifacts[i]
+				}
+			}
+		}
+		if matched == nil {
+			continue
+		}
+
+		versionFile := manifest
+		for _, key := range fw.properties {
+			if version == "" {
+				version = project.properties[key]
+				if version != "" && manifest != "pom.xml" && !strings.Contains(version, "${") {
+					versionFile = "gradle.properties"
+				}
+			}
+		}
+
+		// Unresolved placeholders aren't useful as a version
+		if strings.Contains(version, "${") {
+			version = ""
+		}
+
+		framework := newFramework(fw.name, "java", version, "")
+		framework.PackageManager = project.packageManager
+		dep := Dependency{Name: matched.GroupID + ":" + matched.ArtifactID}
+		candidates = append(candidates, dependencyCandidate(dir, framework, dep, manifest, versionFile))
+	}
+
+	// Generic JVM project
+	candidates = append(candidates, languageCandidate(&Framework{
+		Name:           "java",
+		Type:           "java",
+		PackageManager: project.packageManager,
+	}, manifest))
+	return candidates, nil
+}
+
+// resolve expands ${property} references from the build properties
+func (p *jvmProject) resolve(value string) string {
+	for i := 0; i < 5 && strings.Contains(value, "${"); i++ {
+		value = propertyRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+			if v, ok := p.properties[ref[2:len(ref)-1]]; ok {
+				return v
+			}
+			return ref
+		})
+	}
+	return value
+}
+
+var propertyRefPattern = regexp.MustCompile(`\$\{[^}]+\}`)
+
+// loadMavenProject reads the parent, dependencies, dependency management,
+// plugins and properties from pom.xml
+func loadMavenProject(dir string) (*jvmProject, error) {
+	content, err := os.ReadFile(filepath.Join(dir, "pom.xml"))
+	if err != nil {
+		return nil, err
+	}
+
+	var pom struct {
+		Parent     jvmArtifact `xml:"parent"`
+		Version    string      `xml:"version"`
+		Properties struct {
+			Entries []struct {
+				XMLName xml.Name
+				Value   string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"properties"`
+		Dependencies         []jvmArtifact `xml:"dependencies>dependency"`
+		DependencyManagement []jvmArtifact `xml:"dependencyManagement>dependencies>dependency"`
+		Plugins              []jvmArtifact `xml:"build>plugins>plugin"`
+	}
+	if err := xml.Unmarshal(content, &pom); err != nil {
+		return nil, err
+	}
+
+	project := &jvmProject{
+		properties:     make(map[string]string),
+		packageManager: "maven",
+	}
+	for _, entry := range pom.Properties.Entries {
+		project.properties[entry.XMLName.Local] = strings.TrimSpace(entry.Value)
+	}
+	project.properties["project.parent.version"] = pom.Parent.Version
+	project.properties["project.version"] = pom.Version
+
+	if pom.Parent.GroupID != "" {
+		project.artifacts = append(project.artifacts, pom.Parent)
+	}
+	project.artifacts = append(project.artifacts, pom.DependencyManagement...)
+	project.artifacts = append(project.artifacts, pom.Dependencies...)
+	project.artifacts = append(project.artifacts, pom.Plugins...)
+
+	return project, nil
+}
+
+var (
+	// id("org.springframework.boot") version "3.2.0"  /  id 'io.quarkus'
+	gradlePluginPattern = regexp.MustCompile(`\bid\s*\(?\s*["']([\w.\-]+)["']\s*\)?(?:\s*version\s*\(?\s*["']([^"']+)["'])?`)
+	// "group:artifact:version" or "group:artifact"
+	gradleCoordinatePattern = regexp.MustCompile(`["']([\w.\-]+):([\w.\-]+)(?::([^"'@]+))?["']`)
+	// Test-only configurations: testImplementation, testRuntimeOnly, ...
+	gradleTestConfigPattern = regexp.MustCompile(`^\s*test\w*\s*[( ]`)
+)
+
+// loadGradleProject reads plugins and dependency coordinates from
+// build.gradle(.kts), with properties from gradle.properties
+func loadGradleProject(dir string) (*jvmProject, error) {
+	project := &jvmProject{
+		properties:     make(map[string]string),
+		packageManager: "gradle",
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dir, "gradle.properties")); err == nil {
+		scanner := bufio.NewScanner(strings.NewReader(string(content)))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+				continue
+			}
+			if key, value, ok := strings.Cut(line, "="); ok {
+				project.properties[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		}
+	}
+
+	var content []byte
+	var err error
+	for _, name := range []string{"build.gradle.kts", "build.gradle"} {
+		if content, err = os.ReadFile(filepath.Join(dir, name)); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Groovy also allows $name without braces
+	build := regexp.MustCompile(`\$(\w+)`).ReplaceAllString(string(content), "$${$1}")
+
+	scanner := bufio.NewScanner(strings.NewReader(build))
+	for scanner.Scan() {
+		line := project.resolve(scanner.Text())
+		if i := strings.Index(line, "//"); i >= 0 && !strings.Contains(line[:i], "\"") {
+			line = line[:i]
+		}
+
+		scope := ""
+		if gradleTestConfigPattern.MatchString(line) {
+			scope = "test"
+		}
+
+		if match := gradlePluginPattern.FindStringSubmatch(line); match != nil {
+			project.artifacts = append(project.artifacts, jvmArtifact{
+				GroupID:    match[1],
+				ArtifactID: gradlePluginArtifact,
+				Version:    match[2],
+			})
+			continue
+		}
+
+		for _, match := range gradleCoordinatePattern.FindAllStringSubmatch(line, -1) {
+			project.artifacts = append(project.artifacts, jvmArtifact{
+				GroupID:    match[1],
+				ArtifactID: match[2],
+				Version:    match[3],
+				Scope:      scope,
+			})
+		}
+	}
+
+	return project, nil
+}
@@ -0,0 +1,231 @@
+package detector
+
+import (
+	"bufio"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// Dependency is a package declared in a manifest
+type Dependency struct {
+	Name       string
+	Constraint string // Version constraint as declared (e.g. "^4.18.2", "~> 7.1", ">=4.2,<5")
+	Dev        bool   // Declared as a development-only dependency
+	Indirect   bool   // Go module required only transitively ("// indirect")
+	Source     string // Manifest the dependency was declared in, when merged from several
+}
+
+// parseGoMod returns the modules required by a go.mod file, keyed by module
+// path. Both single-line requires and require blocks are supported.
+func parseGoMod(content []byte) map[string]Dependency {
+	deps := make(map[string]Dependency)
+	inBlock := false
+
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		indirect := false
+		if i := strings.Index(line, "//"); i >= 0 {
+			indirect = strings.TrimSpace(line[i+2:]) == "indirect"
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+
+		switch {
+		case line == "":
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case line == "require (":
+			inBlock = true
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require "))
+		case !inBlock:
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		deps[fields[0]] = Dependency{Name: fields[0], Constraint: fields[1], Indirect: indirect}
+	}
+
+	return deps
+}
+
+// parsePackageJSON returns the dependencies and devDependencies declared in a
+// package.json file, keyed by package name
+func parsePackageJSON(content []byte) (map[string]Dependency, error) {
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, err
+	}
+
+	deps := make(map[string]Dependency)
+	for name, constraint := range pkg.DevDependencies {
+		deps[name] = Dependency{Name: name, Constraint: constraint, Dev: true}
+	}
+	// Runtime dependencies win when a package is listed in both
+	for name, constraint := range pkg.Dependencies {
+		deps[name] = Dependency{Name: name, Constraint: constraint}
+	}
+
+	return deps, nil
+}
+
+// parseComposerJSON returns the packages required by a composer.json file,
+// keyed by package name
+func parseComposerJSON(content []byte) (map[string]Dependency, error) {
+	var composer struct {
+		Require    map[string]string `json:"require"`
+		RequireDev map[string]string `json:"require-dev"`
+	}
+	if err := json.Unmarshal(content, &composer); err != nil {
+		return nil, err
+	}
+
+	deps := make(map[string]Dependency)
+	for name, constraint := range composer.RequireDev {
+		deps[strings.ToLower(name)] = Dependency{Name: name, Constraint: constraint, Dev: true}
+	}
+	for name, constraint := range composer.Require {
+		deps[strings.ToLower(name)] = Dependency{Name: name, Constraint: constraint}
+	}
+
+	return deps, nil
+}
+
+// parseComposerLock returns the locked version of every package in a
+// composer.lock file, keyed by package name
+func parseComposerLock(content []byte) map[string]string {
+	var lock struct {
+		Packages []struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"packages"`
+		PackagesDev []struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"packages-dev"`
+	}
+	if json.Unmarshal(content, &lock) != nil {
+		return nil
+	}
+
+	versions := make(map[string]string)
+	for _, pkg := range append(lock.PackagesDev, lock.Packages...) {
+		versions[strings.ToLower(pkg.Name)] = strings.TrimPrefix(pkg.Version, "v")
+	}
+	return versions
+}
+
+// requirementPattern splits a requirements.txt line into the project name,
+// optional extras and the version specifier
+var requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(\[[^\]]*\])?\s*(.*)$`)
+
+// parseRequirements returns the packages listed in a requirements.txt file,
+// keyed by normalized project name
+func parseRequirements(content []byte) map[string]Dependency {
+	deps := make(map[string]Dependency)
+
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+
+		// Skip comments, options (-r, -e, --index-url) and direct URLs
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") || strings.Contains(line, "://") {
+			continue
+		}
+
+		// Drop environment markers
+		if i := strings.Index(line, ";"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+
+		match := requirementPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		name := normalizePythonName(match[1])
+		deps[name] = Dependency{Name: match[1], Constraint: strings.TrimSpace(match[3])}
+	}
+
+	return deps
+}
+
+// pythonNameSeparators are the runs PEP 503 normalizes to a single "-"
+var pythonNameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// normalizePythonName applies PEP 503 name normalization
+func normalizePythonName(name string) string {
+	return pythonNameSeparators.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+// gemPattern matches a gem declaration in a Gemfile
+var gemPattern = regexp.MustCompile(`^\s*gem\s+["']([^"']+)["']\s*(?:,\s*["']([^"']+)["'])?`)
+
+// parseGemfile returns the gems declared in a Gemfile, keyed by gem name
+func parseGemfile(content []byte) map[string]Dependency {
+	deps := make(map[string]Dependency)
+
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		match := gemPattern.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		deps[match[1]] = Dependency{Name: match[1], Constraint: match[2]}
+	}
+
+	return deps
+}
+
+// gemSpecPattern matches a resolved gem in the GEM specs section of a
+// Gemfile.lock ("    rails (7.1.3)")
+var gemSpecPattern = regexp.MustCompile(`^    ([^\s(]+) \(([^)]+)\)$`)
+
+// parseGemfileLock returns the resolved version of every gem in a
+// Gemfile.lock, keyed by gem name
+func parseGemfileLock(content []byte) map[string]string {
+	versions := make(map[string]string)
+	inSpecs := false
+
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.TrimSpace(line) == "specs:":
+			inSpecs = true
+			continue
+		case line == "" || !strings.HasPrefix(line, " "):
+			inSpecs = false
+			continue
+		case !inSpecs:
+			continue
+		}
+
+		if match := gemSpecPattern.FindStringSubmatch(line); match != nil {
+			// Platform-specific gems look like "nokogiri (1.16.0-x86_64-linux)"
+			version := match[2]
+			if i := strings.Index(version, "-"); i >= 0 {
+				version = version[:i]
+			}
+			versions[match[1]] = version
+		}
+	}
+
+	return versions
+}
@@ -0,0 +1,69 @@
+package detector
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseComposerLock(t *testing.T) {
+	tests := []struct {
+		name string
+		lock string
+		want map[string]string
+	}{
+		{
+			name: "packages and dev packages",
+			lock: `{"packages": [{"name": "Laravel/Framework", "version": "v10.48.4"}], "packages-dev": [{"name": "phpunit/phpunit", "version": "10.5.11"}]}`,
+			want: map[string]string{"laravel/framework": "10.48.4", "phpunit/phpunit": "10.5.11"},
+		},
+		{
+			name: "runtime package wins over dev",
+			lock: `{"packages": [{"name": "symfony/console", "version": "v7.0.4"}], "packages-dev": [{"name": "symfony/console", "version": "v6.4.0"}]}`,
+			want: map[string]string{"symfony/console": "7.0.4"},
+		},
+		{
+			name: "invalid",
+			lock: `{"packages": `,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseComposerLock([]byte(tt.lock)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseComposerLock() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGemfileLock(t *testing.T) {
+	const lock = `GIT
+  remote: https://github.com/example/widget.git
+  specs:
+    widget (0.3.0)
+
+GEM
+  remote: https://rubygems.org/
+  specs:
+    nokogiri (1.16.0-x86_64-linux)
+      racc (~> 1.4)
+    rails (7.1.3)
+      actionpack (= 7.1.3)
+    racc (1.7.3)
+
+PLATFORMS
+  x86_64-linux
+
+DEPENDENCIES
+  rails (~> 7.1)
+`
+	want := map[string]string{
+		"widget":   "0.3.0",
+		"nokogiri": "1.16.0",
+		"rails":    "7.1.3",
+		"racc":     "1.7.3",
+	}
+	if got := parseGemfileLock([]byte(lock)); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseGemfileLock() = %v, want %v", got, want)
+	}
+}
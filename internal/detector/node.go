@@ -0,0 +1,471 @@
+package detector
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Workspace describes the Node.js or Go (go.work) workspace a project
+// belongs to
+type Workspace struct {
+	Root    string `json:"root"`              // Absolute workspace root directory
+	Member  string `json:"member,omitempty"`  // Project path relative to Root ("" when the project is the root)
+	Package string `json:"package,omitempty"` // package.json name (Node.js) or module path (Go) of the member
+}
+
+// nodeFrameworks are checked in order. Meta-frameworks come first because
+// they are often served through express or fastify; they are also commonly
+// listed in devDependencies, so those count for them.
+var nodeFrameworks = []struct {
+	name     string
+	packages []string
+	allowDev bool
+}{
+	{"nestjs", []string{"@nestjs/core"}, false},
+	{"nextjs", []string{"next"}, false},
+	{"nuxt", []string{"nuxt"}, true},
+	{"remix", []string{"@remix-run/node", "@remix-run/react", "@remix-run/dev"}, true},
+	{"sveltekit", []string{"@sveltejs/kit"}, true},
+	{"astro", []string{"astro"}, true},
+	{"fastify", []string{"fastify"}, false},
+	{"koa", []string{"koa"}, false},
+	{"hapi", []string{"@hapi/hapi", "hapi"}, false},
+	{"express", []string{"express"}, false},
+}
+
+// frontendFrameworks run in the browser. They are only reported when no
+// server framework is found, since SSR apps and APIs often depend on them too.
+var frontendFrameworks = []struct {
+	name     string
+	packages []string
+	allowDev bool
+}{
+	{"angular", []string{"@angular/core"}, false},
+	{"react", []string{"react-dom", "react"}, false},
+	{"vue", []string{"vue"}, false},
+	{"svelte", []string{"svelte"}, true},
+	{"vite", []string{"vite"}, true},
+}
+
+// packageJSON is the subset of package.json the detector reads
+type packageJSON struct {
+	Name           string          `json:"name"`
+	PackageManager string          `json:"packageManager"`
+	Workspaces     json.RawMessage `json:"workspaces"`
+}
+
+func nodeCandidates(dir string) ([]Candidate, error) {
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	// A malformed package.json still makes a Node.js project
+	deps, err := parsePackageJSON(content)
+	var warnings []string
+	if err != nil {
+		warnings = append(warnings, malformedManifest("package.json", err))
+	}
+
+	workspace := findNodeWorkspace(dir)
+	packageManager := detectNodePackageManager(dir, workspace)
+
+	var candidates []Candidate
+	for _, fw := range nodeFrameworks {
+		for _, pkgName := range fw.packages {
+			dep, ok := deps[pkgName]
+			if !ok || (dep.Dev && !fw.allowDev) {
+				continue
+			}
+
+			version, versionFile := resolveNodeVersion(dir, workspace, pkgName)
+			framework := newFramework(fw.name, "node", version, dep.Constraint)
+			framework.PackageManager = packageManager
+			framework.Workspace = workspace
+			candidates = append(candidates, dependencyCandidate(dir, framework, dep, "package.json", versionFile))
+			break
+		}
+	}
+
+	// Single-page apps: a frontend framework and no server
+	if len(candidates) == 0 {
+		for _, fw := range frontendFrameworks {
+			for _, pkgName := range fw.packages {
+				dep, ok := deps[pkgName]
+				if !ok || (dep.Dev && !fw.allowDev) {
+					continue
+				}
+
+				version, versionFile := resolveNodeVersion(dir, workspace, pkgName)
+				framework := newFramework(fw.name, "node", version, dep.Constraint)
+				framework.PackageManager = packageManager
+				framework.Workspace = workspace
+				framework.Browser = true
+				candidates = append(candidates, dependencyCandidate(dir, framework, dep, "package.json", versionFile))
+				break
+			}
+		}
+	}
+
+	// Generic Node.js project
+	node := languageCandidate(&Framework{
+		Name:           "node",
+		Type:           "node",
+		PackageManager: packageManager,
+		Workspace:      workspace,
+	}, "package.json")
+	node.Warnings = warnings
+	candidates = append(candidates, node)
+	return candidates, nil
+}
+
+// findNodeWorkspace returns the workspace dir belongs to, looking in dir and
+// its parents for package.json "workspaces" or pnpm-workspace.yaml. It
+// returns nil when dir is not part of a workspace.
+func findNodeWorkspace(dir string) *Workspace {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil
+	}
+
+	for root := dir; ; root = filepath.Dir(root) {
+		if patterns := workspacePatterns(root); patterns != nil {
+			member, _ := filepath.Rel(root, dir)
+			member = filepath.ToSlash(member)
+			if member == "." {
+				member = ""
+			}
+
+			if member != "" && !matchWorkspace(patterns, member) {
+				return nil
+			}
+
+			workspace := &Workspace{Root: root, Member: member}
+			if pkg, err := readPackageJSON(dir); err == nil {
+				workspace.Package = pkg.Name
+			}
+			return workspace
+		}
+
+		// Don't look past the repository root
+		if fileExists(filepath.Join(root, ".git")) || filepath.Dir(root) == root {
+			return nil
+		}
+	}
+}
+
+// workspacePatterns returns the workspace globs declared in dir, or nil if
+// dir is not a workspace root
+func workspacePatterns(dir string) []string {
+	if content, err := os.ReadFile(filepath.Join(dir, "pnpm-workspace.yaml")); err == nil {
+		var config struct {
+			Packages []string `yaml:"packages"`
+		}
+		if yaml.Unmarshal(content, &config) == nil {
+			return append([]string{}, config.Packages...)
+		}
+	}
+
+	pkg, err := readPackageJSON(dir)
+	if err != nil || len(pkg.Workspaces) == 0 {
+		return nil
+	}
+
+	// "workspaces": [...] or "workspaces": {"packages": [...]}
+	var patterns []string
+	if json.Unmarshal(pkg.Workspaces, &patterns) != nil {
+		var object struct {
+			Packages []string `json:"packages"`
+		}
+		if json.Unmarshal(pkg.Workspaces, &object) != nil {
+			return nil
+		}
+		patterns = object.Packages
+	}
+	return append([]string{}, patterns...)
+}
+
+// matchWorkspace reports whether member matches the workspace globs, where
+// later "!" patterns exclude earlier matches
+func matchWorkspace(patterns []string, member string) bool {
+	matched := false
+	for _, pattern := range patterns {
+		negate := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(pattern, "!"), "./"), "/")
+
+		re, err := regexp.Compile("^" + globToRegexp(pattern) + "$")
+		if err != nil {
+			continue
+		}
+		if re.MatchString(member) {
+			matched = !negate
+		}
+	}
+	return matched
+}
+
+func readPackageJSON(dir string) (*packageJSON, error) {
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var pkg packageJSON
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, err
+	}
+	return &pkg, nil
+}
+
+// detectNodePackageManager returns "npm", "pnpm", "yarn" (classic),
+// "yarn-berry" (v2+) or "bun". The corepack packageManager field wins,
+// followed by lockfiles in dir and then the workspace root.
+func detectNodePackageManager(dir string, workspace *Workspace) string {
+	dirs := []string{dir}
+	if workspace != nil && workspace.Root != dir {
+		dirs = append(dirs, workspace.Root)
+	}
+
+	for _, d := range dirs {
+		if pkg, err := readPackageJSON(d); err == nil && pkg.PackageManager != "" {
+			name, version, _ := strings.Cut(pkg.PackageManager, "@")
+			if name == "yarn" && !strings.HasPrefix(version, "1.") {
+				return "yarn-berry"
+			}
+			return name
+		}
+	}
+
+	for _, d := range dirs {
+		switch {
+		case fileExists(filepath.Join(d, "pnpm-lock.yaml")):
+			return "pnpm"
+		case fileExists(filepath.Join(d, "bun.lockb")), fileExists(filepath.Join(d, "bun.lock")):
+			return "bun"
+		case fileExists(filepath.Join(d, "yarn.lock")):
+			if isYarnBerry(d) {
+				return "yarn-berry"
+			}
+			return "yarn"
+		case fileExists(filepath.Join(d, "package-lock.json")):
+			return "npm"
+		}
+	}
+
+	return "npm"
+}
+
+// isYarnBerry distinguishes Yarn v2+ (which writes .yarnrc.yml and a YAML
+// lockfile with a __metadata entry) from Yarn classic
+func isYarnBerry(dir string) bool {
+	if fileExists(filepath.Join(dir, ".yarnrc.yml")) {
+		return true
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "yarn.lock"))
+	return err == nil && strings.Contains(string(content), "\n__metadata:")
+}
+
+// resolveNodeVersion returns the installed version of a package from the
+// project or workspace lockfile, or from node_modules, along with the file it
+// was read from (relative to dir)
+func resolveNodeVersion(dir string, workspace *Workspace, name string) (string, string) {
+	root, member := dir, ""
+	if workspace != nil {
+		root, member = workspace.Root, workspace.Member
+	}
+
+	lockDirs := []string{dir}
+	if root != dir {
+		lockDirs = append(lockDirs, root)
+	}
+
+	source := func(d string, parts ...string) string {
+		rel, err := filepath.Rel(dir, filepath.Join(append([]string{d}, parts...)...))
+		if err != nil {
+			return filepath.Join(parts...)
+		}
+		return filepath.ToSlash(rel)
+	}
+
+	for _, d := range lockDirs {
+		importer := ""
+		if d == root {
+			importer = member
+		}
+
+		if version := packageLockVersion(d, importer, name); version != "" {
+			return version, source(d, "package-lock.json")
+		}
+		if version := pnpmLockVersion(d, importer, name); version != "" {
+			return version, source(d, "pnpm-lock.yaml")
+		}
+		if version := yarnLockVersion(d, name); version != "" {
+			return version, source(d, "yarn.lock")
+		}
+	}
+
+	// Installed packages (pnpm symlinks these too); workspaces hoist to the root
+	for _, d := range lockDirs {
+		if content, err := os.ReadFile(filepath.Join(d, "node_modules", name, "package.json")); err == nil {
+			var pkg struct {
+				Version string `json:"version"`
+			}
+			if json.Unmarshal(content, &pkg) == nil && pkg.Version != "" {
+				return pkg.Version, source(d, "node_modules", name, "package.json")
+			}
+		}
+	}
+
+	return "", ""
+}
+
+// packageLockVersion reads package-lock.json. importer is the workspace
+// member path when dir is the workspace root.
+func packageLockVersion(dir, importer, name string) string {
+	content, err := os.ReadFile(filepath.Join(dir, "package-lock.json"))
+	if err != nil {
+		return ""
+	}
+
+	var lock struct {
+		Packages map[string]struct {
+			Version string `json:"version"`
+		} `json:"packages"`
+		Dependencies map[string]struct {
+			Version string `json:"version"`
+		} `json:"dependencies"`
+	}
+	if json.Unmarshal(content, &lock) != nil {
+		return ""
+	}
+
+	// Lockfile v2/v3, preferring a copy nested under the workspace member
+	keys := []string{"node_modules/" + name}
+	if importer != "" {
+		keys = append([]string{importer + "/node_modules/" + name}, keys...)
+	}
+	for _, key := range keys {
+		if pkg, ok := lock.Packages[key]; ok && pkg.Version != "" {
+			return pkg.Version
+		}
+	}
+
+	// Lockfile v1
+	if pkg, ok := lock.Dependencies[name]; ok {
+		return pkg.Version
+	}
+	return ""
+}
+
+// pnpmLockVersion reads pnpm-lock.yaml (v5 through v9)
+func pnpmLockVersion(dir, importer, name string) string {
+	content, err := os.ReadFile(filepath.Join(dir, "pnpm-lock.yaml"))
+	if err != nil {
+		return ""
+	}
+
+	type depTables struct {
+		Dependencies    map[string]yaml.Node `yaml:"dependencies"`
+		DevDependencies map[string]yaml.Node `yaml:"devDependencies"`
+	}
+	var lock struct {
+		depTables `yaml:",inline"`
+		Importers map[string]depTables `yaml:"importers"`
+	}
+	if yaml.Unmarshal(content, &lock) != nil {
+		return ""
+	}
+
+	tables := lock.depTables
+	if importer == "" {
+		importer = "."
+	}
+	if imp, ok := lock.Importers[importer]; ok {
+		tables = imp
+	}
+
+	for _, deps := range []map[string]yaml.Node{tables.Dependencies, tables.DevDependencies} {
+		node, ok := deps[name]
+		if !ok {
+			continue
+		}
+
+		// v5 stores the version directly, v6+ a {specifier, version} map
+		var version string
+		if node.Kind == yaml.MappingNode {
+			var entry struct {
+				Version string `yaml:"version"`
+			}
+			_ = node.Decode(&entry)
+			version = entry.Version
+		} else {
+			version = node.Value
+		}
+
+		// Strip peer dependency suffixes: 14.1.3(react@18.2.0) or 14.1.3_react@18.2.0
+		if i := strings.IndexAny(version, "(_"); i >= 0 {
+			version = version[:i]
+		}
+		if strings.HasPrefix(version, "link:") {
+			return ""
+		}
+		return version
+	}
+	return ""
+}
+
+// yarnLockVersion reads yarn.lock (classic and berry). Entries look like:
+//
+//	"next@^14.1.0", "next@^14.0.0":      (classic)
+//	  version "14.1.3"
+//	"next@npm:^14.1.0":                   (berry)
+//	  version: 14.1.3
+func yarnLockVersion(dir, name string) string {
+	file, err := os.Open(filepath.Join(dir, "yarn.lock"))
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	inEntry := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line != "" && !strings.HasPrefix(line, " ") {
+			inEntry = false
+			for _, spec := range strings.Split(strings.TrimSuffix(line, ":"), ",") {
+				spec = strings.Trim(strings.TrimSpace(spec), `"`)
+				if strings.HasPrefix(spec, name+"@") {
+					inEntry = true
+					break
+				}
+			}
+			continue
+		}
+
+		if inEntry {
+			trimmed := strings.TrimSpace(line)
+			if rest, ok := strings.CutPrefix(trimmed, "version"); ok {
+				return strings.Trim(strings.TrimSpace(strings.TrimPrefix(rest, ":")), `"`)
+			}
+		}
+	}
+	return ""
+}
+
+// isFrontendFramework reports whether name is a browser framework
+func isFrontendFramework(name string) bool {
+	for _, fw := range frontendFrameworks {
+		if fw.name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeLockfile writes content to name in a new temporary directory
+func writeLockfile(t *testing.T, name, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestPackageLockVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		lock     string
+		importer string
+		pkg      string
+		want     string
+	}{
+		{
+			name: "v3",
+			lock: `{"packages": {"": {}, "node_modules/next": {"version": "14.1.3"}}}`,
+			pkg:  "next",
+			want: "14.1.3",
+		},
+		{
+			name:     "v3 workspace member copy",
+			lock:     `{"packages": {"node_modules/next": {"version": "13.5.0"}, "apps/web/node_modules/next": {"version": "14.1.3"}}}`,
+			importer: "apps/web",
+			pkg:      "next",
+			want:     "14.1.3",
+		},
+		{
+			name:     "v3 hoisted for workspace member",
+			lock:     `{"packages": {"node_modules/next": {"version": "13.5.0"}}}`,
+			importer: "apps/web",
+			pkg:      "next",
+			want:     "13.5.0",
+		},
+		{
+			name: "v1",
+			lock: `{"dependencies": {"express": {"version": "4.18.2"}}}`,
+			pkg:  "express",
+			want: "4.18.2",
+		},
+		{
+			name: "scoped",
+			lock: `{"packages": {"node_modules/@nestjs/core": {"version": "10.3.0"}}}`,
+			pkg:  "@nestjs/core",
+			want: "10.3.0",
+		},
+		{
+			name: "missing",
+			lock: `{"packages": {"node_modules/next": {"version": "14.1.3"}}}`,
+			pkg:  "express",
+			want: "",
+		},
+		{
+			name: "invalid",
+			lock: `{`,
+			pkg:  "next",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeLockfile(t, "package-lock.json", tt.lock)
+			if got := packageLockVersion(dir, tt.importer, tt.pkg); got != tt.want {
+				t.Errorf("packageLockVersion(%q) = %q, want %q", tt.pkg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPnpmLockVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		lock     string
+		importer string
+		pkg      string
+		want     string
+	}{
+		{
+			name: "v5",
+			lock: "lockfileVersion: 5.4\ndependencies:\n  next: 14.1.3_react@18.2.0\n",
+			pkg:  "next",
+			want: "14.1.3",
+		},
+		{
+			name: "v6 peer suffix",
+			lock: "lockfileVersion: '6.0'\ndependencies:\n  next:\n    specifier: ^14.1.0\n    version: 14.1.3(react@18.2.0)\n",
+			pkg:  "next",
+			want: "14.1.3",
+		},
+		{
+			name:     "v9 importer",
+			lock:     "lockfileVersion: '9.0'\nimporters:\n  .:\n    dependencies: {}\n  apps/web:\n    dependencies:\n      next:\n        specifier: ^14.1.0\n        version: 14.1.3\n",
+			importer: "apps/web",
+			pkg:      "next",
+			want:     "14.1.3",
+		},
+		{
+			name: "dev dependency",
+			lock: "lockfileVersion: '9.0'\nimporters:\n  .:\n    devDependencies:\n      vite:\n        specifier: ^5.0.0\n        version: 5.0.12\n",
+			pkg:  "vite",
+			want: "5.0.12",
+		},
+		{
+			name: "workspace link",
+			lock: "lockfileVersion: '9.0'\nimporters:\n  .:\n    dependencies:\n      shared:\n        specifier: workspace:*\n        version: link:packages/shared\n",
+			pkg:  "shared",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeLockfile(t, "pnpm-lock.yaml", tt.lock)
+			if got := pnpmLockVersion(dir, tt.importer, tt.pkg); got != tt.want {
+				t.Errorf("pnpmLockVersion(%q) = %q, want %q", tt.pkg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYarnLockVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		lock string
+		pkg  string
+		want string
+	}{
+		{
+			name: "classic",
+			lock: "# yarn lockfile v1\n\n\"next@^14.0.0\", \"next@^14.1.0\":\n  version \"14.1.3\"\n  resolved \"https://registry.yarnpkg.com/next/-/next-14.1.3.tgz\"\n",
+			pkg:  "next",
+			want: "14.1.3",
+		},
+		{
+			name: "berry",
+			lock: "__metadata:\n  version: 8\n\n\"next@npm:^14.1.0\":\n  version: 14.1.3\n  resolution: \"next@npm:14.1.3\"\n",
+			pkg:  "next",
+			want: "14.1.3",
+		},
+		{
+			name: "scoped",
+			lock: "\"@nestjs/core@^10.0.0\":\n  version \"10.3.0\"\n",
+			pkg:  "@nestjs/core",
+			want: "10.3.0",
+		},
+		{
+			name: "name prefix of another package",
+			lock: "\"next-auth@^4.24.0\":\n  version \"4.24.5\"\n",
+			pkg:  "next",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeLockfile(t, "yarn.lock", tt.lock)
+			if got := yarnLockVersion(dir, tt.pkg); got != tt.want {
+				t.Errorf("yarnLockVersion(%q) = %q, want %q", tt.pkg, got, tt.want)
+			}
+		})
+	}
+}
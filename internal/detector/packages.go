@@ -0,0 +1,94 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Package is a dependency of a project and the version it resolves to
+type Package struct {
+	Name        string
+	Constraint  string // As declared in the manifest
+	Version     string // Resolved version ("" when no lockfile pins it)
+	Manifest    string // File declaring the dependency, relative to the project
+	VersionFile string // File the version was read from ("" when unresolved)
+}
+
+// FindPackage looks up a dependency on name in the manifests of the project
+// in dir, resolving its version from the ecosystem's lockfile
+func FindPackage(dir, name string) (*Package, bool) {
+	manifests := manifestDependencies(dir)
+	for _, manifest := range sortedKeys(manifests) {
+		deps := manifests[manifest]
+		dep, ok := deps[name]
+		if !ok {
+			// Python names are keyed normalized
+			dep, ok = deps[normalizePythonName(name)]
+		}
+		if !ok {
+			continue
+		}
+
+		pkg := &Package{Name: dep.Name, Constraint: dep.Constraint, Manifest: manifest}
+		pkg.Version, pkg.VersionFile = resolvePackageVersion(dir, manifest, dep)
+		return pkg, true
+	}
+	return nil, false
+}
+
+// resolvePackageVersion returns the version dep resolves to and the file it
+// was read from
+func resolvePackageVersion(dir, manifest string, dep Dependency) (string, string) {
+	lockVersion := func(lockfile string, parse func([]byte) map[string]string, key string) (string, string) {
+		content, err := os.ReadFile(filepath.Join(dir, lockfile))
+		if err != nil {
+			return "", ""
+		}
+		if version := parse(content)[key]; version != "" {
+			return strings.TrimPrefix(version, "v"), lockfile
+		}
+		return "", ""
+	}
+
+	switch manifest {
+	case "go.mod":
+		// go.mod versions are exact (minimal version selection)
+		return strings.TrimSuffix(strings.TrimPrefix(dep.Constraint, "v"), "+incompatible"), manifest
+	case "package.json":
+		return resolveNodeVersion(dir, findNodeWorkspace(dir), dep.Name)
+	case "composer.json":
+		return lockVersion("composer.lock", parseComposerLock, dep.Name)
+	case "Gemfile":
+		return lockVersion("Gemfile.lock", parseGemfileLock, dep.Name)
+	case "Cargo.toml":
+		if version, file := lockVersion("Cargo.lock", parsePackageLock, normalizePythonName(dep.Name)); version != "" {
+			return version, file
+		}
+	}
+
+	if hasPythonManifest(dir) && dep.Source != "" {
+		if project, err := loadPythonProject(dir); err == nil {
+			key := normalizePythonName(dep.Name)
+			if version := project.locked[key]; version != "" {
+				return version, project.lockSources[key]
+			}
+		}
+		if version := pinnedVersion(dep.Constraint); version != "" {
+			return version, dep.Source
+		}
+		return "", ""
+	}
+
+	// Maven, Gradle and NuGet declare exact versions
+	if version := versionPattern.FindString(dep.Constraint); version != "" && version == strings.TrimSpace(dep.Constraint) {
+		return version, manifest
+	}
+	return "", ""
+}
+
+// CompareVersions compares two dotted version strings numerically, returning
+// -1, 0 or 1
+func CompareVersions(a, b string) int {
+	return compareVersions(a, b)
+}
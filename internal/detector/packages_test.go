@@ -0,0 +1,86 @@
+package detector
+
+import "testing"
+
+func TestResolvePackageVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		manifest     string
+		lockfile     string
+		lock         string
+		dep          Dependency
+		wantVersion  string
+		wantFromFile string
+	}{
+		{
+			name:         "go.mod",
+			manifest:     "go.mod",
+			dep:          Dependency{Name: "github.com/gin-gonic/gin", Constraint: "v1.9.1"},
+			wantVersion:  "1.9.1",
+			wantFromFile: "go.mod",
+		},
+		{
+			name:         "go.mod incompatible",
+			manifest:     "go.mod",
+			dep:          Dependency{Name: "github.com/example/old", Constraint: "v2.0.0+incompatible"},
+			wantVersion:  "2.0.0",
+			wantFromFile: "go.mod",
+		},
+		{
+			name:         "composer.lock",
+			manifest:     "composer.json",
+			lockfile:     "composer.lock",
+			lock:         `{"packages": [{"name": "laravel/framework", "version": "v10.48.4"}]}`,
+			dep:          Dependency{Name: "laravel/framework", Constraint: "^10.0"},
+			wantVersion:  "10.48.4",
+			wantFromFile: "composer.lock",
+		},
+		{
+			name:         "Gemfile.lock",
+			manifest:     "Gemfile",
+			lockfile:     "Gemfile.lock",
+			lock:         "GEM\n  specs:\n    rails (7.1.3)\n",
+			dep:          Dependency{Name: "rails", Constraint: "~> 7.1"},
+			wantVersion:  "7.1.3",
+			wantFromFile: "Gemfile.lock",
+		},
+		{
+			name:         "Cargo.lock",
+			manifest:     "Cargo.toml",
+			lockfile:     "Cargo.lock",
+			lock:         "[[package]]\nname = \"axum\"\nversion = \"0.7.4\"\n",
+			dep:          Dependency{Name: "axum", Constraint: "0.7"},
+			wantVersion:  "0.7.4",
+			wantFromFile: "Cargo.lock",
+		},
+		{
+			name:     "no lockfile",
+			manifest: "composer.json",
+			dep:      Dependency{Name: "laravel/framework", Constraint: "^10.0"},
+		},
+		{
+			name:         "exact maven version",
+			manifest:     "pom.xml",
+			dep:          Dependency{Name: "org.springframework.boot:spring-boot-starter-web", Constraint: "3.2.2"},
+			wantVersion:  "3.2.2",
+			wantFromFile: "pom.xml",
+		},
+		{
+			name:     "maven range",
+			manifest: "pom.xml",
+			dep:      Dependency{Name: "org.springframework.boot:spring-boot-starter-web", Constraint: "[3.0,4.0)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.lockfile != "" {
+				dir = writeLockfile(t, tt.lockfile, tt.lock)
+			}
+			version, file := resolvePackageVersion(dir, tt.manifest, tt.dep)
+			if version != tt.wantVersion || file != tt.wantFromFile {
+				t.Errorf("resolvePackageVersion(%q) = %q, %q, want %q, %q", tt.dep.Name, version, file, tt.wantVersion, tt.wantFromFile)
+			}
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package detector
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// pythonProject is the merged view of every Python manifest in a directory
+type pythonProject struct {
+	deps           map[string]Dependency // Keyed by normalized project name
+	locked         map[string]string     // Resolved versions from lockfiles
+	lockSources    map[string]string     // Lockfile each resolved version came from
+	packageManager string
+}
+
+// pythonManifests are the files that mark a Python project
+var pythonManifests = []string{"requirements.txt", "pyproject.toml", "Pipfile", "setup.cfg"}
+
+func hasPythonManifest(dir string) bool {
+	for _, name := range pythonManifests {
+		if fileExists(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+func pythonCandidates(dir string) ([]Candidate, error) {
+	project, err := loadPythonProject(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []Candidate
+	for _, name := range []string{"django", "flask", "fastapi"} {
+		dep, ok := project.deps[name]
+		if !ok || dep.Dev {
+			continue
+		}
+
+		version, versionFile := project.locked[name], project.lockSources[name]
+		if version == "" {
+			version, versionFile = pinnedVersion(dep.Constraint), dep.Source
+		}
+
+		fw := newFramework(name, "python", version, dep.Constraint)
+		fw.PackageManager = project.packageManager
+		candidates = append(candidates, dependencyCandidate(dir, fw, dep, dep.Source, versionFile))
+	}
+
+	// Generic Python project
+	manifest := ""
+	for _, name := range pythonManifests {
+		if fileExists(filepath.Join(dir, name)) {
+			manifest = name
+			break
+		}
+	}
+	candidates = append(candidates, languageCandidate(&Framework{
+		Name:           "python",
+		Type:           "python",
+		PackageManager: project.packageManager,
+	}, manifest))
+	return candidates, nil
+}
+
+// loadPythonProject reads requirements.txt, pyproject.toml, Pipfile,
+// setup.cfg and any lockfiles present in dir
+func loadPythonProject(dir string) (*pythonProject, error) {
+	project := &pythonProject{
+		deps:        make(map[string]Dependency),
+		locked:      make(map[string]string),
+		lockSources: make(map[string]string),
+	}
+
+	// Runtime declarations override dev ones when a package appears in both
+	add := func(source string, deps map[string]Dependency) {
+		for name, dep := range deps {
+			if existing, ok := project.deps[name]; ok && !existing.Dev && dep.Dev {
+				continue
+			}
+			dep.Source = source
+			project.deps[name] = dep
+		}
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dir, "requirements.txt")); err == nil {
+		add("requirements.txt", parseRequirements(content))
+	}
+
+	var pyproject map[string]interface{}
+	if content, err := os.ReadFile(filepath.Join(dir, "pyproject.toml")); err == nil {
+		if err := toml.Unmarshal(content, &pyproject); err != nil {
+			return nil, err
+		}
+		add("pyproject.toml", parsePyproject(pyproject))
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dir, "Pipfile")); err == nil {
+		deps, err := parsePipfile(content)
+		if err != nil {
+			return nil, err
+		}
+		add("Pipfile", deps)
+	}
+
+	if content, err := os.ReadFile(filepath.Join(dir, "setup.cfg")); err == nil {
+		add("setup.cfg", parseSetupCfg(content))
+	}
+
+	for _, lockfile := range []string{"poetry.lock", "uv.lock"} {
+		if content, err := os.ReadFile(filepath.Join(dir, lockfile)); err == nil {
+			for name, version := range parsePackageLock(content) {
+				project.locked[name] = version
+				project.lockSources[name] = lockfile
+			}
+		}
+	}
+	if content, err := os.ReadFile(filepath.Join(dir, "Pipfile.lock")); err == nil {
+		for name, version := range parsePipfileLock(content) {
+			project.locked[name] = version
+			project.lockSources[name] = "Pipfile.lock"
+		}
+	}
+
+	project.packageManager = detectPythonManager(dir, pyproject)
+
+	return project, nil
+}
+
+// detectPythonManager works out which tool manages the environment,
+// preferring lockfiles over pyproject.toml tool tables
+func detectPythonManager(dir string, pyproject map[string]interface{}) string {
+	switch {
+	case fileExists(filepath.Join(dir, "uv.lock")):
+		return "uv"
+	case fileExists(filepath.Join(dir, "poetry.lock")):
+		return "poetry"
+	case fileExists(filepath.Join(dir, "Pipfile.lock")), fileExists(filepath.Join(dir, "Pipfile")):
+		return "pipenv"
+	case tomlTable(pyproject, "tool", "poetry") != nil:
+		return "poetry"
+	case tomlTable(pyproject, "tool", "uv") != nil:
+		return "uv"
+	default:
+		return "pip"
+	}
+}
+
+// parsePyproject collects dependencies from PEP 621 ([project]), PEP 735
+// ([dependency-groups]), Poetry and uv tables
+func parsePyproject(doc map[string]interface{}) map[string]Dependency {
+	deps := make(map[string]Dependency)
+
+	addSpecs := func(specs interface{}, dev bool) {
+		list, _ := specs.([]interface{})
+		for _, item := range list {
+			spec, ok := item.(string)
+			if !ok {
+				continue // {include-group = "..."} entries
+			}
+			for name, dep := range parseRequirements([]byte(spec)) {
+				if _, ok := deps[name]; ok && dev {
+					continue
+				}
+				dep.Dev = dev
+				deps[name] = dep
+			}
+		}
+	}
+
+	// PEP 621
+	if project := tomlTable(doc, "project"); project != nil {
+		addSpecs(project["dependencies"], false)
+		if optional, ok := project["optional-dependencies"].(map[string]interface{}); ok {
+			for _, specs := range optional {
+				addSpecs(specs, false)
+			}
+		}
+	}
+
+	// PEP 735 dependency groups
+	if groups := tomlTable(doc, "dependency-groups"); groups != nil {
+		for _, specs := range groups {
+			addSpecs(specs, true)
+		}
+	}
+
+	// uv
+	if uv := tomlTable(doc, "tool", "uv"); uv != nil {
+		addSpecs(uv["dev-dependencies"], true)
+	}
+
+	// Poetry
+	if poetry := tomlTable(doc, "tool", "poetry"); poetry != nil {
+		addDependencyTable(deps, poetry["dependencies"], false)
+		addDependencyTable(deps, poetry["dev-dependencies"], true)
+		if groups, ok := poetry["group"].(map[string]interface{}); ok {
+			for _, group := range groups {
+				if table, ok := group.(map[string]interface{}); ok {
+					addDependencyTable(deps, table["dependencies"], true)
+				}
+			}
+		}
+	}
+
+	return deps
+}
+
+// addDependencyTable adds entries from a Poetry, Pipfile or Cargo dependency
+// table, where each value is a constraint string or a table with a "version" key
+func addDependencyTable(deps map[string]Dependency, table interface{}, dev bool) {
+	entries, _ := table.(map[string]interface{})
+	for name, value := range entries {
+		if name == "python" {
+			continue
+		}
+
+		var constraint string
+		switch v := value.(type) {
+		case string:
+			constraint = v
+		case map[string]interface{}:
+			constraint, _ = v["version"].(string)
+		case []interface{}:
+			// Multiple constraints for different markers; use the first
+			if len(v) > 0 {
+				if first, ok := v[0].(map[string]interface{}); ok {
+					constraint, _ = first["version"].(string)
+				}
+			}
+		}
+		if constraint == "*" {
+			constraint = ""
+		}
+
+		key := normalizePythonName(name)
+		if _, ok := deps[key]; ok && dev {
+			continue
+		}
+		deps[key] = Dependency{Name: name, Constraint: constraint, Dev: dev}
+	}
+}
+
+// parsePipfile returns the packages and dev-packages declared in a Pipfile
+func parsePipfile(content []byte) (map[string]Dependency, error) {
+	var pipfile map[string]interface{}
+	if err := toml.Unmarshal(content, &pipfile); err != nil {
+		return nil, err
+	}
+
+	deps := make(map[string]Dependency)
+	addDependencyTable(deps, pipfile["packages"], false)
+	addDependencyTable(deps, pipfile["dev-packages"], true)
+	return deps, nil
+}
+
+// parsePipfileLock returns the locked version of every package in a
+// Pipfile.lock. Only the package sections are decoded: _meta holds numbers
+// and lists that don't fit the package entries.
+func parsePipfileLock(content []byte) map[string]string {
+	type lockedPackage struct {
+		Version string `json:"version"`
+	}
+	var lock struct {
+		Default map[string]lockedPackage `json:"default"`
+		Develop map[string]lockedPackage `json:"develop"`
+	}
+	if json.Unmarshal(content, &lock) != nil {
+		return nil
+	}
+
+	versions := make(map[string]string)
+	for _, section := range []map[string]lockedPackage{lock.Develop, lock.Default} {
+		for name, pkg := range section {
+			if version := pinnedVersion(pkg.Version); version != "" {
+				versions[normalizePythonName(name)] = version
+			}
+		}
+	}
+	return versions
+}
+
+// parsePackageLock returns the locked version of every package in a
+// poetry.lock, uv.lock or Cargo.lock file. All use a [[package]] array of
+// tables.
+func parsePackageLock(content []byte) map[string]string {
+	var lock struct {
+		Package []struct {
+			Name    string `toml:"name"`
+			Version string `toml:"version"`
+		} `toml:"package"`
+	}
+	if toml.Unmarshal(content, &lock) != nil {
+		return nil
+	}
+
+	versions := make(map[string]string)
+	for _, pkg := range lock.Package {
+		versions[normalizePythonName(pkg.Name)] = pkg.Version
+	}
+	return versions
+}
+
+// parseSetupCfg returns the packages listed under install_requires in a
+// setup.cfg file. Optional extras aren't runtime dependencies.
+func parseSetupCfg(content []byte) map[string]Dependency {
+	deps := make(map[string]Dependency)
+	var section, key string
+
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			section = strings.Trim(line, "[]")
+			key = ""
+			continue
+		}
+
+		// Unindented lines start a new key; indented lines continue its value
+		value := line
+		if raw[0] != ' ' && raw[0] != '\t' {
+			k, v, ok := strings.Cut(line, "=")
+			if !ok {
+				key = ""
+				continue
+			}
+			key = strings.TrimSpace(k)
+			value = strings.TrimSpace(v)
+		}
+
+		if value == "" || section != "options" || key != "install_requires" {
+			continue
+		}
+
+		for name, dep := range parseRequirements([]byte(value)) {
+			deps[name] = dep
+		}
+	}
+
+	return deps
+}
+
+// tomlTable walks nested TOML tables, returning nil if any key is missing
+func tomlTable(doc map[string]interface{}, keys ...string) map[string]interface{} {
+	table := doc
+	for _, key := range keys {
+		next, ok := table[key].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		table = next
+	}
+	return table
+}
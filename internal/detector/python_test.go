@@ -0,0 +1,78 @@
+package detector
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePipfileLock(t *testing.T) {
+	tests := []struct {
+		name string
+		lock string
+		want map[string]string
+	}{
+		{
+			name: "default and develop",
+			lock: `{"_meta": {"pipfile-spec": 6, "sources": []}, "default": {"Django": {"version": "==5.0.2"}}, "develop": {"pytest": {"version": "==8.0.1"}}}`,
+			want: map[string]string{"django": "5.0.2", "pytest": "8.0.1"},
+		},
+		{
+			name: "default wins over develop",
+			lock: `{"default": {"requests": {"version": "==2.31.0"}}, "develop": {"requests": {"version": "==2.28.0"}}}`,
+			want: map[string]string{"requests": "2.31.0"},
+		},
+		{
+			name: "unpinned and editable entries",
+			lock: `{"default": {"flask": {"version": ">=3.0"}, "mylib": {"editable": true, "path": "."}, "opentelemetry_api": {"version": "==1.23.0"}}}`,
+			want: map[string]string{"opentelemetry-api": "1.23.0"},
+		},
+		{
+			name: "invalid",
+			lock: `{"default": [`,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parsePipfileLock([]byte(tt.lock)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePipfileLock() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePackageLock(t *testing.T) {
+	tests := []struct {
+		name string
+		lock string
+		want map[string]string
+	}{
+		{
+			name: "poetry",
+			lock: "[[package]]\nname = \"Django\"\nversion = \"5.0.2\"\n\n[[package]]\nname = \"zope.interface\"\nversion = \"6.2\"\n\n[metadata]\nlock-version = \"2.0\"\n",
+			want: map[string]string{"django": "5.0.2", "zope-interface": "6.2"},
+		},
+		{
+			name: "uv",
+			lock: "version = 1\nrequires-python = \">=3.12\"\n\n[[package]]\nname = \"fastapi\"\nversion = \"0.110.0\"\nsource = { registry = \"https://pypi.org/simple\" }\n",
+			want: map[string]string{"fastapi": "0.110.0"},
+		},
+		{
+			name: "cargo",
+			lock: "version = 3\n\n[[package]]\nname = \"axum\"\nversion = \"0.7.4\"\nsource = \"registry+https://github.com/rust-lang/crates.io-index\"\n",
+			want: map[string]string{"axum": "0.7.4"},
+		},
+		{
+			name: "invalid",
+			lock: "[[package]\n",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parsePackageLock([]byte(tt.lock)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePackageLock() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package detector
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+// Detector finds candidate frameworks in a directory. Detectors that don't
+// apply to a directory return no candidates.
+type Detector interface {
+	Name() string
+	Priority() int // Higher-priority candidates rank above lower ones regardless of confidence
+	Detect(dir string) ([]Candidate, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   []Detector
+)
+
+// Register adds a detector. Detectors are consulted in registration order,
+// which breaks ties between candidates of equal priority and confidence.
+func Register(d Detector) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, d)
+}
+
+// Detectors returns the registered detectors in registration order
+func Detectors() []Detector {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return append([]Detector{}, registry...)
+}
+
+// ecosystemDetector is a built-in detector for one language ecosystem
+type ecosystemDetector struct {
+	name       string
+	present    func(dir string) bool
+	candidates func(dir string) ([]Candidate, error)
+}
+
+func (d ecosystemDetector) Name() string  { return d.name }
+func (d ecosystemDetector) Priority() int { return 0 }
+
+func (d ecosystemDetector) Detect(dir string) ([]Candidate, error) {
+	if !d.present(dir) {
+		return nil, nil
+	}
+	return d.candidates(dir)
+}
+
+// hasFile returns a presence check for a single manifest
+func hasFile(name string) func(dir string) bool {
+	return func(dir string) bool { return fileExists(filepath.Join(dir, name)) }
+}
+
+func init() {
+	for _, d := range []ecosystemDetector{
+		{"go", hasGoProject, goCandidates},
+		{"php", hasFile("composer.json"), phpCandidates},
+		{"node", hasFile("package.json"), nodeCandidates},
+		{"python", hasPythonManifest, pythonCandidates},
+		{"ruby", hasFile("Gemfile"), rubyCandidates},
+		{"jvm", hasJVMBuild, jvmCandidates},
+		{"dotnet", hasDotnetProject, dotnetCandidates},
+		{"rust", hasFile("Cargo.toml"), rustCandidates},
+		{"elixir", hasFile("mix.exs"), elixirCandidates},
+	} {
+		Register(d)
+	}
+}
@@ -0,0 +1,313 @@
+package detector
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RulesFile is where a repository keeps its custom detector rules. It is
+// looked up in the project directory and its parents, up to the repository
+// root.
+const RulesFile = ".tracekit/detectors.yaml"
+
+// scoreRuleFiles is the confidence of a custom rule matched by files alone
+const scoreRuleFiles = 50
+
+// Rule is a user-defined detector for a framework the built-ins don't know,
+// such as an internal wrapper around Gin or Laravel:
+//
+//	detectors:
+//	  - name: acme-web
+//	    type: go
+//	    sdk: Go
+//	    priority: 10
+//	    files: ["acme.yaml"]
+//	    dependencies:
+//	      - github.com/acme/web
+//	      - name: "github.com/acme/web/*"
+//	        manifest: go.mod
+//
+// A rule matches when every file glob matches and, if dependencies are
+// listed, at least one of them is declared.
+type Rule struct {
+	Name         string           `yaml:"name"`
+	Type         string           `yaml:"type"`     // Language: go, php, node, python, ...
+	SDK          string           `yaml:"sdk"`      // SDK to recommend, by name (default: the language SDK)
+	Priority     int              `yaml:"priority"` // Built-in detectors have priority 0
+	Files        []string         `yaml:"files"`    // Globs relative to the project directory
+	Dependencies []RuleDependency `yaml:"dependencies"`
+}
+
+// RuleDependency matches a dependency by name (a glob) in any manifest, or
+// only in Manifest when set
+type RuleDependency struct {
+	Name     string `yaml:"name"`
+	Manifest string `yaml:"manifest"`
+}
+
+// UnmarshalYAML accepts a bare package name as well as a mapping
+func (d *RuleDependency) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		d.Name = node.Value
+		return nil
+	}
+	type plain RuleDependency
+	return node.Decode((*plain)(d))
+}
+
+// ruleDetector adapts a Rule to the Detector interface
+type ruleDetector struct {
+	rule   Rule
+	source string // File the rule was loaded from
+}
+
+func (d ruleDetector) Name() string  { return fmt.Sprintf("rule %s (%s)", d.rule.Name, d.source) }
+func (d ruleDetector) Priority() int { return d.rule.Priority }
+
+func (d ruleDetector) Detect(dir string) ([]Candidate, error) {
+	var evidence []Evidence
+	for _, glob := range d.rule.Files {
+		matches := globFiles(dir, filepath.FromSlash(glob))
+		if len(matches) == 0 {
+			return nil, nil
+		}
+		rel, _ := filepath.Rel(dir, matches[0])
+		evidence = append(evidence, Evidence{File: filepath.ToSlash(rel), Detail: "matches " + glob})
+	}
+
+	if len(d.rule.Dependencies) == 0 {
+		fw := newFramework(d.rule.Name, d.rule.Type, "", "")
+		fw.SDK = d.rule.SDK
+		return []Candidate{{Framework: fw, Confidence: scoreRuleFiles, Evidence: evidence}}, nil
+	}
+
+	manifest, dep, ok := d.matchDependency(manifestDependencies(dir))
+	if !ok {
+		return nil, nil
+	}
+
+	fw := newFramework(d.rule.Name, d.rule.Type, "", dep.Constraint)
+	fw.SDK = d.rule.SDK
+	c := dependencyCandidate(dir, fw, dep, manifest, "")
+	if len(evidence) > 0 {
+		c.Evidence = append(c.Evidence, evidence...)
+		c.Confidence = min(c.Confidence+scoreMarker, 100)
+	}
+	return []Candidate{c}, nil
+}
+
+// matchDependency returns the first declared dependency matching the rule,
+// checking manifests and packages in name order so results are stable
+func (d ruleDetector) matchDependency(manifests map[string]map[string]Dependency) (string, Dependency, bool) {
+	for _, want := range d.rule.Dependencies {
+		for _, manifest := range sortedKeys(manifests) {
+			if want.Manifest != "" && want.Manifest != manifest {
+				continue
+			}
+			deps := manifests[manifest]
+			for _, key := range sortedKeys(deps) {
+				if dep := deps[key]; globMatch(want.Name, key) || globMatch(want.Name, dep.Name) {
+					return manifest, dep, true
+				}
+			}
+		}
+	}
+	return "", Dependency{}, false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func globMatch(pattern, name string) bool {
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
+
+// validate reports the first problem with a rule
+func (r Rule) validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("rule is missing a name")
+	}
+	if !knownType(r.Type) {
+		return fmt.Errorf("rule %q: unknown type %q", r.Name, r.Type)
+	}
+	if len(r.Files) == 0 && len(r.Dependencies) == 0 {
+		return fmt.Errorf("rule %q: needs files or dependencies to match", r.Name)
+	}
+	for _, glob := range r.Files {
+		if _, err := filepath.Match(glob, ""); err != nil {
+			return fmt.Errorf("rule %q: bad file glob %q", r.Name, glob)
+		}
+	}
+	for _, dep := range r.Dependencies {
+		if _, err := path.Match(dep.Name, ""); err != nil || dep.Name == "" {
+			return fmt.Errorf("rule %q: bad dependency %q", r.Name, dep.Name)
+		}
+	}
+	return nil
+}
+
+func knownType(frameworkType string) bool {
+	for _, t := range knownFrameworks {
+		if t == frameworkType {
+			return true
+		}
+	}
+	return false
+}
+
+// RuleFiles returns the custom rule files that apply to dir: the nearest
+// repository RulesFile, then the user's detectors.yaml
+func RuleFiles(dir string) []string {
+	var files []string
+
+	if abs, err := filepath.Abs(dir); err == nil {
+		for root := abs; ; root = filepath.Dir(root) {
+			if file := filepath.Join(root, filepath.FromSlash(RulesFile)); fileExists(file) {
+				files = append(files, file)
+				break
+			}
+			if fileExists(filepath.Join(root, ".git")) || filepath.Dir(root) == root {
+				break
+			}
+		}
+	}
+
+	if file := UserRulesFile(); file != "" && fileExists(file) {
+		files = append(files, file)
+	}
+	return files
+}
+
+// UserRulesFile returns the path of the user's detectors.yaml, or an empty
+// string when there is no user config directory
+func UserRulesFile() string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "tracekit", "detectors.yaml")
+}
+
+// loadRules reads the custom rules that apply to dir
+func loadRules(dir string) ([]Detector, error) {
+	var detectors []Detector
+	for _, file := range RuleFiles(dir) {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var doc struct {
+			Detectors []Rule `yaml:"detectors"`
+		}
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return nil, fmt.Errorf("invalid detector rules in %s: %w", file, err)
+		}
+
+		for _, rule := range doc.Detectors {
+			if err := rule.validate(); err != nil {
+				return nil, fmt.Errorf("invalid detector rules in %s: %w", file, err)
+			}
+			detectors = append(detectors, ruleDetector{rule: rule, source: file})
+		}
+	}
+	return detectors, nil
+}
+
+// manifestDependencies returns the dependencies declared in each manifest in
+// dir, keyed by manifest file name and then package name
+func manifestDependencies(dir string) map[string]map[string]Dependency {
+	manifests := make(map[string]map[string]Dependency)
+
+	read := func(name string) []byte {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil
+		}
+		return content
+	}
+
+	if content := read("go.mod"); content != nil {
+		manifests["go.mod"] = parseGoMod(content)
+	}
+	if content := read("package.json"); content != nil {
+		if deps, err := parsePackageJSON(content); err == nil {
+			manifests["package.json"] = deps
+		}
+	}
+	if content := read("composer.json"); content != nil {
+		if deps, err := parseComposerJSON(content); err == nil {
+			manifests["composer.json"] = deps
+		}
+	}
+	if content := read("Gemfile"); content != nil {
+		manifests["Gemfile"] = parseGemfile(content)
+	}
+	if content := read("Cargo.toml"); content != nil {
+		if deps, err := parseCargoToml(content); err == nil {
+			manifests["Cargo.toml"] = deps
+		}
+	}
+
+	if hasPythonManifest(dir) {
+		if project, err := loadPythonProject(dir); err == nil {
+			for name, dep := range project.deps {
+				if manifests[dep.Source] == nil {
+					manifests[dep.Source] = make(map[string]Dependency)
+				}
+				manifests[dep.Source][name] = dep
+			}
+		}
+	}
+
+	if hasJVMBuild(dir) {
+		manifest, load := "pom.xml", loadMavenProject
+		if !fileExists(filepath.Join(dir, manifest)) {
+			manifest, load = "build.gradle", loadGradleProject
+			if fileExists(filepath.Join(dir, "build.gradle.kts")) {
+				manifest = "build.gradle.kts"
+			}
+		}
+		if project, err := load(dir); err == nil {
+			deps := make(map[string]Dependency)
+			for _, a := range project.artifacts {
+				name := a.GroupID + ":" + a.ArtifactID
+				deps[name] = Dependency{Name: name, Constraint: project.resolve(a.Version), Dev: a.Scope == "test"}
+			}
+			manifests[manifest] = deps
+		}
+	}
+
+	for _, file := range globFiles(dir, "*.csproj") {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var project csproj
+		if xml.Unmarshal(content, &project) != nil {
+			continue
+		}
+		deps := make(map[string]Dependency)
+		for _, group := range project.ItemGroups {
+			for _, ref := range group.PackageReferences {
+				deps[ref.Include] = Dependency{Name: ref.Include}
+			}
+		}
+		manifests[filepath.Base(file)] = deps
+	}
+
+	return manifests
+}
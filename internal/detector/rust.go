@@ -0,0 +1,57 @@
+package detector
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+func rustCandidates(dir string) ([]Candidate, error) {
+	content, err := os.ReadFile(filepath.Join(dir, "Cargo.toml"))
+	if err != nil {
+		return nil, err
+	}
+
+	deps, err := parseCargoToml(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var locked map[string]string
+	if lock, err := os.ReadFile(filepath.Join(dir, "Cargo.lock")); err == nil {
+		locked = parsePackageLock(lock)
+	}
+
+	var candidates []Candidate
+	for _, name := range []string{"axum", "actix-web", "rocket"} {
+		if dep, ok := deps[name]; ok {
+			fw := newFramework(name, "rust", locked[name], dep.Constraint)
+			fw.PackageManager = "cargo"
+			candidates = append(candidates, dependencyCandidate(dir, fw, dep, "Cargo.toml", "Cargo.lock"))
+		}
+	}
+
+	// Generic Rust project
+	candidates = append(candidates, languageCandidate(&Framework{
+		Name:           "rust",
+		Type:           "rust",
+		PackageManager: "cargo",
+	}, "Cargo.toml"))
+	return candidates, nil
+}
+
+// parseCargoToml returns the [dependencies] and [workspace.dependencies] of a
+// Cargo.toml, keyed by crate name. [dev-dependencies] are deliberately
+// ignored.
+func parseCargoToml(content []byte) (map[string]Dependency, error) {
+	var manifest map[string]interface{}
+	if err := toml.Unmarshal(content, &manifest); err != nil {
+		return nil, err
+	}
+
+	deps := make(map[string]Dependency)
+	addDependencyTable(deps, tomlTable(manifest, "workspace")["dependencies"], false)
+	addDependencyTable(deps, manifest["dependencies"], false)
+	return deps, nil
+}
@@ -0,0 +1,147 @@
+package detector
+
+import (
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Service is a directory containing its own project manifest
+type Service struct {
+	Path      string // Relative to the scan root ("." for the root itself)
+	Dir       string // Absolute directory
+	Name      string // Suggested service name
+	Framework *Framework
+}
+
+// manifestFiles mark a directory as a service root
+var manifestFiles = []string{
+	"go.mod", "composer.json", "package.json",
+	"requirements.txt", "pyproject.toml", "Pipfile", "setup.cfg",
+	"Gemfile", "pom.xml", "build.gradle", "build.gradle.kts",
+	"Cargo.toml", "mix.exs",
+}
+
+// manifestPatterns mark a directory as a service root when any file matches
+var manifestPatterns = []string{"*.csproj", "*.sln"}
+
+// skippedDirs are never scanned, regardless of .gitignore
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"venv":         true,
+	"__pycache__":  true,
+	"testdata":     true,
+	"target":       true, // Maven and Cargo build output
+	"build":        true, // Gradle build output
+	"bin":          true, // .NET build output
+	"obj":          true,
+	"deps":         true, // Mix dependencies
+	"_build":       true,
+}
+
+// maxScanDepth limits how far below the root DetectServices looks
+const maxScanDepth = 5
+
+// DetectServices walks root recursively and returns every service root it
+// finds, ordered by path. Paths excluded by .gitignore, hidden directories
+// and dependency directories such as node_modules and vendor are skipped.
+func DetectServices(root string) ([]Service, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []Service
+	matcher := &ignoreMatcher{}
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable directories are skipped rather than failing the scan
+			if d != nil && d.IsDir() && path != root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(root, path)
+		rel = filepath.ToSlash(rel)
+
+		if rel != "." {
+			name := d.Name()
+			if strings.HasPrefix(name, ".") || skippedDirs[name] ||
+				strings.Count(rel, "/") >= maxScanDepth || matcher.ignored(rel, true) {
+				return filepath.SkipDir
+			}
+		}
+
+		matcher.load(path, rel)
+
+		if !hasManifest(path) {
+			return nil
+		}
+
+		framework, err := DetectDir(path)
+		if err != nil {
+			// A broken manifest shouldn't hide the other services
+			framework = &Framework{Name: "generic", Type: "unknown"}
+		}
+
+		services = append(services, Service{
+			Path:      rel,
+			Dir:       path,
+			Framework: framework,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Path < services[j].Path
+	})
+	assignServiceNames(root, services)
+
+	return services, nil
+}
+
+func hasManifest(dir string) bool {
+	for _, name := range manifestFiles {
+		if fileExists(filepath.Join(dir, name)) {
+			return true
+		}
+	}
+	for _, pattern := range manifestPatterns {
+		if len(globFiles(dir, pattern)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// assignServiceNames names each service after its directory, falling back
+// to the full relative path when two directories share a name
+func assignServiceNames(root string, services []Service) {
+	counts := make(map[string]int)
+	for i := range services {
+		services[i].Name = ServiceName(filepath.Base(services[i].Dir))
+		counts[services[i].Name]++
+	}
+
+	rootName := ServiceName(filepath.Base(root))
+	for i := range services {
+		if counts[services[i].Name] > 1 && services[i].Path != "." {
+			services[i].Name = ServiceName(rootName + "-" + strings.ReplaceAll(services[i].Path, "/", "-"))
+		}
+	}
+}
+
+// ServiceName sanitizes a directory name for use as a service name
+func ServiceName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+}
@@ -0,0 +1,185 @@
+package detector
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Vendor is an observability tool already set up in a project
+type Vendor struct {
+	Key      string // "datadog", "newrelic", "sentry" or "opentelemetry"
+	Name     string // Display name
+	Tracing  bool   // Traces requests itself, so running TraceKit too duplicates spans
+	Evidence []Evidence
+}
+
+// Evidence kinds used for vendors, so callers can turn them into removal steps
+const (
+	VendorDependency = "dependency"
+	VendorEnv        = "env"
+	VendorConfig     = "config file"
+)
+
+// vendorSignatures describe how each vendor shows up in a project. Package
+// patterns ending in "*" match by prefix; everything is case-insensitive.
+var vendorSignatures = []struct {
+	key      string
+	name     string
+	tracing  bool
+	packages []string
+	env      []string // Variable names, or prefixes ending in "*"
+	files    []string // Globs relative to the project directory
+}{
+	{
+		key:     "datadog",
+		name:    "Datadog",
+		tracing: true,
+		packages: []string{
+			"dd-trace", "gopkg.in/DataDog/dd-trace-go*", "github.com/DataDog/dd-trace-go*",
+			"ddtrace", "datadog/dd-trace", "com.datadoghq:*", "Datadog.Trace*",
+		},
+		env:   []string{"DD_API_KEY", "DD_AGENT_HOST", "DD_TRACE_*"},
+		files: []string{"datadog.yaml", "dd-java-agent.jar"},
+	},
+	{
+		key:     "newrelic",
+		name:    "New Relic",
+		tracing: true,
+		packages: []string{
+			"newrelic", "@newrelic/*", "newrelic_rpm", "github.com/newrelic/go-agent*",
+			"com.newrelic.agent.java:*", "NewRelic.Agent*",
+		},
+		env:   []string{"NEW_RELIC_*"},
+		files: []string{"newrelic.js", "newrelic.cjs", "newrelic.ini", "newrelic.yml", "newrelic.config", "newrelic.jar"},
+	},
+	{
+		key:      "sentry",
+		name:     "Sentry",
+		packages: []string{"@sentry/*", "github.com/getsentry/sentry-go*", "io.sentry:*", "sentry*"},
+		env:      []string{"SENTRY_*"},
+		files:    []string{"sentry.properties", ".sentryclirc", "sentry.*.config.js", "sentry.*.config.ts"},
+	},
+	{
+		key:     "opentelemetry",
+		name:    "OpenTelemetry",
+		tracing: true,
+		packages: []string{
+			"@opentelemetry/*", "go.opentelemetry.io/otel*", "open-telemetry/*", "io.opentelemetry*", "opentelemetry*",
+		},
+		env:   []string{"OTEL_*"},
+		files: []string{"opentelemetry-javaagent.jar", "otel-collector-config.yaml", "otel-collector-config.yml"},
+	},
+}
+
+// envFiles are the dotenv files checked for vendor settings
+var envFiles = []string{".env", ".env.local", ".env.development", ".env.production", ".env.example"}
+
+// DetectVendors returns the observability tools already set up in dir, from
+// manifest dependencies, dotenv files and vendor config files. The TraceKit
+// block written by init is ignored.
+func DetectVendors(dir string) []Vendor {
+	manifests := manifestDependencies(dir)
+	env := readEnvKeys(dir)
+
+	var vendors []Vendor
+	for _, sig := range vendorSignatures {
+		vendor := Vendor{Key: sig.key, Name: sig.name, Tracing: sig.tracing}
+
+		for _, manifest := range sortedKeys(manifests) {
+			deps := manifests[manifest]
+			for _, key := range sortedKeys(deps) {
+				dep := deps[key]
+				if dep.Dev || !matchAny(sig.packages, dep.Name) {
+					continue
+				}
+				vendor.Evidence = append(vendor.Evidence, Evidence{
+					File:   manifest,
+					Detail: VendorDependency + " " + dep.Name,
+				})
+			}
+		}
+
+		for _, file := range envFiles {
+			for _, key := range env[file] {
+				if matchAny(sig.env, key) {
+					vendor.Evidence = append(vendor.Evidence, Evidence{File: file, Detail: VendorEnv + " " + key})
+				}
+			}
+		}
+
+		for _, glob := range sig.files {
+			for _, match := range globFiles(dir, glob) {
+				rel, _ := filepath.Rel(dir, match)
+				vendor.Evidence = append(vendor.Evidence, Evidence{File: filepath.ToSlash(rel), Detail: VendorConfig})
+			}
+		}
+
+		if len(vendor.Evidence) > 0 {
+			vendors = append(vendors, vendor)
+		}
+	}
+
+	return vendors
+}
+
+// matchAny reports whether name matches one of the patterns, where a
+// trailing "*" matches any suffix
+func matchAny(patterns []string, name string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// readEnvKeys returns the variable names set in each dotenv file in dir,
+// skipping the TraceKit configuration block
+func readEnvKeys(dir string) map[string][]string {
+	keys := make(map[string][]string)
+
+	for _, name := range envFiles {
+		file, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+
+		inTraceKitBlock := false
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if strings.Contains(line, "# TraceKit Configuration") {
+				inTraceKitBlock = true
+				continue
+			}
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			key, _, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+			if !ok {
+				continue
+			}
+			key = strings.TrimSpace(key)
+
+			if inTraceKitBlock {
+				if strings.HasPrefix(key, "TRACEKIT_") || strings.HasPrefix(key, "OTEL_") {
+					continue
+				}
+				inTraceKitBlock = false
+			}
+			keys[name] = append(keys[name], key)
+		}
+		file.Close()
+	}
+
+	return keys
+}
@@ -0,0 +1,128 @@
+package detector
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// minSupportedVersions is the oldest framework version each TraceKit SDK
+// integration supports
+var minSupportedVersions = map[string]string{
+	"gin":     "1.7.0",
+	"echo":    "4.0.0",
+	"fiber":   "2.0.0",
+	"chi":     "4.0.0",
+	"grpc":    "1.40.0",
+	"gemvc":   "5.0.0",
+	"laravel": "8.0.0",
+	"symfony": "5.4.0",
+	"express": "4.0.0",
+	"nextjs":  "12.0.0",
+	"nestjs":  "8.0.0",
+
+	"fastify":   "4.0.0",
+	"koa":       "2.0.0",
+	"hapi":      "20.0.0",
+	"remix":     "2.0.0",
+	"nuxt":      "3.0.0",
+	"sveltekit": "1.0.0",
+	"astro":     "3.0.0",
+
+	"django":  "3.2.0",
+	"flask":   "2.0.0",
+	"fastapi": "0.68.0",
+	"rails":   "6.0.0",
+	"sinatra": "2.0.0",
+
+	"spring-boot": "2.6.0",
+	"quarkus":     "2.0.0",
+	"micronaut":   "3.0.0",
+	"aspnetcore":  "6.0",
+	"axum":        "0.6.0",
+	"actix-web":   "4.0.0",
+	"rocket":      "0.5.0",
+	"phoenix":     "1.6.0",
+}
+
+// SetMinSupportedVersions overrides the oldest supported version of the
+// given frameworks, as published in the SDK catalog
+func SetMinSupportedVersions(versions map[string]string) {
+	for name, version := range versions {
+		minSupportedVersions[name] = version
+	}
+}
+
+// versionPattern matches the first dotted version number in a string
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)*`)
+
+// pinnedVersion returns the version of an exact pin ("==4.2.11" or
+// "===4.2.11"), or an empty string for ranges
+func pinnedVersion(constraint string) string {
+	if !strings.HasPrefix(constraint, "==") {
+		return ""
+	}
+	version := strings.TrimSpace(strings.TrimLeft(constraint, "="))
+	if strings.ContainsAny(version, ",*<>!~") {
+		return ""
+	}
+	return version
+}
+
+// lowerBound returns the lowest version a constraint allows, or an empty
+// string when it has no lower bound (e.g. "*", "<5" or "latest")
+func lowerBound(constraint string) string {
+	// Take the lowest alternative of "||" ranges
+	var lowest string
+	for _, alt := range strings.Split(constraint, "||") {
+		alt = strings.TrimSpace(alt)
+		if alt == "" || strings.HasPrefix(alt, "<") || strings.HasPrefix(alt, "!") {
+			continue
+		}
+		version := versionPattern.FindString(alt)
+		if version == "" {
+			continue
+		}
+		if lowest == "" || compareVersions(version, lowest) < 0 {
+			lowest = version
+		}
+	}
+	return lowest
+}
+
+// compareVersions compares two dotted version strings numerically, returning
+// -1, 0 or 1. Missing components count as zero and pre-release suffixes are
+// ignored.
+func compareVersions(a, b string) int {
+	pa := versionParts(a)
+	pb := versionParts(b)
+
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) []int {
+	var parts []int
+	for _, part := range strings.Split(versionPattern.FindString(version), ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
@@ -0,0 +1,671 @@
+package devserver
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/context.io/cli/internal/trace"
+)
+
+// DefaultVerificationCode is accepted by the emulated email verification
+const DefaultVerificationCode = "000000"
+
+// Options configures the dev server
+type Options struct {
+	VerificationCode string // Code accepted by /v1/integrate/verify
+	BaseURL          string // Public URL, used for dashboard links
+	Logf             func(format string, args ...interface{})
+}
+
+// Payload records a single request to the ingest endpoint
+type Payload struct {
+	ReceivedAt  time.Time `json:"received_at"`
+	ContentType string    `json:"content_type"`
+	Format      string    `json:"format"` // "tracekit", "otlp-json" or "otlp-protobuf"
+	Bytes       int       `json:"bytes"`
+	SpanCount   int       `json:"span_count"`
+}
+
+// Webhook is an emulated webhook configuration
+type Webhook struct {
+	ID                   string    `json:"id"`
+	Name                 string    `json:"name"`
+	URL                  string    `json:"url"`
+	Description          string    `json:"description"`
+	Events               []string  `json:"events"`
+	Enabled              bool      `json:"enabled"`
+	Status               string    `json:"status"`
+	Secret               string    `json:"secret,omitempty"`
+	TotalDeliveries      int       `json:"total_deliveries"`
+	SuccessfulDeliveries int       `json:"successful_deliveries"`
+	FailedDeliveries     int       `json:"failed_deliveries"`
+	LastDeliveryAt       *string   `json:"last_delivery_at"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+// Snapshot is everything the dev server has stored
+type Snapshot struct {
+	Spans        []trace.Span             `json:"spans"`
+	Payloads     []Payload                `json:"payloads"`
+	Webhooks     []Webhook                `json:"webhooks"`
+	HealthChecks []map[string]interface{} `json:"health_checks"`
+	Heartbeats   []map[string]interface{} `json:"heartbeats"`
+	Accounts     []map[string]interface{} `json:"accounts"`
+}
+
+type session struct {
+	Email       string
+	ServiceName string
+}
+
+// Server is an in-memory stand-in for the TraceKit API
+type Server struct {
+	opts Options
+
+	mu           sync.Mutex
+	spans        []trace.Span // Index + 1 is the stream event ID
+	payloads     []Payload
+	webhooks     []Webhook
+	healthChecks []map[string]interface{}
+	heartbeats   []map[string]interface{}
+	accounts     []map[string]interface{}
+	sessions     map[string]session
+	serviceName  string
+	subscribers  map[chan struct{}]struct{}
+}
+
+// New creates a dev server with empty storage
+func New(opts Options) *Server {
+	if opts.VerificationCode == "" {
+		opts.VerificationCode = DefaultVerificationCode
+	}
+	if opts.Logf == nil {
+		opts.Logf = func(string, ...interface{}) {}
+	}
+
+	return &Server{
+		opts:        opts,
+		sessions:    make(map[string]session),
+		subscribers: make(map[chan struct{}]struct{}),
+	}
+}
+
+// Handler returns the HTTP handler serving the emulated API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	// Ingest
+	mux.HandleFunc("POST /v1/traces", s.handleIngest)
+
+	// Trace queries
+	mux.HandleFunc("GET /v1/traces/search", s.requireAPIKey(s.handleTraceSearch))
+	mux.HandleFunc("GET /v1/traces/stream", s.requireAPIKey(s.handleTraceStream))
+	mux.HandleFunc("GET /v1/traces/{id}", s.requireAPIKey(s.handleTraceGet))
+
+	// Account setup
+	mux.HandleFunc("POST /v1/integrate/register", s.handleRegister)
+	mux.HandleFunc("POST /v1/integrate/verify", s.handleVerify)
+	mux.HandleFunc("GET /v1/integrate/status", s.requireAPIKey(s.handleStatus))
+	mux.HandleFunc("POST /v1/integrate/public-keys", s.requireAPIKey(s.handlePublicKeyCreate))
+
+	// Webhooks
+	mux.HandleFunc("GET /v1/webhooks", s.requireAPIKey(s.handleWebhookList))
+	mux.HandleFunc("POST /v1/webhooks", s.requireAPIKey(s.handleWebhookCreate))
+	mux.HandleFunc("DELETE /v1/webhooks/{id}", s.requireAPIKey(s.handleWebhookDelete))
+
+	// Health checks
+	mux.HandleFunc("GET /api/health-checks", s.requireAPIKey(s.handleHealthCheckList))
+	mux.HandleFunc("POST /api/health-checks", s.requireAPIKey(s.handleHealthCheckCreate))
+	mux.HandleFunc("POST /v1/health/heartbeat", s.requireAPIKey(s.handleHeartbeat))
+
+	// Inspection
+	mux.HandleFunc("GET /_dev/dump", s.handleDump)
+	mux.HandleFunc("POST /_dev/reset", s.handleReset)
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+
+	return s.logRequests(mux)
+}
+
+// Snapshot returns a copy of everything stored so far
+func (s *Server) Snapshot() Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return Snapshot{
+		Spans:        append([]trace.Span{}, s.spans...),
+		Payloads:     append([]Payload{}, s.payloads...),
+		Webhooks:     append([]Webhook{}, s.webhooks...),
+		HealthChecks: append([]map[string]interface{}{}, s.healthChecks...),
+		Heartbeats:   append([]map[string]interface{}{}, s.heartbeats...),
+		Accounts:     append([]map[string]interface{}{}, s.accounts...),
+	}
+}
+
+// Reset clears all stored data
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.spans = nil
+	s.payloads = nil
+	s.webhooks = nil
+	s.healthChecks = nil
+	s.heartbeats = nil
+	s.accounts = nil
+	s.sessions = make(map[string]session)
+	s.serviceName = ""
+}
+
+func (s *Server) handleIngest(w http.ResponseWriter, r *http.Request) {
+	// Browser SDKs send a public ingest key instead of the API key
+	if r.Header.Get("X-API-Key") == "" && r.Header.Get("Authorization") == "" && r.Header.Get("X-TraceKit-Public-Key") == "" {
+		writeError(w, http.StatusUnauthorized, "missing API key")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read body")
+		return
+	}
+
+	payload := Payload{
+		ReceivedAt:  time.Now().UTC(),
+		ContentType: r.Header.Get("Content-Type"),
+		Bytes:       len(body),
+	}
+
+	// Protobuf exports are recorded but not decoded
+	if strings.Contains(payload.ContentType, "protobuf") {
+		payload.Format = "otlp-protobuf"
+		s.mu.Lock()
+		s.payloads = append(s.payloads, payload)
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"accepted": 0,
+			"warning":  "protobuf payloads are recorded but not decoded; use OTLP/HTTP JSON to inspect spans",
+		})
+		return
+	}
+
+	payload.Format = "tracekit"
+	if trace.IsOTLP(body) {
+		payload.Format = "otlp-json"
+	}
+
+	spans, err := trace.ParseSpans(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid trace payload: %v", err))
+		return
+	}
+	payload.SpanCount = len(spans)
+
+	s.mu.Lock()
+	s.spans = append(s.spans, spans...)
+	s.payloads = append(s.payloads, payload)
+	for ch := range s.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"accepted": len(spans)})
+}
+
+func (s *Server) handleTraceSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := spanFilter{
+		service: q.Get("service"),
+		status:  q.Get("status"),
+	}
+	if since, err := time.Parse(time.RFC3339, q.Get("since")); err == nil {
+		filter.since = since
+	}
+	filter.minDurationMs, _ = strconv.ParseFloat(q.Get("min_duration_ms"), 64)
+	name := q.Get("name")
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+
+	s.mu.Lock()
+	byTrace := groupByTrace(s.spans)
+	s.mu.Unlock()
+
+	var summaries []traceSummary
+	for _, spans := range byTrace {
+		summary := summarize(spans)
+		if !filter.matchesTrace(summary) || (name != "" && summary.RootName != name) {
+			continue
+		}
+		summaries = append(summaries, summary)
+	}
+
+	// Newest first
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].StartTime.After(summaries[j].StartTime)
+	})
+
+	total := len(summaries)
+	if len(summaries) > limit {
+		summaries = summaries[:limit]
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"traces": summaries,
+		"total":  total,
+	})
+}
+
+func (s *Server) handleTraceGet(w http.ResponseWriter, r *http.Request) {
+	traceID := r.PathValue("id")
+
+	s.mu.Lock()
+	var spans []trace.Span
+	for _, span := range s.spans {
+		if span.TraceID == traceID {
+			spans = append(spans, span)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(spans) == 0 {
+		writeError(w, http.StatusNotFound, "trace not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"trace_id": traceID,
+		"spans":    spans,
+	})
+}
+
+func (s *Server) handleTraceStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	q := r.URL.Query()
+	filter := spanFilter{
+		service: q.Get("service"),
+		status:  q.Get("status"),
+		route:   q.Get("route"),
+	}
+	filter.minDurationMs, _ = strconv.ParseFloat(q.Get("min_duration_ms"), 64)
+
+	notify := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.subscribers[notify] = struct{}{}
+	// Resume after the last event the client saw, otherwise only new spans
+	next := len(s.spans)
+	if lastID, err := strconv.Atoi(r.Header.Get("Last-Event-ID")); err == nil && lastID >= 0 && lastID < next {
+		next = lastID
+	}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscribers, notify)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(15 * time.Second)
+	defer keepAlive.Stop()
+
+	// Replay anything missed before subscribing. An ingest since then may
+	// have queued a notification already, which covers it too.
+	select {
+	case notify <- struct{}{}:
+	default:
+	}
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+
+		case <-notify:
+			s.mu.Lock()
+			if next > len(s.spans) {
+				// Storage was reset
+				next = len(s.spans)
+			}
+			pending := append([]trace.Span{}, s.spans[next:]...)
+			s.mu.Unlock()
+
+			for _, span := range pending {
+				next++
+				if !filter.matchesSpan(span) {
+					continue
+				}
+				data, _ := json.Marshal(span)
+				fmt.Fprintf(w, "id: %d\nevent: span\ndata: %s\n\n", next, data)
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Email       string `json:"email"`
+		ServiceName string `json:"service_name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+		writeError(w, http.StatusBadRequest, "email is required")
+		return
+	}
+
+	sessionID := "sess_dev_" + randomHex(8)
+	s.mu.Lock()
+	s.sessions[sessionID] = session{Email: req.Email, ServiceName: req.ServiceName}
+	s.mu.Unlock()
+
+	s.opts.Logf("verification code for %s: %s", req.Email, s.opts.VerificationCode)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"verification_required": true,
+		"session_id":            sessionID,
+		"message":               "Dev server: use code " + s.opts.VerificationCode,
+		"expires_at":            time.Now().Add(15 * time.Minute).UTC(),
+	})
+}
+
+func (s *Server) handleVerify(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		SessionID string `json:"session_id"`
+		Code      string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[req.SessionID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "session not found or expired")
+		return
+	}
+	if req.Code != s.opts.VerificationCode {
+		writeError(w, http.StatusUnauthorized, "invalid verification code")
+		return
+	}
+	delete(s.sessions, req.SessionID)
+
+	apiKey := "tk_dev_" + randomHex(16)
+	s.serviceName = sess.ServiceName
+	s.accounts = append(s.accounts, map[string]interface{}{
+		"email":        sess.Email,
+		"service_name": sess.ServiceName,
+		"api_key":      apiKey,
+		"created_at":   time.Now().UTC(),
+	})
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"api_key":         apiKey,
+		"organization_id": "org_dev",
+		"service_name":    sess.ServiceName,
+		"dashboard_url":   s.opts.BaseURL,
+	})
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	serviceName := s.serviceName
+	var firstData, lastData interface{}
+	if len(s.payloads) > 0 {
+		firstData = s.payloads[0].ReceivedAt
+		lastData = s.payloads[len(s.payloads)-1].ReceivedAt
+	}
+	if serviceName == "" && len(s.spans) > 0 {
+		serviceName = s.spans[len(s.spans)-1].Service.Name
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status": "active",
+		"integration": map[string]interface{}{
+			"service_name":     serviceName,
+			"integration_type": "sdk",
+			"source":           "dev-server",
+			"first_data_at":    firstData,
+			"last_data_at":     lastData,
+		},
+	})
+}
+
+func (s *Server) handlePublicKeyCreate(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ServiceName string `json:"service_name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ServiceName == "" {
+		writeError(w, http.StatusBadRequest, "service_name is required")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"public_key":   "tkp_dev_" + randomHex(16),
+		"service_name": req.ServiceName,
+	})
+}
+
+func (s *Server) handleWebhookList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	webhooks := make([]Webhook, len(s.webhooks))
+	for i, webhook := range s.webhooks {
+		webhook.Secret = "" // Secrets are only returned on creation
+		webhooks[i] = webhook
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"webhooks": webhooks,
+		"total":    len(webhooks),
+	})
+}
+
+func (s *Server) handleWebhookCreate(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name        string   `json:"name"`
+		URL         string   `json:"url"`
+		Description string   `json:"description"`
+		Events      []string `json:"events"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+	if req.Name == "" || req.URL == "" || len(req.Events) == 0 {
+		writeError(w, http.StatusBadRequest, "name, url and at least one event are required")
+		return
+	}
+
+	webhook := Webhook{
+		ID:          uuid.New().String(),
+		Name:        req.Name,
+		URL:         req.URL,
+		Description: req.Description,
+		Events:      req.Events,
+		Enabled:     true,
+		Status:      "active",
+		Secret:      "whsec_" + randomHex(24),
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	s.mu.Lock()
+	s.webhooks = append(s.webhooks, webhook)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, webhook)
+}
+
+func (s *Server) handleWebhookDelete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, webhook := range s.webhooks {
+		if webhook.ID == id {
+			s.webhooks = append(s.webhooks[:i], s.webhooks[i+1:]...)
+			writeJSON(w, http.StatusOK, map[string]interface{}{"message": "webhook deleted"})
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "webhook not found")
+}
+
+func (s *Server) handleHealthCheckList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	checks := append([]map[string]interface{}{}, s.healthChecks...)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"health_checks": checks,
+		"total":         len(checks),
+	})
+}
+
+func (s *Server) handleHealthCheckCreate(w http.ResponseWriter, r *http.Request) {
+	var check map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&check); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+	if _, ok := check["service_name"].(string); !ok {
+		writeError(w, http.StatusBadRequest, "service_name is required")
+		return
+	}
+
+	// Fill in the fields the real API computes
+	check["id"] = uuid.New().String()
+	if _, ok := check["check_name"].(string); !ok {
+		check["check_name"] = "health-check"
+	}
+	if _, ok := check["check_type"].(string); !ok {
+		check["check_type"] = "pull"
+	}
+	if _, ok := check["enabled"].(bool); !ok {
+		check["enabled"] = true
+	}
+	check["status"] = "unknown"
+	check["uptime_percentage"] = 100.0
+	check["consecutive_failures"] = 0
+	check["created_at"] = time.Now().UTC()
+
+	s.mu.Lock()
+	s.healthChecks = append(s.healthChecks, check)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, check)
+}
+
+func (s *Server) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
+	var heartbeat map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&heartbeat); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request")
+		return
+	}
+	now := time.Now().UTC()
+	heartbeat["received_at"] = now
+
+	s.mu.Lock()
+	s.heartbeats = append(s.heartbeats, heartbeat)
+	for _, check := range s.healthChecks {
+		if check["check_type"] == "push" && check["service_name"] == heartbeat["service_name"] {
+			check["status"] = "healthy"
+			check["last_check_at"] = now.Format(time.RFC3339)
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"status": "received"})
+}
+
+func (s *Server) handleDump(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.Snapshot())
+}
+
+func (s *Server) handleReset(w http.ResponseWriter, r *http.Request) {
+	s.Reset()
+	writeJSON(w, http.StatusOK, map[string]interface{}{"status": "reset"})
+}
+
+func (s *Server) requireAPIKey(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") == "" {
+			writeError(w, http.StatusUnauthorized, "missing API key")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		s.opts.Logf("%s %s → %d", r.Method, r.URL.Path, rec.status)
+	})
+}
+
+// statusRecorder captures the response status for request logging
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,102 @@
+package devserver
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/yourusername/context.io/cli/internal/trace"
+)
+
+// indexMaxSpans caps how many recent spans the inspection page lists
+const indexMaxSpans = 100
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>TraceKit Dev Server</title>
+  <meta http-equiv="refresh" content="5">
+  <style>
+    body { font-family: system-ui, sans-serif; margin: 2rem; color: #111827; }
+    h1 { color: #6366f1; }
+    h2 { margin-top: 2rem; border-bottom: 1px solid #e5e7eb; padding-bottom: .25rem; }
+    table { border-collapse: collapse; width: 100%; font-size: 14px; }
+    th, td { text-align: left; padding: .35rem .75rem; border-bottom: 1px solid #f3f4f6; }
+    th { color: #6b7280; font-weight: 600; }
+    code { background: #f3f4f6; padding: .1rem .3rem; border-radius: 4px; }
+    .error { color: #ef4444; font-weight: 600; }
+    .ok { color: #10b981; }
+    .muted { color: #9ca3af; }
+  </style>
+</head>
+<body>
+  <h1>TraceKit Dev Server</h1>
+  <p class="muted">
+    {{len .Snapshot.Spans}} spans · {{len .Snapshot.Payloads}} payloads ·
+    {{len .Snapshot.Webhooks}} webhooks · {{len .Snapshot.HealthChecks}} health checks ·
+    <a href="/_dev/dump">JSON dump</a> · reset with <code>curl -X POST {{.BaseURL}}/_dev/reset</code>
+  </p>
+
+  <h2>Recent spans</h2>
+  {{if .Spans}}
+  <table>
+    <tr><th>Started</th><th>Service</th><th>Name</th><th>Kind</th><th>Duration</th><th>Status</th><th>Trace ID</th></tr>
+    {{range .Spans}}
+    <tr>
+      <td>{{.StartTime.Format "15:04:05.000"}}</td>
+      <td>{{.Service.Name}}</td>
+      <td>{{.Name}}</td>
+      <td>{{.Kind}}</td>
+      <td>{{printf "%.1fms" .Duration}}</td>
+      <td class="{{.Status.Code}}">{{or .Status.Code "unset"}}</td>
+      <td><a href="/v1/traces/{{.TraceID}}" class="muted">{{.TraceID}}</a></td>
+    </tr>
+    {{end}}
+  </table>
+  {{else}}
+  <p class="muted">No spans received yet. Point your SDK at <code>{{.BaseURL}}/v1/traces</code>.</p>
+  {{end}}
+
+  <h2>Webhooks</h2>
+  {{if .Snapshot.Webhooks}}
+  <table>
+    <tr><th>ID</th><th>Name</th><th>URL</th><th>Events</th></tr>
+    {{range .Snapshot.Webhooks}}
+    <tr><td class="muted">{{.ID}}</td><td>{{.Name}}</td><td>{{.URL}}</td><td>{{range .Events}}{{.}} {{end}}</td></tr>
+    {{end}}
+  </table>
+  {{else}}
+  <p class="muted">None</p>
+  {{end}}
+
+  <h2>Health checks</h2>
+  {{if .Snapshot.HealthChecks}}
+  <table>
+    <tr><th>Service</th><th>Check</th><th>Type</th><th>Status</th></tr>
+    {{range .Snapshot.HealthChecks}}
+    <tr><td>{{index . "service_name"}}</td><td>{{index . "check_name"}}</td><td>{{index . "check_type"}}</td><td>{{index . "status"}}</td></tr>
+    {{end}}
+  </table>
+  {{else}}
+  <p class="muted">None</p>
+  {{end}}
+</body>
+</html>
+`))
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	snapshot := s.Snapshot()
+
+	// Newest spans first
+	spans := make([]trace.Span, 0, indexMaxSpans)
+	for i := len(snapshot.Spans) - 1; i >= 0 && len(spans) < indexMaxSpans; i-- {
+		spans = append(spans, snapshot.Spans[i])
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	indexTemplate.Execute(w, map[string]interface{}{
+		"Snapshot": snapshot,
+		"Spans":    spans,
+		"BaseURL":  s.opts.BaseURL,
+	})
+}
@@ -0,0 +1,109 @@
+package devserver
+
+import (
+	"strings"
+	"time"
+
+	"github.com/yourusername/context.io/cli/internal/trace"
+)
+
+// traceSummary mirrors client.TraceSummary
+type traceSummary struct {
+	TraceID     string    `json:"trace_id"`
+	RootName    string    `json:"root_name"`
+	ServiceName string    `json:"service_name"`
+	StartTime   time.Time `json:"start_time"`
+	DurationMs  float64   `json:"duration_ms"`
+	SpanCount   int       `json:"span_count"`
+	ErrorCount  int       `json:"error_count"`
+	Status      string    `json:"status"`
+}
+
+// spanFilter holds the query filters shared by search and stream
+type spanFilter struct {
+	service       string
+	status        string
+	route         string
+	since         time.Time
+	minDurationMs float64
+}
+
+func (f spanFilter) matchesSpan(span trace.Span) bool {
+	if f.service != "" && span.Service.Name != f.service {
+		return false
+	}
+	if f.status != "" && span.Status.Code != f.status {
+		return false
+	}
+	if f.minDurationMs > 0 && span.Duration < f.minDurationMs {
+		return false
+	}
+	if f.route != "" {
+		httpRoute, _ := span.Attributes["http.route"].(string)
+		if span.Name != f.route && httpRoute != f.route && !strings.HasSuffix(span.Name, " "+f.route) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f spanFilter) matchesTrace(t traceSummary) bool {
+	if f.service != "" && t.ServiceName != f.service {
+		return false
+	}
+	if f.status != "" && t.Status != f.status {
+		return false
+	}
+	if !f.since.IsZero() && t.StartTime.Before(f.since) {
+		return false
+	}
+	if f.minDurationMs > 0 && t.DurationMs < f.minDurationMs {
+		return false
+	}
+	return true
+}
+
+// groupByTrace buckets spans by trace ID
+func groupByTrace(spans []trace.Span) map[string][]trace.Span {
+	byTrace := make(map[string][]trace.Span)
+	for _, span := range spans {
+		byTrace[span.TraceID] = append(byTrace[span.TraceID], span)
+	}
+	return byTrace
+}
+
+// summarize builds a search result from all spans of one trace
+func summarize(spans []trace.Span) traceSummary {
+	roots := trace.BuildTree(spans)
+	root := roots[0].Span
+
+	start, end := float64(root.Timestamp), root.End()
+	errors := 0
+	for _, span := range spans {
+		if float64(span.Timestamp) < start {
+			start = float64(span.Timestamp)
+		}
+		if span.End() > end {
+			end = span.End()
+		}
+		if span.IsError() {
+			errors++
+		}
+	}
+
+	status := "ok"
+	if errors > 0 {
+		status = "error"
+	}
+
+	return traceSummary{
+		TraceID:     root.TraceID,
+		RootName:    root.Name,
+		ServiceName: root.Service.Name,
+		StartTime:   time.UnixMilli(int64(start)).UTC(),
+		DurationMs:  end - start,
+		SpanCount:   len(spans),
+		ErrorCount:  errors,
+		Status:      status,
+	}
+}
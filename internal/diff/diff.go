@@ -0,0 +1,144 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// context is the number of unchanged lines shown around each change
+const context = 3
+
+// op is one line of an edit script
+type op struct {
+	kind byte // ' ', '-' or '+'
+	text string
+}
+
+// Unified returns a unified diff turning before into after, labelled with
+// path. It returns an empty string when the contents are equal. An empty
+// before is shown as a new file.
+func Unified(path, before, after string) string {
+	if before == after {
+		return ""
+	}
+
+	a, b := splitLines(before), splitLines(after)
+	ops := editScript(a, b)
+
+	var out strings.Builder
+	if before == "" {
+		fmt.Fprintf(&out, "--- /dev/null\n+++ b/%s\n", path)
+	} else {
+		fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", path, path)
+	}
+
+	// Group changes that are within 2*context lines of each other into hunks
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			continue
+		}
+
+		first := max(start-context, 0)
+		last := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				last = i
+			} else if i-last > 2*context {
+				break
+			}
+		}
+		end := min(last+context+1, len(ops))
+
+		writeHunk(&out, ops, first, end)
+		start = end
+	}
+
+	return out.String()
+}
+
+func writeHunk(out *strings.Builder, ops []op, first, end int) {
+	// Line numbers at the start of the hunk
+	aLine, bLine := 1, 1
+	for _, o := range ops[:first] {
+		if o.kind != '+' {
+			aLine++
+		}
+		if o.kind != '-' {
+			bLine++
+		}
+	}
+
+	var aCount, bCount int
+	for _, o := range ops[first:end] {
+		if o.kind != '+' {
+			aCount++
+		}
+		if o.kind != '-' {
+			bCount++
+		}
+	}
+	if aCount == 0 {
+		aLine--
+	}
+	if bCount == 0 {
+		bLine--
+	}
+
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", aLine, aCount, bLine, bCount)
+	for _, o := range ops[first:end] {
+		out.WriteByte(o.kind)
+		out.WriteString(o.text)
+		out.WriteByte('\n')
+	}
+}
+
+// editScript computes a shortest line edit script from a to b using the
+// longest common subsequence
+func editScript(a, b []string) []op {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{'+', b[j]})
+	}
+	return ops
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
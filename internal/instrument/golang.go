@@ -0,0 +1,205 @@
+package instrument
+
+import (
+	"fmt"
+	"go/format"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/context.io/cli/internal/sdk"
+)
+
+// goEntrypoints are where Go services usually start, most common first
+var goEntrypoints = []string{"main.go", "cmd/*/main.go", "cmd/main.go", "server.go"}
+
+// goRouters match where a framework's router is created, capturing its
+// variable; the middleware is added on the line after the statement
+var goRouters = map[string]*regexp.Regexp{
+	"gin":         regexp.MustCompile(`(?m)^\s*(\w+)\s*:?=\s*gin\.(?:Default|New)\(`),
+	"echo":        regexp.MustCompile(`(?m)^\s*(\w+)\s*:?=\s*echo\.New\(`),
+	"fiber":       regexp.MustCompile(`(?m)^\s*(\w+)\s*:?=\s*fiber\.New\(`),
+	"chi":         regexp.MustCompile(`(?m)^\s*(\w+)\s*:?=\s*chi\.NewRouter\(`),
+	"gorilla-mux": regexp.MustCompile(`(?m)^\s*(\w+)\s*:?=\s*mux\.NewRouter\(`),
+}
+
+// The shapes of the catalog's Go snippets the planner can apply to code
+var (
+	goInitCall      = regexp.MustCompile(`^(\w+)\.\w+\(.*\)$`)                                 // tracekit.Init()
+	goUseSnippet    = regexp.MustCompile(`^\w+\.Use\((.+)\)$`)                                 // r.Use(middleware)
+	goWrapSnippet   = regexp.MustCompile(`^http\.ListenAndServe\([^,]+,\s*([\w.]+)\(\w+\)\)$`) // http.ListenAndServe(addr, wrap(mux))
+	goOptionSnippet = regexp.MustCompile(`^grpc\.NewServer\((.+)\)$`)                          // grpc.NewServer(option)
+)
+
+var (
+	goMain          = regexp.MustCompile(`(?m)^func main\(\)\s*\{[ \t]*$`)
+	goImportBlock   = regexp.MustCompile(`(?m)^import \($`)
+	goImportLine    = regexp.MustCompile(`(?m)^import (\w+ )?"[^"]+"[ \t]*$`)
+	goPackage       = regexp.MustCompile(`(?m)^package \w+[ \t]*$`)
+	goListenAndServ = regexp.MustCompile(`\bhttp\.ListenAndServe\(([^,()]+),\s*([\w.]+)\)`)
+	goGRPCServer    = regexp.MustCompile(`\bgrpc\.NewServer\(`)
+)
+
+// goServerPatterns find the file that sets up each framework's server
+var goServerPatterns = map[string]*regexp.Regexp{
+	"net-http": goListenAndServ,
+	"grpc":     goGRPCServer,
+	"connect":  regexp.MustCompile(`\bconnect\.`),
+}
+
+func goPlanner(b *planBuilder) error {
+	pattern := goServerPatterns[b.framework]
+	if r, ok := goRouters[b.framework]; ok {
+		pattern = r
+	}
+
+	entry := b.findFile(goEntrypoints, pattern)
+	if entry == "" {
+		return fmt.Errorf("no main.go or cmd/*/main.go found")
+	}
+
+	goSDK := sdk.GetRecommendedSDK("go", b.framework)
+	if goSDK == nil {
+		return fmt.Errorf("the SDK catalog has no Go SDK")
+	}
+	setup := newGoSetup(goSDK, b.framework)
+
+	return b.edit(entry, "initialize the TraceKit SDK and trace requests", func(before string) (string, error) {
+		// Only code from a signed catalog, which is checked against the SDK,
+		// is written into the app; the built-in snippets are only shown
+		if !sdk.CurrentCatalog().Signed() || setup.init == "" {
+			for _, step := range sdk.SetupSteps(*goSDK, b.framework) {
+				b.instruct(step)
+			}
+			return before, nil
+		}
+
+		content, err := setup.addInit(before)
+		if err != nil {
+			return "", err
+		}
+		content = b.addGoMiddleware(content, setup.middleware)
+
+		// gofmt sorts the SDK into the import block. Files that weren't
+		// formatted already are left as they are, so the diff stays small.
+		if formatted, err := format.Source([]byte(before)); err == nil && string(formatted) == before {
+			if formatted, err := format.Source([]byte(content)); err == nil {
+				content = string(formatted)
+			}
+		}
+		return content, nil
+	})
+}
+
+// goSetup is the catalog's Go setup code for one framework
+type goSetup struct {
+	importSpec string // Import of the SDK under the name the snippets use
+	init       string // Call that starts the SDK; "" when the catalog has none
+	middleware *sdk.Snippet
+}
+
+// newGoSetup picks the import, init call and middleware out of the SDK's
+// setup snippets for framework
+func newGoSetup(goSDK *sdk.SDK, framework string) goSetup {
+	var setup goSetup
+	for _, snippet := range goSDK.Setup {
+		if m := goInitCall.FindStringSubmatch(snippet.Code); m != nil {
+			setup.importSpec = m[1] + " " + strconv.Quote(goSDK.PackageName)
+			setup.init = snippet.Code
+			break
+		}
+	}
+	if m, ok := goSDK.Middleware[framework]; ok {
+		setup.middleware = &m
+	}
+	return setup
+}
+
+// addInit imports the SDK and starts it first thing in main
+func (s goSetup) addInit(content string) (string, error) {
+	m := goMain.FindStringIndex(content)
+	if m == nil {
+		return "", fmt.Errorf("no func main() to initialize the SDK in")
+	}
+	lines := strings.Split(content, "\n")
+	lines = insertAfter(lines, strings.Count(content[:m[0]], "\n"), "\t"+s.init, "")
+	content = strings.Join(lines, "\n")
+
+	if m := goImportBlock.FindStringIndex(content); m != nil {
+		end := strings.Index(content[m[1]:], "\n)")
+		if end >= 0 {
+			at := m[1] + end
+			return content[:at] + "\n\t" + s.importSpec + content[at:], nil
+		}
+	}
+	if m := goImportLine.FindAllStringIndex(content, -1); m != nil {
+		at := m[len(m)-1][1]
+		return content[:at] + "\nimport " + s.importSpec + content[at:], nil
+	}
+	m = goPackage.FindStringIndex(content)
+	if m == nil {
+		return "", fmt.Errorf("no package clause")
+	}
+	return content[:m[1]] + "\n\nimport " + s.importSpec + content[m[1]:], nil
+}
+
+// addGoMiddleware adds the catalog's middleware to the server, or leaves a
+// step to do by hand when the server or the snippet isn't in a shape the
+// planner recognizes
+func (b *planBuilder) addGoMiddleware(content string, snippet *sdk.Snippet) string {
+	if snippet == nil {
+		// The SDK traces this framework once it is initialized
+		return content
+	}
+
+	if r, ok := goRouters[b.framework]; ok {
+		use := goUseSnippet.FindStringSubmatch(snippet.Code)
+		m := r.FindStringSubmatchIndex(content)
+		if use == nil || m == nil {
+			b.instructMiddleware(snippet)
+			return content
+		}
+		lines := strings.Split(content, "\n")
+		start := strings.Count(content[:m[2]], "\n")
+		line := lineIndent(lines[start]) + content[m[2]:m[3]] + ".Use(" + use[1] + ")"
+		return strings.Join(insertAfter(lines, statementEnd(content, m[2]), line), "\n")
+	}
+
+	switch b.framework {
+	case "net-http":
+		wrap := goWrapSnippet.FindStringSubmatch(snippet.Code)
+		m := goListenAndServ.FindStringSubmatchIndex(content)
+		if wrap == nil || m == nil {
+			b.instructMiddleware(snippet)
+			return content
+		}
+		handler := content[m[4]:m[5]]
+		if handler == "nil" {
+			handler = "http.DefaultServeMux"
+		}
+		return content[:m[4]] + wrap[1] + "(" + handler + ")" + content[m[5]:]
+
+	case "grpc":
+		option := goOptionSnippet.FindStringSubmatch(snippet.Code)
+		m := goGRPCServer.FindStringIndex(content)
+		if option == nil || m == nil {
+			b.instructMiddleware(snippet)
+			return content
+		}
+		code := option[1]
+		if !strings.HasPrefix(content[m[1]:], ")") {
+			code += ", "
+		}
+		return content[:m[1]] + code + content[m[1]:]
+
+	default:
+		// Connect interceptors are passed to every generated handler constructor
+		b.instructMiddleware(snippet)
+		return content
+	}
+}
+
+// instructMiddleware leaves adding the middleware to the user
+func (b *planBuilder) instructMiddleware(snippet *sdk.Snippet) {
+	b.instruct(snippet.Description+":", "  "+snippet.Code)
+}
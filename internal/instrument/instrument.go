@@ -0,0 +1,192 @@
+package instrument
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/yourusername/context.io/cli/internal/detector"
+	"github.com/yourusername/context.io/cli/internal/plan"
+)
+
+// Change is an edit to a single file
+type Change struct {
+	Path        string // Relative to the project directory
+	Description string
+	Before      string
+	After       string
+}
+
+// Plan is the instrumentation of a project
+type Plan struct {
+	Changes      []Change
+	Instructions []string // Steps that couldn't be done automatically
+	Instrumented []string // Files that already reference TraceKit
+}
+
+// planner instruments one framework family
+type planner func(b *planBuilder) error
+
+// planners map frameworks to the code that instruments them
+var planners = map[string]planner{
+	"gin": goPlanner, "echo": goPlanner, "fiber": goPlanner, "chi": goPlanner,
+	"gorilla-mux": goPlanner, "net-http": goPlanner, "grpc": goPlanner, "connect": goPlanner,
+	"express": nodePlanner, "fastify": nodePlanner, "koa": nodePlanner, "hapi": nodePlanner,
+	"django":  djangoPlanner,
+	"laravel": laravelPlanner,
+	"rails":   railsPlanner,
+	"sinatra": sinatraPlanner,
+}
+
+// Supported reports whether framework can be instrumented automatically
+func Supported(framework string) bool {
+	_, ok := planners[framework]
+	return ok
+}
+
+// NewPlan finds the entrypoint of the framework's app in dir and works out
+// the edits that initialize the SDK and add its middleware
+func NewPlan(dir string, fw *detector.Framework) (*Plan, error) {
+	p, ok := planners[fw.Name]
+	if !ok {
+		return nil, fmt.Errorf("automatic instrumentation isn't available for %s", fw.Name)
+	}
+
+	b := &planBuilder{dir: dir, framework: fw.Name}
+	if err := p(b); err != nil {
+		return nil, err
+	}
+	return &b.plan, nil
+}
+
+// Apply writes every change in the plan into dir
+func (p *Plan) Apply(dir string) error {
+	for _, c := range p.Changes {
+		path := filepath.Join(dir, filepath.FromSlash(c.Path))
+		if err := plan.WriteFile(c.Description, path, []byte(c.After), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", c.Path, err)
+		}
+	}
+	return nil
+}
+
+// planBuilder accumulates the plan for one project
+type planBuilder struct {
+	dir       string
+	framework string
+	plan      Plan
+}
+
+// edit applies fn to the file at name (relative to the project). Files that
+// already mention TraceKit are left alone.
+func (b *planBuilder) edit(name, description string, fn func(content string) (string, error)) error {
+	content, err := os.ReadFile(filepath.Join(b.dir, filepath.FromSlash(name)))
+	if err != nil {
+		return err
+	}
+
+	if tracekitReference.Match(content) {
+		b.plan.Instrumented = append(b.plan.Instrumented, name)
+		return nil
+	}
+
+	after, err := fn(string(content))
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	if after != string(content) {
+		b.plan.Changes = append(b.plan.Changes, Change{Path: name, Description: description, Before: string(content), After: after})
+	}
+	return nil
+}
+
+// create adds the file at name (relative to the project) unless it exists
+func (b *planBuilder) create(name, description, content string) error {
+	if _, err := os.Stat(filepath.Join(b.dir, filepath.FromSlash(name))); err == nil {
+		b.plan.Instrumented = append(b.plan.Instrumented, name)
+		return nil
+	}
+	b.plan.Changes = append(b.plan.Changes, Change{Path: name, Description: description, After: content})
+	return nil
+}
+
+// instruct adds a step to do by hand
+func (b *planBuilder) instruct(lines ...string) {
+	b.plan.Instructions = append(b.plan.Instructions, lines...)
+}
+
+// tracekitReference matches code that already uses a TraceKit SDK: an
+// import of the Go SDK, the npm package, the Python module or the Ruby gem,
+// the Laravel or Rack middleware, or a call starting an SDK. Comments and
+// names that merely contain "tracekit" don't count.
+var tracekitReference = regexp.MustCompile(`(?m)"github\.com/Tracekit-Dev/go-sdk[/"]` +
+	`|['"]@tracekit/node-apm['"]` +
+	`|^\s*(?:import|from)\s+tracekit\b` +
+	`|['"]tracekit-ruby['"]` +
+	`|TraceKit\\Laravel\\|TraceKit::Rack::` +
+	`|\b(?:tracekit|TraceKit)\.(?:init|Init)\b`)
+
+// findFile returns the first of names (relative, globs allowed) that exists
+// and, when pattern is non-nil, matches it. Without a match it falls back to
+// the first file that exists.
+func (b *planBuilder) findFile(names []string, pattern *regexp.Regexp) string {
+	var first string
+	for _, name := range names {
+		matches, _ := filepath.Glob(filepath.Join(b.dir, filepath.FromSlash(name)))
+		for _, match := range matches {
+			rel, _ := filepath.Rel(b.dir, match)
+			rel = filepath.ToSlash(rel)
+			if first == "" {
+				first = rel
+			}
+			if pattern == nil {
+				return rel
+			}
+			if content, err := os.ReadFile(match); err == nil && pattern.Match(content) {
+				return rel
+			}
+		}
+	}
+	return first
+}
+
+// insertAfter inserts lines after line i (0-based)
+func insertAfter(lines []string, i int, insert ...string) []string {
+	out := append([]string{}, lines[:i+1]...)
+	out = append(out, insert...)
+	return append(out, lines[i+1:]...)
+}
+
+// statementEnd returns the line on which the call starting at offset ends,
+// following parentheses across lines (e.g. fiber.New(fiber.Config{...}))
+func statementEnd(content string, offset int) int {
+	depth := 0
+	started := false
+	for i := offset; i < len(content); i++ {
+		switch content[i] {
+		case '(', '{', '[':
+			depth++
+			started = true
+		case ')', '}', ']':
+			depth--
+		case '\n':
+			if started && depth <= 0 {
+				return strings.Count(content[:i], "\n")
+			}
+		}
+	}
+	return strings.Count(content, "\n")
+}
+
+// lineIndent returns the leading whitespace of line
+func lineIndent(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+// fileContains reports whether the file at name (relative to dir) matches pattern
+func fileContains(dir, name string, pattern *regexp.Regexp) bool {
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	return err == nil && pattern.Match(content)
+}
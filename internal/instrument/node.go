@@ -0,0 +1,119 @@
+package instrument
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// nodePackage is the TraceKit Node.js SDK on npm
+const nodePackage = "@tracekit/node-apm"
+
+// nodeEntrypoints are where Node.js servers usually start, most common first.
+// The package.json main field is tried before these.
+var nodeEntrypoints = []string{
+	"app.js", "server.js", "index.js", "app.ts", "server.ts", "index.ts",
+	"src/app.ts", "src/server.ts", "src/index.ts", "src/main.ts",
+	"src/app.js", "src/server.js", "src/index.js", "src/main.js",
+}
+
+// nodeServers match where each framework's server is created
+var nodeServers = map[string]*regexp.Regexp{
+	"express": regexp.MustCompile(`(?m)^\s*(?:export\s+)?(?:const|let|var)\s+(\w+)\s*=\s*express\(\)`),
+	"fastify": regexp.MustCompile(`\b[Ff]astify\(`),
+	"koa":     regexp.MustCompile(`\bnew\s+Koa\(`),
+	"hapi":    regexp.MustCompile(`\bHapi\.server\(`),
+}
+
+// esModule matches an ES module import
+var esModule = regexp.MustCompile(`(?m)^import\s`)
+
+// jsImportEnd matches the line that ends an import statement
+var jsImportEnd = regexp.MustCompile(`^import\s+['"]|\bfrom\s+['"][^'"]+['"]\s*;?\s*$`)
+
+func nodePlanner(b *planBuilder) error {
+	names := nodeEntrypoints
+	if main := packageMain(b.dir); main != "" {
+		names = append([]string{main}, names...)
+	}
+
+	entry := b.findFile(names, nodeServers[b.framework])
+	if entry == "" {
+		return fmt.Errorf("no app entrypoint found (package.json main, app.js, server.ts, ...)")
+	}
+
+	return b.edit(entry, "initialize the TraceKit SDK and trace requests", func(content string) (string, error) {
+		content = addNodeInit(content, esModule.MatchString(content) || strings.HasSuffix(entry, ".ts"))
+
+		if b.framework != "express" {
+			// The SDK instruments the other frameworks once it is initialized
+			return content, nil
+		}
+		m := nodeServers["express"].FindStringSubmatchIndex(content)
+		if m == nil {
+			b.instruct("Add the TraceKit middleware to your Express app:", "  app.use(tracekit.expressMiddleware());")
+			return content, nil
+		}
+		lines := strings.Split(content, "\n")
+		start := strings.Count(content[:m[2]], "\n")
+		use := lineIndent(lines[start]) + content[m[2]:m[3]] + ".use(tracekit.expressMiddleware());"
+		return strings.Join(insertAfter(lines, statementEnd(content, m[2]), use), "\n"), nil
+	})
+}
+
+// addNodeInit loads and starts the SDK. CommonJS modules start it before
+// anything else is required; ES modules import it first and start it after
+// the last import, since imports are hoisted.
+func addNodeInit(content string, esm bool) string {
+	lines := strings.Split(content, "\n")
+
+	// Keep a shebang and 'use strict' first
+	top := 0
+	for top < len(lines) {
+		trimmed := strings.TrimSpace(lines[top])
+		if !strings.HasPrefix(trimmed, "#!") && !strings.HasPrefix(trimmed, "'use strict'") && !strings.HasPrefix(trimmed, `"use strict"`) {
+			break
+		}
+		top++
+	}
+
+	if !esm {
+		init := []string{"const tracekit = require('" + nodePackage + "');", "tracekit.init();", ""}
+		return strings.Join(append(lines[:top:top], append(init, lines[top:]...)...), "\n")
+	}
+
+	last := -1
+	inImport := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "import ") || strings.HasPrefix(trimmed, "import{") {
+			inImport = true
+		}
+		if inImport && jsImportEnd.MatchString(trimmed) {
+			last = i
+			inImport = false
+		}
+	}
+
+	lines = insertAfter(lines, max(last, top-1), "", "tracekit.init();")
+	imp := []string{"import tracekit from '" + nodePackage + "';"}
+	return strings.Join(append(lines[:top:top], append(imp, lines[top:]...)...), "\n")
+}
+
+// packageMain returns the main field of package.json in dir, if it names a file
+func packageMain(dir string) string {
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		Main string `json:"main"`
+	}
+	if json.Unmarshal(content, &pkg) != nil || pkg.Main == "" {
+		return ""
+	}
+	return filepath.ToSlash(filepath.Clean(pkg.Main))
+}
@@ -0,0 +1,48 @@
+package instrument
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// laravelMiddleware traces Laravel requests. The SDK's service provider is
+// auto-discovered and initializes it.
+const laravelMiddleware = `\TraceKit\Laravel\Middleware\TraceRequests::class`
+
+var (
+	// laravelWithMiddleware matches the middleware callback of Laravel 11+
+	// bootstrap/app.php, capturing the variable it receives
+	laravelWithMiddleware = regexp.MustCompile(`->withMiddleware\(function\s*\(\s*Middleware\s+(\$\w+)\s*\)[^{]*\{`)
+	// laravelKernelMiddleware matches the global middleware of Laravel 10 and earlier
+	laravelKernelMiddleware = regexp.MustCompile(`(?m)^([ \t]*)protected \$middleware\s*=\s*\[[ \t]*$`)
+)
+
+// laravelPlanner appends the middleware to the global stack: in
+// bootstrap/app.php on Laravel 11+, or app/Http/Kernel.php before that
+func laravelPlanner(b *planBuilder) error {
+	if app := "bootstrap/app.php"; fileContains(b.dir, app, laravelWithMiddleware) {
+		return b.edit(app, "add the TraceKit middleware", func(content string) (string, error) {
+			m := laravelWithMiddleware.FindStringSubmatchIndex(content)
+			lines := strings.Split(content, "\n")
+			i := strings.Count(content[:m[1]], "\n")
+			line := lineIndent(lines[i]) + "    " + content[m[2]:m[3]] + "->append(" + laravelMiddleware + ");"
+			return strings.Join(insertAfter(lines, i, line), "\n"), nil
+		})
+	}
+
+	if kernel := b.findFile([]string{"app/Http/Kernel.php"}, nil); kernel != "" {
+		return b.edit(kernel, "add the TraceKit middleware", func(content string) (string, error) {
+			m := laravelKernelMiddleware.FindStringSubmatchIndex(content)
+			if m == nil {
+				return "", fmt.Errorf("no protected $middleware list")
+			}
+			lines := strings.Split(content, "\n")
+			i := strings.Count(content[:m[0]], "\n")
+			line := content[m[2]:m[3]] + "    " + laravelMiddleware + ","
+			return strings.Join(insertAfter(lines, i, line), "\n"), nil
+		})
+	}
+
+	return fmt.Errorf("no bootstrap/app.php with withMiddleware() or app/Http/Kernel.php found")
+}
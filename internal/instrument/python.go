@@ -0,0 +1,92 @@
+package instrument
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// djangoMiddleware traces Django requests
+const djangoMiddleware = "tracekit.django.TracekitMiddleware"
+
+var (
+	// djangoSettingsModule matches the settings module manage.py points at
+	djangoSettingsModule = regexp.MustCompile(`DJANGO_SETTINGS_MODULE['"]\s*,\s*['"]([\w.]+)['"]`)
+	djangoMiddlewareList = regexp.MustCompile(`(?m)^MIDDLEWARE\s*=\s*\[[ \t]*$`)
+	pythonImport         = regexp.MustCompile(`^(import|from)\s`)
+)
+
+// djangoPlanner starts the SDK in settings.py, where Django projects set up
+// their other SDKs, and puts its middleware first in MIDDLEWARE so it times
+// the whole request
+func djangoPlanner(b *planBuilder) error {
+	settings := b.findFile([]string{"*/settings.py", "*/settings/base.py", "*/settings/__init__.py"}, djangoMiddlewareList)
+	if content, err := os.ReadFile(filepath.Join(b.dir, "manage.py")); err == nil {
+		if m := djangoSettingsModule.FindSubmatch(content); m != nil {
+			name := strings.ReplaceAll(string(m[1]), ".", "/") + ".py"
+			if _, err := os.Stat(filepath.Join(b.dir, filepath.FromSlash(name))); err == nil {
+				settings = name
+			}
+		}
+	}
+	if settings == "" {
+		return fmt.Errorf("no Django settings.py found")
+	}
+
+	return b.edit(settings, "initialize the TraceKit SDK and add its middleware", func(content string) (string, error) {
+		lines := strings.Split(content, "\n")
+
+		if m := djangoMiddlewareList.FindStringIndex(content); m != nil {
+			i := strings.Count(content[:m[0]], "\n")
+			indent := "    "
+			if i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "]" {
+				indent = lineIndent(lines[i+1])
+			}
+			lines = insertAfter(lines, i, indent+`"`+djangoMiddleware+`",`)
+		} else {
+			b.instruct("Add the TraceKit middleware first in MIDDLEWARE:", `  "`+djangoMiddleware+`",`)
+		}
+
+		init := []string{"import tracekit", "", "tracekit.init()"}
+		last := pythonImportsEnd(lines)
+		if last < 0 || (last+1 < len(lines) && strings.TrimSpace(lines[last+1]) != "") {
+			init = append(init, "")
+		}
+		return strings.Join(insertAfter(lines, last, init...), "\n"), nil
+	})
+}
+
+// pythonImportsEnd returns the last line of the imports at the top of a
+// module, or of its docstring and comments when it has no imports
+func pythonImportsEnd(lines []string) int {
+	last := -1
+	inDocstring, inParens := false, false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inParens:
+			// from x import (a, b) split over lines
+			inParens = !strings.HasPrefix(trimmed, ")")
+			last = i
+		case inDocstring:
+			if strings.Contains(trimmed, `"""`) || strings.Contains(trimmed, "'''") {
+				inDocstring = false
+				last = i
+			}
+		case strings.HasPrefix(trimmed, `"""`) || strings.HasPrefix(trimmed, "'''"):
+			// A docstring that doesn't close on its opening line
+			inDocstring = len(trimmed) < 6 || !(strings.HasSuffix(trimmed, `"""`) || strings.HasSuffix(trimmed, "'''"))
+			last = i
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			continue
+		case pythonImport.MatchString(line):
+			inParens = strings.HasSuffix(trimmed, "(")
+			last = i
+		default:
+			return last
+		}
+	}
+	return last
+}
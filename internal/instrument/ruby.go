@@ -0,0 +1,88 @@
+package instrument
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/yourusername/context.io/cli/internal/sdk"
+)
+
+// rubyRequire loads the Ruby SDK outside Rails, which requires gems itself
+const rubyRequire = `require "tracekit-ruby"`
+
+var (
+	rubyRequireLine = regexp.MustCompile(`^require(_relative)?\s`)
+	sinatraRequire  = regexp.MustCompile(`(?m)^require\s+['"]sinatra(/base)?['"]`)
+	sinatraModular  = regexp.MustCompile(`(?m)^([ \t]*)class \w+(::\w+)* < Sinatra::(Base|Application)[ \t]*$`)
+	rackRun         = regexp.MustCompile(`(?m)^run\s`)
+)
+
+// railsPlanner creates the initializer that starts the SDK and adds its
+// middleware, unless config/application.rb already does
+func railsPlanner(b *planBuilder) error {
+	const app = "config/application.rb"
+	if b.findFile([]string{app}, nil) == "" {
+		return fmt.Errorf("no %s found", app)
+	}
+	if fileContains(b.dir, app, tracekitReference) {
+		b.plan.Instrumented = append(b.plan.Instrumented, app)
+		return nil
+	}
+	return b.create(sdk.RailsInitializerPath, "start the TraceKit SDK and add its middleware", sdk.RailsInitializer)
+}
+
+// sinatraPlanner adds the middleware in config.ru when the app is started
+// from there, or in the app file otherwise
+func sinatraPlanner(b *planBuilder) error {
+	if fileContains(b.dir, "config.ru", rackRun) {
+		return b.edit("config.ru", "start the TraceKit SDK and add its middleware", func(content string) (string, error) {
+			lines := addRubyRequire(strings.Split(content, "\n"))
+			content = strings.Join(lines, "\n")
+			m := rackRun.FindStringIndex(content)
+			i := strings.Count(content[:m[0]], "\n")
+			return strings.Join(insertAfter(lines, i-1, "TraceKit.init", "use "+sdk.RackMiddleware, ""), "\n"), nil
+		})
+	}
+
+	app := b.findFile([]string{"app.rb", "server.rb", "main.rb", "application.rb", "*.rb", "lib/*.rb"}, sinatraRequire)
+	if app == "" || !fileContains(b.dir, app, sinatraRequire) {
+		return fmt.Errorf("no config.ru or Ruby file requiring sinatra found")
+	}
+	return b.edit(app, "start the TraceKit SDK and add its middleware", func(content string) (string, error) {
+		lines := addRubyRequire(strings.Split(content, "\n"))
+		last := rubyRequiresEnd(lines)
+
+		// Modular apps take middleware in the class body
+		content = strings.Join(lines, "\n")
+		if m := sinatraModular.FindStringSubmatchIndex(content); m != nil {
+			i := strings.Count(content[:m[0]], "\n")
+			lines = insertAfter(lines, i, content[m[2]:m[3]]+"  use "+sdk.RackMiddleware, "")
+			return strings.Join(insertAfter(lines, last, "", "TraceKit.init"), "\n"), nil
+		}
+		return strings.Join(insertAfter(lines, last, "", "TraceKit.init", "use "+sdk.RackMiddleware), "\n"), nil
+	})
+}
+
+// addRubyRequire requires the SDK after the file's other requires
+func addRubyRequire(lines []string) []string {
+	return insertAfter(lines, rubyRequiresEnd(lines), rubyRequire)
+}
+
+// rubyRequiresEnd returns the last require line at the top of a file
+// (after any leading comments), or -1
+func rubyRequiresEnd(lines []string) int {
+	last := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case rubyRequireLine.MatchString(trimmed):
+			last = i
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			continue
+		default:
+			return last
+		}
+	}
+	return last
+}
@@ -0,0 +1,56 @@
+// Package keyring stores secrets in the operating system's credential store
+// through its command-line tool: security on macOS and secret-tool
+// (libsecret) on Linux.
+package keyring
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/yourusername/context.io/cli/internal/plan"
+)
+
+// Service is the keyring service TraceKit secrets are stored under
+const Service = "tracekit"
+
+// Set stores secret under Service and account, replacing any previous value
+func Set(reason, account, label, secret string) error {
+	var args []string
+	stdin := ""
+	switch runtime.GOOS {
+	case "darwin":
+		// security only takes the password as an argument; -U replaces an
+		// existing item
+		args = []string{"security", "add-generic-password", "-U", "-s", Service, "-a", account, "-l", label, "-w", secret}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		args = []string{"secret-tool", "store", "--label", label, "service", Service, "account", account}
+		stdin = secret
+	default:
+		return fmt.Errorf("no supported keyring on %s", runtime.GOOS)
+	}
+
+	shown := make([]string, len(args))
+	for i, arg := range args {
+		if arg == secret {
+			arg = plan.Placeholder("secret")
+		}
+		shown[i] = arg
+	}
+	if plan.Command(reason, "", shown, nil) {
+		return nil
+	}
+
+	if _, err := exec.LookPath(args[0]); err != nil {
+		return fmt.Errorf("%s not found; install it or save the secret to a file instead", args[0])
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
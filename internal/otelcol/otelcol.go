@@ -0,0 +1,313 @@
+// Package otelcol generates OpenTelemetry Collector configuration that
+// exports traces to TraceKit, for teams that run their own collector instead
+// of a TraceKit SDK.
+package otelcol
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Component and pipeline names. The /tracekit suffix keeps them apart from
+// components of the same type already in a collector config.
+const (
+	Exporter     = "otlphttp/tracekit"
+	Pipeline     = "traces/tracekit"
+	memoryLimit  = "memory_limiter/tracekit"
+	tailSampling = "tail_sampling/tracekit"
+	batch        = "batch/tracekit"
+)
+
+// Options configures the generated collector config
+type Options struct {
+	Endpoint         string // TraceKit API base URL
+	APIKeyEnv        string // Environment variable holding the API key
+	TailSampling     bool   // Keep errors, slow traces and a sample of the rest
+	SamplePercentage int    // Share of other traces kept with tail sampling
+	SlowThresholdMS  int    // Traces slower than this are always kept
+}
+
+// Generate returns a complete collector config: an OTLP receiver and a
+// traces pipeline exporting to TraceKit
+func Generate(opts Options) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `# OpenTelemetry Collector configuration exporting traces to TraceKit.
+# The API key is read from the %s environment variable.
+
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+      http:
+        endpoint: 0.0.0.0:4318
+
+`, opts.APIKeyEnv)
+	b.WriteString(components(opts))
+	fmt.Fprintf(&b, `
+service:
+  pipelines:
+    %s:
+      receivers: [otlp]
+      processors: [%s]
+      exporters: [%s]
+`, Pipeline, strings.Join(processors(opts), ", "), Exporter)
+	return b.String()
+}
+
+// components renders the processors and exporter sections
+func components(opts Options) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `processors:
+  # Refuses data before the collector runs out of memory; keep it first
+  %s:
+    check_interval: 1s
+    limit_percentage: 80
+    spike_limit_percentage: 20
+`, memoryLimit)
+	if opts.TailSampling {
+		fmt.Fprintf(&b, `  # Keeps every trace with an error or a slow span, and a share of the rest.
+  # Needs a collector distribution with tail sampling (otelcol-contrib).
+  %s:
+    decision_wait: 10s
+    policies:
+      - name: errors
+        type: status_code
+        status_code:
+          status_codes: [ERROR]
+      - name: slow
+        type: latency
+        latency:
+          threshold_ms: %d
+      - name: sample
+        type: probabilistic
+        probabilistic:
+          sampling_percentage: %d
+`, tailSampling, opts.SlowThresholdMS, opts.SamplePercentage)
+	}
+	fmt.Fprintf(&b, `  # Sends spans in batches; keep it last
+  %s:
+    send_batch_size: 512
+    timeout: 5s
+
+exporters:
+  %s:
+    endpoint: %s
+    headers:
+      X-API-Key: ${env:%s}
+`, batch, Exporter, opts.Endpoint, opts.APIKeyEnv)
+	return b.String()
+}
+
+func processors(opts Options) []string {
+	if opts.TailSampling {
+		return []string{memoryLimit, tailSampling, batch}
+	}
+	return []string{memoryLimit, batch}
+}
+
+// Merge adds the TraceKit exporter, processors and a traces pipeline to an
+// existing collector config. The pipeline takes its receivers from the
+// config's traces pipelines, so other pipelines are left as they are.
+// Merging again replaces the TraceKit components.
+func Merge(content string, opts Options) (string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return "", fmt.Errorf("invalid YAML: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return Generate(opts), nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return "", fmt.Errorf("not a collector config: expected a mapping at the top level")
+	}
+
+	var generated yaml.Node
+	if err := yaml.Unmarshal([]byte(Generate(opts)), &generated); err != nil {
+		return "", err
+	}
+	gen := generated.Content[0]
+
+	service, err := ensureMapping(root, "service")
+	if err != nil {
+		return "", err
+	}
+	pipelines, err := ensureMapping(service, "pipelines")
+	if err != nil {
+		return "", err
+	}
+
+	receivers := tracesReceivers(pipelines)
+	if len(receivers) == 0 {
+		// No traces pipeline to share receivers with: use (or add) OTLP
+		existing, err := ensureMapping(root, "receivers")
+		if err != nil {
+			return "", err
+		}
+		if mappingValue(existing, "otlp") == nil {
+			setEntry(existing, "otlp", mappingValue(mappingValue(gen, "receivers"), "otlp"))
+		}
+		receivers = []string{"otlp"}
+	}
+
+	for _, section := range []string{"processors", "exporters"} {
+		existing, err := ensureMapping(root, section)
+		if err != nil {
+			return "", err
+		}
+		// Drop TraceKit components from an earlier merge that are no longer
+		// wanted (tail sampling turned off)
+		for _, e := range mappingEntries(existing) {
+			if strings.HasSuffix(e.name, "/tracekit") && mappingValue(mappingValue(gen, section), e.name) == nil {
+				deleteEntry(existing, e.name)
+			}
+		}
+		for _, e := range mappingEntries(mappingValue(gen, section)) {
+			setEntryWithKey(existing, e.key, e.value)
+		}
+	}
+
+	pipeline := mappingValue(mappingValue(mappingValue(gen, "service"), "pipelines"), Pipeline)
+	mappingValue(pipeline, "receivers").Content = scalars(receivers)
+	setEntry(pipelines, Pipeline, pipeline)
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return "", err
+	}
+	encoder.Close()
+
+	// The encoder drops blank lines; keep sections apart as the file did
+	if strings.Contains(content, "\n\n") {
+		return spaceSections(out.String()), nil
+	}
+	return out.String(), nil
+}
+
+// spaceSections puts a blank line before each top-level key after the first,
+// above any comment lines that belong to it
+func spaceSections(content string) string {
+	lines := strings.Split(content, "\n")
+	var out []string
+	for i, line := range lines {
+		topLevel := line != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "-")
+		if topLevel && i > 0 {
+			// Move above the key's head comment
+			start := len(out)
+			for start > 0 && strings.HasPrefix(out[start-1], "#") {
+				start--
+			}
+			if start > 0 && out[start-1] != "" {
+				out = append(out[:start], append([]string{""}, out[start:]...)...)
+			}
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// tracesReceivers returns the receivers of the traces pipelines other than
+// TraceKit's, in order of first use
+func tracesReceivers(pipelines *yaml.Node) []string {
+	var receivers []string
+	seen := make(map[string]bool)
+	for _, p := range mappingEntries(pipelines) {
+		if p.name == Pipeline || (p.name != "traces" && !strings.HasPrefix(p.name, "traces/")) {
+			continue
+		}
+		r := mappingValue(p.value, "receivers")
+		if r == nil || r.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, n := range r.Content {
+			if !seen[n.Value] {
+				seen[n.Value] = true
+				receivers = append(receivers, n.Value)
+			}
+		}
+	}
+	return receivers
+}
+
+// yamlEntry is a key and its value in a YAML mapping
+type yamlEntry struct {
+	name  string
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// mappingEntries returns the entries of a mapping node in order
+func mappingEntries(node *yaml.Node) []yamlEntry {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	var entries []yamlEntry
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		entries = append(entries, yamlEntry{node.Content[i].Value, node.Content[i], node.Content[i+1]})
+	}
+	return entries
+}
+
+// mappingValue returns the value for key in a mapping node
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for _, e := range mappingEntries(node) {
+		if e.name == key {
+			return e.value
+		}
+	}
+	return nil
+}
+
+// ensureMapping returns the mapping under key in node, adding an empty one
+// when the key is missing or has no value (e.g. "processors:")
+func ensureMapping(node *yaml.Node, key string) (*yaml.Node, error) {
+	value := mappingValue(node, key)
+	switch {
+	case value == nil, value.Kind == yaml.ScalarNode && value.Tag == "!!null":
+		value = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setEntry(node, key, value)
+	case value.Kind != yaml.MappingNode:
+		return nil, fmt.Errorf("%s is not a mapping", key)
+	}
+	return value, nil
+}
+
+// setEntry sets key to value in a mapping node, replacing an existing value
+func setEntry(node *yaml.Node, key string, value *yaml.Node) {
+	setEntryWithKey(node, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// setEntryWithKey is setEntry keeping the key node (and its comments)
+func setEntryWithKey(node *yaml.Node, key, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key.Value {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, key, value)
+}
+
+// deleteEntry removes key from a mapping node
+func deleteEntry(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+func scalars(values []string) []*yaml.Node {
+	nodes := make([]*yaml.Node, len(values))
+	for i, v := range values {
+		nodes[i] = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+	}
+	return nodes
+}
@@ -0,0 +1,107 @@
+package otelcol
+
+import (
+	"strings"
+	"testing"
+)
+
+const existingConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+  jaeger:
+    protocols:
+      thrift_http:
+
+processors:
+  batch:
+
+exporters:
+  debug:
+
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, jaeger]
+      processors: [batch]
+      exporters: [debug]
+    metrics:
+      receivers: [otlp]
+      exporters: [debug]
+`
+
+func TestMergeIdempotent(t *testing.T) {
+	opts := Options{Endpoint: "https://app.tracekit.dev", APIKeyEnv: "TRACEKIT_API_KEY"}
+	sampled := opts
+	sampled.TailSampling, sampled.SamplePercentage, sampled.SlowThresholdMS = true, 10, 1000
+
+	tests := []struct {
+		name    string
+		content string
+		opts    Options
+	}{
+		{"existing traces pipeline", existingConfig, opts},
+		{"existing traces pipeline with tail sampling", existingConfig, sampled},
+		{"metrics only", "receivers:\n  prometheus:\n    config: {}\nexporters:\n  debug:\nservice:\n  pipelines:\n    metrics:\n      receivers: [prometheus]\n      exporters: [debug]\n", opts},
+		{"empty", "", opts},
+		{"generated", Generate(sampled), sampled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			once, err := Merge(tt.content, tt.opts)
+			if err != nil {
+				t.Fatalf("Merge() error = %v", err)
+			}
+			twice, err := Merge(once, tt.opts)
+			if err != nil {
+				t.Fatalf("second Merge() error = %v", err)
+			}
+			if twice != once {
+				t.Errorf("Merge() is not idempotent\nfirst:\n%s\nsecond:\n%s", once, twice)
+			}
+			if n := strings.Count(once, Exporter+":"); n != 1 {
+				t.Errorf("Merge() defines %s %d times, want once", Exporter, n)
+			}
+		})
+	}
+}
+
+func TestMergeKeepsExistingPipelines(t *testing.T) {
+	merged, err := Merge(existingConfig, Options{Endpoint: "https://app.tracekit.dev", APIKeyEnv: "TRACEKIT_API_KEY"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"    traces:\n", "    metrics:\n", "    " + Pipeline + ":\n", "receivers: [otlp, jaeger]"} {
+		if !strings.Contains(merged, want) {
+			t.Errorf("merged config is missing %q:\n%s", want, merged)
+		}
+	}
+}
+
+func TestMergeDropsTailSampling(t *testing.T) {
+	opts := Options{Endpoint: "https://app.tracekit.dev", APIKeyEnv: "TRACEKIT_API_KEY", TailSampling: true, SamplePercentage: 10, SlowThresholdMS: 1000}
+	sampled, err := Merge(existingConfig, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(sampled, tailSampling) {
+		t.Fatalf("merged config has no %s:\n%s", tailSampling, sampled)
+	}
+
+	opts.TailSampling = false
+	unsampled, err := Merge(sampled, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(unsampled, tailSampling) {
+		t.Errorf("%s left in after merging without tail sampling:\n%s", tailSampling, unsampled)
+	}
+	fresh, err := Merge(existingConfig, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unsampled != fresh {
+		t.Errorf("merging without tail sampling differs from a fresh merge\ngot:\n%s\nwant:\n%s", unsampled, fresh)
+	}
+}
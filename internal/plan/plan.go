@@ -0,0 +1,203 @@
+// Package plan records what mutating commands would do under --dry-run:
+// the commands they'd run, the files they'd write and the API requests
+// they'd send. Code that changes anything goes through this package, which
+// either does it or, in a dry run, records it. Files it writes are also
+// recorded in the project's state manifest for 'tracekit rollback'.
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/yourusername/context.io/cli/internal/diff"
+	"github.com/yourusername/context.io/cli/internal/state"
+)
+
+// Action is one change a command would make
+type Action struct {
+	Type   string `json:"type"`             // "command", "write", "remove" or "request"
+	Reason string `json:"reason,omitempty"` // What the action is for
+
+	// command
+	Command string `json:"command,omitempty"`
+	Dir     string `json:"dir,omitempty"`
+
+	// write, remove
+	Path string `json:"path,omitempty"`
+	Diff string `json:"diff,omitempty"` // Unified diff, with secrets redacted
+
+	// request
+	Method string `json:"method,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Body   any    `json:"body,omitempty"` // JSON body, with secrets redacted
+}
+
+var (
+	dryRun  bool
+	actions []Action
+)
+
+// StartDryRun makes the package record changes instead of making them
+func StartDryRun() {
+	dryRun = true
+}
+
+// DryRun reports whether changes are being recorded instead of made
+func DryRun() bool {
+	return dryRun
+}
+
+// Actions returns the changes recorded so far
+func Actions() []Action {
+	return actions
+}
+
+// Placeholder stands in for a value only the API could return, such as an
+// API key, in a dry run
+func Placeholder(name string) string {
+	return "<" + name + ">"
+}
+
+// Command records a command in a dry run, reporting whether it was recorded
+// (and so must not be run)
+func Command(reason, dir string, args []string, env []string) bool {
+	if !dryRun {
+		return false
+	}
+	line := strings.Join(args, " ")
+	if len(env) > 0 {
+		line = strings.Join(env, " ") + " " + line
+	}
+	actions = append(actions, Action{Type: "command", Reason: reason, Command: line, Dir: dir})
+	return true
+}
+
+// WriteFile writes data to path, creating its directory. In a dry run it
+// records the change as a diff instead.
+func WriteFile(reason, path string, data []byte, perm os.FileMode) error {
+	if dryRun {
+		before, _ := os.ReadFile(path)
+		actions = append(actions, Action{
+			Type:   "write",
+			Reason: reason,
+			Path:   path,
+			Diff:   redactDiff(diff.Unified(displayPath(path), string(before), string(data))),
+		})
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	change, err := state.PrepareFile(reason, path, data)
+	if err != nil {
+		return fmt.Errorf("failed to record change: %w", err)
+	}
+	if err := os.WriteFile(path, data, perm); err != nil {
+		state.Discard(change)
+		return err
+	}
+	if err := state.Record(change); err != nil {
+		return fmt.Errorf("failed to record change: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes the file at path, or records it in a dry run
+func Remove(reason, path string) error {
+	if dryRun {
+		if _, err := os.Stat(path); err == nil {
+			actions = append(actions, Action{Type: "remove", Reason: reason, Path: path})
+		}
+		return nil
+	}
+	change, err := state.PrepareRemove(reason, path)
+	if err != nil {
+		return fmt.Errorf("failed to record change: %w", err)
+	}
+	if err := os.Remove(path); err != nil {
+		state.Discard(change)
+		return err
+	}
+	if err := state.Record(change); err != nil {
+		return fmt.Errorf("failed to record change: %w", err)
+	}
+	return nil
+}
+
+// Request records an API request in a dry run, reporting whether it was
+// recorded (and so must not be sent). body is the JSON request body, if any.
+func Request(reason, method, url string, body []byte) bool {
+	if !dryRun {
+		return false
+	}
+	action := Action{Type: "request", Reason: reason, Method: method, URL: url}
+	if len(body) > 0 {
+		var decoded any
+		if err := json.Unmarshal(body, &decoded); err == nil {
+			action.Body = redactJSON(decoded)
+		}
+	}
+	actions = append(actions, action)
+	return true
+}
+
+// displayPath shortens path relative to the working directory, for diffs
+func displayPath(path string) string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(cwd, abs); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// secretKeyPattern matches JSON keys and environment variables holding
+// credentials
+var secretKeyPattern = regexp.MustCompile(`(?i)(api_?key|secret|token|password)`)
+
+// secretLinePattern matches credential assignments in lines of a diff
+// (.env files and YAML environment blocks), including OTLP headers, skipping
+// references such as ${TRACEKIT_API_KEY} that hold no secret themselves
+var secretLinePattern = regexp.MustCompile(`(?m)^([+ -]\s*[A-Z0-9_]*(?:API_?KEY|SECRET|TOKEN|PASSWORD|HEADERS)[A-Z0-9_]*\s*[=:]\s*)[^\s$].*$`)
+
+// secretHeaderPattern matches an API key header value wherever it appears,
+// such as a Kubernetes env value on the line after its name
+var secretHeaderPattern = regexp.MustCompile(`(?i)(X-API-Key\s*[=:]\s*)[^\s$"',][^\s"',]*`)
+
+const redacted = "[redacted]"
+
+func redactDiff(d string) string {
+	d = secretLinePattern.ReplaceAllString(d, "${1}"+redacted)
+	return secretHeaderPattern.ReplaceAllString(d, "${1}"+redacted)
+}
+
+// redactJSON replaces the values of credential fields (and verification
+// codes) in a decoded JSON value
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if secretKeyPattern.MatchString(key) || key == "code" {
+				v[key] = redacted
+			} else {
+				v[key] = redactJSON(value)
+			}
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redactJSON(value)
+		}
+	}
+	return v
+}
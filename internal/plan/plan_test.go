@@ -0,0 +1,35 @@
+package plan
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactDiff(t *testing.T) {
+	const key = "tk_live_0123456789abcdef"
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"env api key", "+TRACEKIT_API_KEY=" + key, "+TRACEKIT_API_KEY=[redacted]"},
+		{"env otlp headers", "+OTEL_EXPORTER_OTLP_HEADERS=X-API-Key=" + key, "+OTEL_EXPORTER_OTLP_HEADERS=[redacted]"},
+		{"yaml api key", `+      TRACEKIT_API_KEY: "` + key + `"`, "+      TRACEKIT_API_KEY: [redacted]"},
+		{"yaml otlp headers", "+      OTEL_EXPORTER_OTLP_HEADERS: X-API-Key=" + key, "+      OTEL_EXPORTER_OTLP_HEADERS: [redacted]"},
+		{"kubernetes value", "+          value: X-API-Key=" + key, "+          value: X-API-Key=[redacted]"},
+		{"quoted header", `+  "headers": "X-API-Key=` + key + `"`, `+  "headers": "X-API-Key=[redacted]"`},
+		{"reference", "+TRACEKIT_API_KEY=${TRACEKIT_API_KEY}", "+TRACEKIT_API_KEY=${TRACEKIT_API_KEY}"},
+		{"plain variable", "+TRACEKIT_ENDPOINT=https://app.tracekit.dev", "+TRACEKIT_ENDPOINT=https://app.tracekit.dev"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redactDiff(tt.line)
+			if got != tt.want {
+				t.Errorf("redactDiff(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+			if strings.Contains(got, key) {
+				t.Errorf("redactDiff(%q) leaks the key", tt.line)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package rum
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/yourusername/context.io/cli/internal/config"
+	"github.com/yourusername/context.io/cli/internal/plan"
+)
+
+// Package is the TraceKit browser SDK on npm
+const Package = "@tracekit/browser"
+
+// loaderURL serves the browser SDK for pages without a bundler entry module
+const loaderURL = "https://cdn.tracekit.dev/browser/v1/tracekit.min.js"
+
+// bundlers expose environment variables with a prefix to frontend code
+var bundlers = []struct {
+	pkg    string
+	prefix string // Variable name prefix
+	access string // Expression reading a variable, without its name
+}{
+	{"vite", "VITE_", "import.meta.env."},
+	{"react-scripts", "REACT_APP_", "process.env."},
+	{"@vue/cli-service", "VUE_APP_", "process.env."},
+}
+
+// entryModules are app entry points, most specific first
+var entryModules = []string{
+	"src/main.tsx", "src/main.ts", "src/main.jsx", "src/main.js",
+	"src/index.tsx", "src/index.jsx", "src/index.ts", "src/index.js",
+}
+
+// htmlPages are the page templates of Vite, Create React App, Vue CLI and Angular
+var htmlPages = []string{"index.html", "public/index.html", "src/index.html"}
+
+// Project is a browser app and what it offers to load the SDK from
+type Project struct {
+	Dir       string
+	EnvPrefix string // Prefix the bundler exposes .env variables with ("" if it doesn't)
+	envAccess string
+	Entry     string // Entry module, relative to Dir ("" if none was found)
+	HTML      string // index.html, relative to Dir ("" if none was found)
+}
+
+// Inspect finds the bundler, entry module and index.html of the app in dir
+func Inspect(dir string) (*Project, error) {
+	p := &Project{Dir: dir}
+
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package.json: %w", err)
+	}
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, fmt.Errorf("failed to parse package.json: %w", err)
+	}
+
+	for _, b := range bundlers {
+		_, dep := pkg.Dependencies[b.pkg]
+		_, devDep := pkg.DevDependencies[b.pkg]
+		if dep || devDep {
+			p.EnvPrefix, p.envAccess = b.prefix, b.access
+			break
+		}
+	}
+
+	for _, name := range entryModules {
+		if fileExists(filepath.Join(dir, name)) {
+			p.Entry = name
+			break
+		}
+	}
+	for _, name := range htmlPages {
+		if fileExists(filepath.Join(dir, name)) {
+			p.HTML = name
+			break
+		}
+	}
+
+	return p, nil
+}
+
+// Change is an edit to a single file
+type Change struct {
+	Path        string // Relative to the project directory
+	Description string
+	Before      string
+	After       string
+}
+
+// Apply writes the change
+func (p *Project) Apply(c *Change) error {
+	path := filepath.Join(p.Dir, filepath.FromSlash(c.Path))
+	if err := plan.WriteFile(c.Description, path, []byte(c.After), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", c.Path, err)
+	}
+	return nil
+}
+
+// Plan works out how to load the SDK. Apps whose bundler exposes .env
+// variables get an import in the entry module that reads the browser block;
+// others get the CDN loader in index.html with the public key inline. The
+// change's After equals Before when the SDK is already loaded.
+func (p *Project) Plan(browser *config.BrowserConfig) (*Change, error) {
+	switch {
+	case p.Entry != "" && p.EnvPrefix != "":
+		return p.edit(p.Entry, "import and start the TraceKit browser SDK", func(content string) (string, error) {
+			return injectEntry(content, p.EnvPrefix, p.envAccess), nil
+		})
+	case p.HTML != "":
+		return p.edit(p.HTML, "load the TraceKit browser SDK", func(content string) (string, error) {
+			return injectHTML(content, browser)
+		})
+	default:
+		return nil, fmt.Errorf("no index.html or entry module (src/main.*, src/index.*) found")
+	}
+}
+
+func (p *Project) edit(name, description string, fn func(string) (string, error)) (*Change, error) {
+	content, err := os.ReadFile(filepath.Join(p.Dir, filepath.FromSlash(name)))
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Change{Path: name, Description: description, Before: string(content), After: string(content)}
+	if strings.Contains(c.Before, Package) || strings.Contains(c.Before, loaderURL) {
+		return c, nil
+	}
+
+	if c.After, err = fn(c.Before); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return c, nil
+}
+
+// importEnd matches the line that ends an import statement
+var importEnd = regexp.MustCompile(`^import\s+['"]|\bfrom\s+['"][^'"]+['"]\s*;?\s*$`)
+
+// injectEntry imports the SDK first and starts it after the last import
+func injectEntry(content, prefix, access string) string {
+	lines := strings.Split(content, "\n")
+
+	last := -1
+	inImport := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "import ") || strings.HasPrefix(trimmed, "import{") {
+			inImport = true
+		}
+		if inImport && importEnd.MatchString(trimmed) {
+			last = i
+			inImport = false
+		}
+	}
+
+	env := access + prefix
+	start := []string{
+		"",
+		"initTraceKit({",
+		"  publicKey: " + env + "TRACEKIT_PUBLIC_KEY,",
+		"  endpoint: " + env + "TRACEKIT_ENDPOINT,",
+		"  serviceName: " + env + "TRACEKIT_SERVICE_NAME,",
+		"});",
+	}
+
+	out := []string{"import { init as initTraceKit } from '" + Package + "';"}
+	if last < 0 {
+		out = append(out, start[1:]...)
+		out = append(out, "")
+		return strings.Join(append(out, lines...), "\n")
+	}
+	out = append(out, lines[:last+1]...)
+	out = append(out, start...)
+	return strings.Join(append(out, lines[last+1:]...), "\n")
+}
+
+// headClose matches the closing head tag and its indentation
+var headClose = regexp.MustCompile(`(?im)^([ \t]*)</head>`)
+
+// injectHTML adds the CDN loader at the end of <head>
+func injectHTML(content string, browser *config.BrowserConfig) (string, error) {
+	m := headClose.FindStringSubmatchIndex(content)
+	if m == nil {
+		return "", fmt.Errorf("no </head> tag to add the loader to")
+	}
+	indent := content[m[2]:m[3]] + "  "
+
+	settings, _ := json.Marshal(map[string]string{
+		"publicKey":   browser.PublicKey,
+		"endpoint":    browser.Endpoint,
+		"serviceName": browser.ServiceName,
+	})
+	loader := indent + "<!-- TraceKit browser monitoring (public ingest key) -->\n" +
+		indent + `<script src="` + loaderURL + `" crossorigin="anonymous"></script>` + "\n" +
+		indent + "<script>TraceKit.init(" + string(settings) + ");</script>\n"
+
+	return content[:m[0]] + loader + content[m[0]:], nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,443 @@
+package sdk
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Catalog is the list of SDKs the CLI installs, with the setup snippets it
+// shows for them. The API serves a signed catalog so packages and snippets
+// can change without a CLI release; builtinCatalog is the fallback.
+type Catalog struct {
+	Schema   int   `json:"schema"`   // Format version; catalogs in another format are ignored
+	Revision int   `json:"revision"` // Increases with every published catalog
+	SDKs     []SDK `json:"sdks"`
+
+	// Oldest supported version of each framework, overriding the detector's
+	MinFrameworkVersions map[string]string `json:"min_framework_versions,omitempty"`
+
+	Source string `json:"-"` // "api", "cached" or "built-in"
+}
+
+// Snippet is one setup step: an instruction and the code it refers to
+type Snippet struct {
+	Description string `json:"description"`
+	Code        string `json:"code,omitempty"` // "" for a plain instruction
+}
+
+const (
+	// catalogSchema is the catalog format this CLI understands
+	catalogSchema = 1
+
+	// CatalogTTL is how long a fetched catalog is used before refetching
+	CatalogTTL = 24 * time.Hour
+)
+
+// catalogKeys are the Ed25519 public keys catalogs may be signed with, by
+// key ID. The private keys belong to the API's catalog publishing job and
+// never leave it. A key is rotated by adding its successor here under a new
+// ID (the month it was made) in a CLI release, switching the publisher to it
+// once that release is out, and dropping the old key a release later.
+var catalogKeys = map[string]string{
+	"2026-10": "bwXR8lThk0jy1QjgNhPG5jMIsDIoe3VX7TZS7p8eeew=",
+}
+
+// signedCatalog is the catalog as served by the API and kept in the cache
+type signedCatalog struct {
+	Catalog   string `json:"catalog"` // Base64 catalog JSON, signed byte for byte
+	KeyID     string `json:"key_id"`
+	Signature string `json:"signature"` // Base64 Ed25519 signature of the catalog JSON
+}
+
+// ErrUnverifiedCatalog is returned when a catalog's signature doesn't check
+// out against catalogKeys
+var ErrUnverifiedCatalog = errors.New("SDK catalog failed verification")
+
+// current is the catalog in use, nil until first needed
+var current *Catalog
+
+// Signed reports whether the catalog came from the API with a valid
+// signature, directly or through the cache
+func (c *Catalog) Signed() bool {
+	return c.Source == "api" || c.Source == "cached"
+}
+
+// CurrentCatalog returns the catalog in use: the last one loaded, else the
+// cached copy, else the built-in one
+func CurrentCatalog() *Catalog {
+	if current == nil {
+		if cached, _ := readCachedCatalog(); cached != nil {
+			current = cached
+		} else {
+			current = builtin()
+		}
+	}
+	return current
+}
+
+// LoadCatalog makes the newest catalog current. A cached catalog younger
+// than maxAge is used as is; otherwise fetch is called for a signed one from
+// the API, which is cached. When that fails, the cached (even if stale) or
+// built-in catalog is used and the error returned alongside it.
+func LoadCatalog(fetch func() ([]byte, error), maxAge time.Duration) (*Catalog, error) {
+	cached, age := readCachedCatalog()
+	if cached != nil && age < maxAge {
+		current = cached
+		return cached, nil
+	}
+
+	fallback := cached
+	if fallback == nil {
+		fallback = builtin()
+	}
+	current = fallback
+
+	data, err := fetch()
+	if err != nil {
+		return fallback, fmt.Errorf("failed to fetch SDK catalog: %w", err)
+	}
+	catalog, err := parseSignedCatalog(data)
+	if err != nil {
+		return fallback, err
+	}
+	// A replayed old catalog is signed too
+	if catalog.Revision < fallback.Revision {
+		return fallback, fmt.Errorf("SDK catalog revision %d is older than revision %d", catalog.Revision, fallback.Revision)
+	}
+
+	catalog.Source = "api"
+	if path := catalogCachePath(); path != "" && os.MkdirAll(filepath.Dir(path), 0755) == nil {
+		os.WriteFile(path, data, 0644)
+	}
+	current = catalog
+	return catalog, nil
+}
+
+// parseSignedCatalog verifies a signed catalog and decodes it
+func parseSignedCatalog(data []byte) (*Catalog, error) {
+	var signed signedCatalog
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return nil, fmt.Errorf("failed to parse SDK catalog: %w", err)
+	}
+
+	key, ok := catalogKeys[signed.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: signed with unknown key %q", ErrUnverifiedCatalog, signed.KeyID)
+	}
+	publicKey, _ := base64.StdEncoding.DecodeString(key)
+	payload, err := base64.StdEncoding.DecodeString(signed.Catalog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SDK catalog: %w", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(signed.Signature)
+	if err != nil || !ed25519.Verify(publicKey, payload, signature) {
+		return nil, fmt.Errorf("%w: invalid signature", ErrUnverifiedCatalog)
+	}
+
+	var catalog Catalog
+	if err := json.Unmarshal(payload, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse SDK catalog: %w", err)
+	}
+	if err := catalog.validate(); err != nil {
+		return nil, err
+	}
+	return &catalog, nil
+}
+
+// validate checks that the catalog is usable by this CLI
+func (c *Catalog) validate() error {
+	if c.Schema != catalogSchema {
+		return fmt.Errorf("SDK catalog format %d isn't supported by this CLI (upgrade it)", c.Schema)
+	}
+	if len(c.SDKs) == 0 {
+		return fmt.Errorf("SDK catalog is empty")
+	}
+	for i, s := range c.SDKs {
+		if s.Name == "" || s.Language == "" || s.PackageName == "" || s.InstallCmd == "" {
+			return fmt.Errorf("SDK catalog entry %d is incomplete", i+1)
+		}
+	}
+	return nil
+}
+
+// readCachedCatalog returns the cached catalog and its age, or nil when
+// there is no usable one
+func readCachedCatalog() (*Catalog, time.Duration) {
+	path := catalogCachePath()
+	if path == "" {
+		return nil, 0
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, 0
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0
+	}
+
+	catalog, err := parseSignedCatalog(data)
+	// The CLI may have been upgraded past the cached catalog
+	if err != nil || catalog.Revision < builtinCatalog.Revision {
+		return nil, 0
+	}
+	catalog.Source = "cached"
+	return catalog, time.Since(info.ModTime())
+}
+
+// catalogCachePath returns where the fetched catalog is cached, or "" when
+// there is no user cache directory
+func catalogCachePath() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(cacheDir, "tracekit", "sdk-catalog.json")
+}
+
+// builtin returns a copy of the catalog compiled into the CLI
+func builtin() *Catalog {
+	catalog := builtinCatalog
+	catalog.SDKs = slices.Clone(builtinCatalog.SDKs)
+	catalog.Source = "built-in"
+	return &catalog
+}
+
+// SetupSnippets returns the steps that set the SDK up in framework once it
+// is installed
+func (s SDK) SetupSnippets(framework string) []Snippet {
+	if setup, ok := s.FrameworkSetup[framework]; ok {
+		return setup
+	}
+	setup := slices.Clone(s.Setup)
+	if m, ok := s.Middleware[framework]; ok {
+		setup = append(setup, m)
+	}
+	return setup
+}
+
+// SetupSteps returns the SDK's setup snippets for framework as one step
+// each, for numbered lists
+func SetupSteps(sdk SDK, framework string) []string {
+	var steps []string
+	for _, snippet := range sdk.SetupSnippets(framework) {
+		switch {
+		case snippet.Code == "":
+			steps = append(steps, snippet.Description)
+		case !strings.Contains(snippet.Code, "\n"):
+			steps = append(steps, snippet.Description+": "+snippet.Code)
+		default:
+			// Indented under the step number
+			steps = append(steps, snippet.Description+":\n     "+strings.ReplaceAll(snippet.Code, "\n", "\n     "))
+		}
+	}
+	return steps
+}
+
+// otlpEnvSnippet explains how to point a standard OpenTelemetry exporter at
+// TraceKit
+var otlpEnvSnippet = Snippet{
+	Description: "Point the OpenTelemetry exporter at TraceKit",
+	Code: strings.Join([]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT=<your TRACEKIT_ENDPOINT>",
+		"OTEL_EXPORTER_OTLP_HEADERS=X-API-Key=<your TRACEKIT_API_KEY>",
+		"OTEL_EXPORTER_OTLP_PROTOCOL=http/protobuf",
+		"OTEL_SERVICE_NAME=<your TRACEKIT_SERVICE_NAME>",
+	}, "\n"),
+}
+
+// builtinCatalog is used until a catalog has been fetched, and whenever the
+// API can't be reached. It doesn't track releases, so its SDKs have no
+// Version: 'sdk outdated' reports them as unknown and 'sdk upgrade' asks
+// the package manager for the latest.
+var builtinCatalog = Catalog{
+	Schema:   catalogSchema,
+	Revision: 1,
+	SDKs: []SDK{
+		{
+			Name:        "PHP",
+			Language:    "php",
+			PackageName: "tracekit/php-apm",
+			InstallCmd:  "composer require tracekit/php-apm",
+			Description: "TraceKit PHP SDK (works with any PHP project)",
+			Setup: []Snippet{
+				{"Require in your code", "require 'vendor/autoload.php';"},
+				{"Initialize", `TraceKit\SDK::init();`},
+			},
+		},
+		{
+			Name:        "Laravel",
+			Language:    "php",
+			PackageName: "tracekit/laravel-apm",
+			InstallCmd:  "composer require tracekit/laravel-apm",
+			Description: "TraceKit Laravel SDK (optimized for Laravel)",
+			Frameworks:  []string{"laravel"},
+			Setup: []Snippet{
+				{"Laravel auto-discovery will register the service provider.", ""},
+				{"Publish config (optional)", "php artisan vendor:publish --tag=tracekit"},
+			},
+		},
+		{
+			Name:        "Node.js",
+			Language:    "node",
+			PackageName: "@tracekit/node-apm",
+			InstallCmd:  "npm install @tracekit/node-apm",
+			Description: "TraceKit Node.js SDK (Express, Fastify, etc.)",
+			Setup: []Snippet{
+				{"Import in your code", "const tracekit = require('@tracekit/node-apm');"},
+				{"Initialize", "tracekit.init();"},
+			},
+		},
+		{
+			Name:        "Browser (RUM)",
+			Language:    "node",
+			PackageName: browserPackage,
+			InstallCmd:  "npm install " + browserPackage,
+			Description: "TraceKit browser SDK for real user monitoring (React, Vue, Angular, Svelte, Vite)",
+			Frameworks:  []string{"angular", "react", "vue", "svelte", "vite"},
+			Setup: []Snippet{
+				{"Initialize in your app entry module with the public ingest key (never the API key)",
+					"import { init } from '@tracekit/browser';\ninit({ publicKey: '<TRACEKIT_PUBLIC_KEY>', serviceName: '<service>' });"},
+				{"Or run 'tracekit rum' to inject the loader for you.", ""},
+			},
+		},
+		{
+			Name:        "Go",
+			Language:    "go",
+			PackageName: "github.com/Tracekit-Dev/go-sdk",
+			InstallCmd:  "go get github.com/Tracekit-Dev/go-sdk",
+			Description: "TraceKit Go SDK (Gin, Echo, Fiber, chi, gorilla/mux, net/http, gRPC, Connect)",
+			Setup: []Snippet{
+				{"Import in your code", `import "github.com/Tracekit-Dev/go-sdk"`},
+				{"Initialize", "tracekit.Init()"},
+			},
+			Middleware: map[string]Snippet{
+				"gin":         {"Add the middleware to your Gin engine", "r.Use(tracekit.GinMiddleware())"},
+				"echo":        {"Add the middleware to your Echo instance", "e.Use(tracekit.EchoMiddleware())"},
+				"fiber":       {"Add the middleware to your Fiber app", "app.Use(tracekit.FiberMiddleware())"},
+				"chi":         {"Add the middleware to your chi router", "r.Use(tracekit.HTTPMiddleware)"},
+				"gorilla-mux": {"Add the middleware to your mux router", "r.Use(tracekit.HTTPMiddleware)"},
+				"net-http":    {"Wrap your handler", `http.ListenAndServe(":8080", tracekit.HTTPMiddleware(mux))`},
+				"grpc":        {"Register the stats handler on your gRPC server", "grpc.NewServer(grpc.StatsHandler(tracekit.GRPCServerHandler()))"},
+				"connect":     {"Add the interceptor to your Connect handlers", "api.NewServiceHandler(svc, connect.WithInterceptors(tracekit.ConnectInterceptor()))"},
+			},
+		},
+		{
+			Name:        "Python",
+			Language:    "python",
+			PackageName: "tracekit-python",
+			InstallCmd:  "pip install tracekit-python",
+			Description: "TraceKit Python SDK (Django, Flask, FastAPI)",
+			Setup: []Snippet{
+				{"Import in your code", "import tracekit"},
+				{"Initialize", "tracekit.init()"},
+			},
+		},
+		{
+			Name:        "Ruby",
+			Language:    "ruby",
+			PackageName: rubyGem,
+			InstallCmd:  "bundle add " + rubyGem,
+			Description: "TraceKit Ruby SDK (Rails, Sinatra, any Rack app)",
+			Setup: []Snippet{
+				{"Require in your code", `require "` + rubyGem + `"`},
+				{"Initialize", "TraceKit.init"},
+			},
+			FrameworkSetup: map[string][]Snippet{
+				"rails": {
+					{"Start the SDK in " + RailsInitializerPath + " ('tracekit init' creates it)",
+						"TraceKit.init\nRails.application.config.middleware.insert_before 0, " + RackMiddleware},
+				},
+			},
+			Middleware: map[string]Snippet{
+				"rails":   {"Add the middleware in " + RailsInitializerPath, "Rails.application.config.middleware.insert_before 0, " + RackMiddleware},
+				"sinatra": {"Add the middleware to your Sinatra app (or config.ru)", "use " + RackMiddleware},
+			},
+		},
+		{
+			Name:        "Java (OpenTelemetry)",
+			Language:    "java",
+			PackageName: "opentelemetry-javaagent",
+			InstallCmd:  "curl -sSLfo " + javaAgentJar + " " + javaAgentURL,
+			Description: "OpenTelemetry Java agent exporting to TraceKit (Spring Boot, Micronaut, any JVM app)",
+			Setup: []Snippet{
+				{"Start your app with the agent", "java -javaagent:" + javaAgentJar + " -jar app.jar"},
+				otlpEnvSnippet,
+			},
+		},
+		{
+			Name:        "Quarkus (OpenTelemetry)",
+			Language:    "java",
+			PackageName: quarkusExtension,
+			InstallCmd:  "./mvnw quarkus:add-extension -Dextensions=opentelemetry",
+			Description: "Quarkus OpenTelemetry extension exporting to TraceKit",
+			Frameworks:  []string{"quarkus"},
+			Setup: []Snippet{
+				{"Configure in application.properties", strings.Join([]string{
+					"quarkus.otel.exporter.otlp.traces.endpoint=${TRACEKIT_ENDPOINT}",
+					"quarkus.otel.exporter.otlp.traces.headers=X-API-Key=${TRACEKIT_API_KEY}",
+					"quarkus.otel.exporter.otlp.traces.protocol=http/protobuf",
+					"quarkus.application.name=${TRACEKIT_SERVICE_NAME}",
+				}, "\n")},
+			},
+		},
+		{
+			Name:          ".NET (OpenTelemetry)",
+			Language:      "dotnet",
+			PackageName:   "OpenTelemetry.Extensions.Hosting",
+			ExtraPackages: []string{"OpenTelemetry.Instrumentation.AspNetCore", "OpenTelemetry.Exporter.OpenTelemetryProtocol"},
+			InstallCmd:    "dotnet add package OpenTelemetry.Extensions.Hosting && dotnet add package OpenTelemetry.Instrumentation.AspNetCore && dotnet add package OpenTelemetry.Exporter.OpenTelemetryProtocol",
+			Description:   "OpenTelemetry .NET SDK exporting to TraceKit (ASP.NET Core)",
+			Setup: []Snippet{
+				{"Register in Program.cs", "builder.Services.AddOpenTelemetry()\n    .WithTracing(t => t.AddAspNetCoreInstrumentation().AddOtlpExporter());"},
+				otlpEnvSnippet,
+			},
+		},
+		{
+			Name:          "Rust (OpenTelemetry)",
+			Language:      "rust",
+			PackageName:   "opentelemetry-otlp",
+			ExtraPackages: []string{"opentelemetry", "opentelemetry_sdk", "tracing-opentelemetry"},
+			InstallCmd:    "cargo add opentelemetry-otlp opentelemetry opentelemetry_sdk tracing-opentelemetry",
+			Description:   "OpenTelemetry Rust SDK exporting to TraceKit (axum, actix-web, rocket)",
+			Setup: []Snippet{
+				{"Install a tracing layer in main.rs", strings.Join([]string{
+					"let exporter = opentelemetry_otlp::SpanExporter::builder().with_http().build()?;",
+					"let provider = opentelemetry_sdk::trace::SdkTracerProvider::builder()",
+					"    .with_batch_exporter(exporter).build();",
+					"tracing_subscriber::registry()",
+					"    .with(tracing_opentelemetry::layer().with_tracer(provider.tracer(\"app\")))",
+					"    .init();",
+				}, "\n")},
+				otlpEnvSnippet,
+			},
+		},
+		{
+			Name:          "Elixir (OpenTelemetry)",
+			Language:      "elixir",
+			PackageName:   "opentelemetry_exporter",
+			ExtraPackages: []string{"opentelemetry", "opentelemetry_api", "opentelemetry_phoenix"},
+			InstallCmd:    "mix deps.get",
+			Description:   "OpenTelemetry Erlang/Elixir SDK exporting to TraceKit (Phoenix)",
+			Setup: []Snippet{
+				{"Add to deps in mix.exs (before mix deps.get)", strings.Join([]string{
+					`{:opentelemetry_exporter, "~> 1.6"},`,
+					`{:opentelemetry, "~> 1.3"},`,
+					`{:opentelemetry_api, "~> 1.2"},`,
+					`{:opentelemetry_phoenix, "~> 1.2"}`,
+				}, "\n")},
+				{"Set up in lib/my_app/application.ex", "OpentelemetryPhoenix.setup()"},
+				{"Configure in config/runtime.exs", "config :opentelemetry_exporter, otlp_protocol: :http_protobuf"},
+				otlpEnvSnippet,
+			},
+		},
+	},
+}
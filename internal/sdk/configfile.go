@@ -0,0 +1,286 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yourusername/context.io/cli/internal/plan"
+)
+
+// ConfigFile is a framework configuration file for the SDK. It reads the
+// TRACEKIT_* variables config.Save writes to .env and holds the settings
+// that don't belong there: sampling, ignored routes and PII scrubbing.
+type ConfigFile struct {
+	Path        string // Relative to the project directory
+	Description string // What the file is for
+	Usage       string // How the app loads it ("" when the framework does)
+	Content     string
+}
+
+// Routes that are never worth tracing, and request fields (parameters,
+// headers, form and JSON keys) whose values are scrubbed from traces
+var (
+	ignoredRoutes = []string{"/health", "/healthz", "/ready", "/metrics", "/favicon.ico"}
+	scrubFields   = []string{"password", "passwd", "secret", "token", "api_key", "authorization", "cookie", "set-cookie", "credit_card", "ssn"}
+)
+
+// ConfigFiles returns the configuration files for sdk in target's framework,
+// or nil when it has none
+func ConfigFiles(sdk SDK, target Target) []ConfigFile {
+	switch {
+	case target.Framework == "laravel":
+		return []ConfigFile{{
+			Path:        "config/tracekit.php",
+			Description: "configure the TraceKit SDK",
+			Content:     laravelConfig(),
+		}}
+	case target.Framework == "django":
+		return []ConfigFile{{
+			Path:        "tracekit_settings.py",
+			Description: "configure the TraceKit SDK",
+			Usage:       "Import it at the end of settings.py: from tracekit_settings import TRACEKIT  # noqa",
+			Content:     djangoConfig(),
+		}}
+	case sdk.Language == "node" && sdk.PackageName != browserPackage:
+		usage := "Pass it to init: tracekit.init(require('./tracekit.config'))"
+		if isESModule(target.Dir) {
+			usage = "Pass it to init: import config from './tracekit.config.js'; tracekit.init(config)"
+		}
+		return []ConfigFile{{
+			Path:        "tracekit.config.js",
+			Description: "configure the TraceKit SDK",
+			Usage:       usage,
+			Content:     nodeConfig(isESModule(target.Dir)),
+		}}
+	case sdk.Language == "go":
+		return []ConfigFile{{
+			Path:        "tracekitconfig/config.go",
+			Description: "load the TraceKit SDK configuration",
+			Usage:       "Load it with tracekitconfig.Load() and pass the fields to the SDK",
+			Content:     goConfig(),
+		}}
+	}
+	return nil
+}
+
+// WriteConfigFiles writes sdk's configuration files into target, leaving
+// existing files alone, and returns the ones it wrote
+func WriteConfigFiles(sdk SDK, target Target) ([]ConfigFile, error) {
+	var written []ConfigFile
+	for _, file := range ConfigFiles(sdk, target) {
+		path := filepath.Join(target.Dir, filepath.FromSlash(file.Path))
+		if fileExists(path) {
+			continue
+		}
+		if err := plan.WriteFile(file.Description, path, []byte(file.Content), 0644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", file.Path, err)
+		}
+		written = append(written, file)
+	}
+	return written, nil
+}
+
+// isESModule reports whether the Node.js project in dir loads .js files as
+// ES modules
+func isESModule(dir string) bool {
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return false
+	}
+	var pkg struct {
+		Type string `json:"type"`
+	}
+	return json.Unmarshal(content, &pkg) == nil && pkg.Type == "module"
+}
+
+// quoted renders values as a comma-separated list of quoted strings
+func quoted(values []string, quote string) string {
+	items := make([]string, len(values))
+	for i, v := range values {
+		items[i] = quote + v + quote
+	}
+	return strings.Join(items, ", ")
+}
+
+func laravelConfig() string {
+	return `<?php
+
+// TraceKit APM configuration. Connection settings come from .env, where
+// 'tracekit init' writes them; tune the rest here.
+
+return [
+
+    // Set TRACEKIT_ENABLED=false to stop tracing without removing the SDK
+    'enabled' => env('TRACEKIT_ENABLED', true),
+
+    'api_key' => env('TRACEKIT_API_KEY'),
+    'endpoint' => env('TRACEKIT_ENDPOINT', 'https://app.tracekit.dev/v1/traces'),
+    'service_name' => env('TRACEKIT_SERVICE_NAME', env('APP_NAME', 'laravel')),
+
+    // Shown on every trace, so staging and production can be told apart
+    'environment' => env('TRACEKIT_ENVIRONMENT', env('APP_ENV', 'production')),
+
+    // Capture variable snapshots at code monitoring breakpoints
+    'code_monitoring_enabled' => env('TRACEKIT_CODE_MONITORING_ENABLED', true),
+
+    // Fraction of requests to trace, from 0.0 (none) to 1.0 (all)
+    'sample_rate' => (float) env('TRACEKIT_SAMPLE_RATE', 1.0),
+
+    // Requests to these paths are never traced (health checks, metrics scrapes)
+    'ignored_routes' => [` + quoted(ignoredRoutes, "'") + `],
+
+    // Values of request parameters, headers and body fields with these names
+    // are replaced with [redacted] before traces leave the app
+    'scrub_pii' => true,
+    'scrub_fields' => [` + quoted(scrubFields, "'") + `],
+
+];
+`
+}
+
+func djangoConfig() string {
+	return `"""TraceKit APM configuration.
+
+Connection settings come from .env, where 'tracekit init' writes them; tune
+the rest here. Import TRACEKIT from settings.py to apply it.
+"""
+
+import os
+
+
+def _env_bool(name, default):
+    return os.environ.get(name, str(default)).lower() in ("1", "true", "yes")
+
+
+TRACEKIT = {
+    # Set TRACEKIT_ENABLED=false to stop tracing without removing the SDK
+    "ENABLED": _env_bool("TRACEKIT_ENABLED", True),
+    "API_KEY": os.environ.get("TRACEKIT_API_KEY"),
+    "ENDPOINT": os.environ.get("TRACEKIT_ENDPOINT", "https://app.tracekit.dev/v1/traces"),
+    "SERVICE_NAME": os.environ.get("TRACEKIT_SERVICE_NAME", "django"),
+    # Shown on every trace, so staging and production can be told apart
+    "ENVIRONMENT": os.environ.get("TRACEKIT_ENVIRONMENT", "production"),
+    # Capture variable snapshots at code monitoring breakpoints
+    "CODE_MONITORING_ENABLED": _env_bool("TRACEKIT_CODE_MONITORING_ENABLED", True),
+    # Fraction of requests to trace, from 0.0 (none) to 1.0 (all)
+    "SAMPLE_RATE": float(os.environ.get("TRACEKIT_SAMPLE_RATE", "1.0")),
+    # Requests to these paths are never traced (health checks, metrics scrapes)
+    "IGNORED_ROUTES": [` + quoted(ignoredRoutes, `"`) + `],
+    # Values of request parameters, headers and body fields with these names
+    # are replaced with [redacted] before traces leave the app
+    "SCRUB_PII": True,
+    "SCRUB_FIELDS": [` + quoted(scrubFields, `"`) + `],
+}
+`
+}
+
+func nodeConfig(esm bool) string {
+	export := "module.exports = {"
+	if esm {
+		export = "export default {"
+	}
+	return `// TraceKit APM configuration. Connection settings come from .env, where
+// 'tracekit init' writes them; tune the rest here.
+
+const env = process.env;
+
+` + export + `
+  // Set TRACEKIT_ENABLED=false to stop tracing without removing the SDK
+  enabled: env.TRACEKIT_ENABLED !== 'false',
+  apiKey: env.TRACEKIT_API_KEY,
+  endpoint: env.TRACEKIT_ENDPOINT || 'https://app.tracekit.dev/v1/traces',
+  serviceName: env.TRACEKIT_SERVICE_NAME,
+
+  // Shown on every trace, so staging and production can be told apart
+  environment: env.TRACEKIT_ENVIRONMENT || env.NODE_ENV || 'production',
+
+  // Capture variable snapshots at code monitoring breakpoints
+  codeMonitoringEnabled: env.TRACEKIT_CODE_MONITORING_ENABLED !== 'false',
+
+  // Fraction of requests to trace, from 0.0 (none) to 1.0 (all)
+  sampleRate: Number(env.TRACEKIT_SAMPLE_RATE || 1.0),
+
+  // Requests to these paths are never traced (health checks, metrics scrapes)
+  ignoredRoutes: [` + quoted(ignoredRoutes, "'") + `],
+
+  // Values of request parameters, headers and body fields with these names
+  // are replaced with [redacted] before traces leave the app
+  scrubPII: true,
+  scrubFields: [` + quoted(scrubFields, "'") + `],
+};
+`
+}
+
+func goConfig() string {
+	return `// Package tracekitconfig loads the TraceKit APM configuration. Connection
+// settings come from the environment (.env, where 'tracekit init' writes
+// them); tune the rest here.
+package tracekitconfig
+
+import (
+	"os"
+	"strconv"
+)
+
+// Config holds the settings passed to the TraceKit SDK
+type Config struct {
+	Enabled               bool // Set TRACEKIT_ENABLED=false to stop tracing without removing the SDK
+	APIKey                string
+	Endpoint              string
+	ServiceName           string
+	Environment           string // Shown on every trace, so staging and production can be told apart
+	CodeMonitoringEnabled bool   // Capture variable snapshots at code monitoring breakpoints
+
+	// Fraction of requests to trace, from 0.0 (none) to 1.0 (all)
+	SampleRate float64
+
+	// Requests to these paths are never traced (health checks, metrics scrapes)
+	IgnoredRoutes []string
+
+	// Values of request parameters, headers and body fields with these names
+	// are replaced with [redacted] before traces leave the service
+	ScrubPII    bool
+	ScrubFields []string
+}
+
+// Load reads the configuration from TRACEKIT_* environment variables
+func Load() Config {
+	return Config{
+		Enabled:               envBool("TRACEKIT_ENABLED", true),
+		APIKey:                os.Getenv("TRACEKIT_API_KEY"),
+		Endpoint:              envString("TRACEKIT_ENDPOINT", "https://app.tracekit.dev/v1/traces"),
+		ServiceName:           os.Getenv("TRACEKIT_SERVICE_NAME"),
+		Environment:           envString("TRACEKIT_ENVIRONMENT", "production"),
+		CodeMonitoringEnabled: envBool("TRACEKIT_CODE_MONITORING_ENABLED", true),
+		SampleRate:            envFloat("TRACEKIT_SAMPLE_RATE", 1.0),
+		IgnoredRoutes:         []string{` + quoted(ignoredRoutes, `"`) + `},
+		ScrubPII:              true,
+		ScrubFields:           []string{` + quoted(scrubFields, `"`) + `},
+	}
+}
+
+func envString(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func envBool(name string, fallback bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(name)); err == nil {
+		return value
+	}
+	return fallback
+}
+
+func envFloat(name string, fallback float64) float64 {
+	if value, err := strconv.ParseFloat(os.Getenv(name), 64); err == nil {
+		return value
+	}
+	return fallback
+}
+`
+}
@@ -0,0 +1,97 @@
+package sdk
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Diagnosis explains a known installation failure and how to fix it
+type Diagnosis struct {
+	Problem string
+	Fixes   []string
+}
+
+var (
+	// phpMissingExtension matches composer's platform check, e.g.
+	// "requires ext-sockets * -> it is missing from your system"
+	phpMissingExtension = regexp.MustCompile(`requires (ext-([\w-]+)) .*-> it is missing from your system`)
+	// goModuleName matches the module go failed to fetch
+	goModuleName = regexp.MustCompile(`(?m)^go: ([^\s@:]+)@?\S*: `)
+)
+
+// diagnose classifies the output of a failed installation of sdk, or returns
+// nil for failures it doesn't recognize
+func diagnose(sdk SDK, output string) *Diagnosis {
+	switch sdk.Language {
+	case "php":
+		if m := phpMissingExtension.FindStringSubmatch(output); m != nil {
+			return &Diagnosis{
+				Problem: "PHP extension " + m[2] + " is missing",
+				Fixes: []string{
+					"Install it with your system's package manager, e.g.: sudo apt install php-" + m[2] + " or pecl install " + m[2],
+					"Then enable it in php.ini ('php --ini' shows which file is loaded) and check with: php -m",
+					"If only this machine lacks it, skip the check: " + sdk.InstallCmd + " --ignore-platform-req=" + m[1],
+				},
+			}
+		}
+
+	case "node":
+		if strings.Contains(output, "ERESOLVE") {
+			return &Diagnosis{
+				Problem: "npm found conflicting peer dependencies in the project",
+				Fixes: []string{
+					"The conflicting packages are listed under 'Could not resolve dependency' in the log",
+					"Install without the strict peer check: " + sdk.InstallCmd + " --legacy-peer-deps",
+					"Or upgrade the package with the outdated peer range, then retry",
+				},
+			}
+		}
+
+	case "python":
+		if strings.Contains(output, "externally-managed-environment") {
+			return &Diagnosis{
+				Problem: "This Python is managed by the operating system, so pip won't install into it (PEP 668)",
+				Fixes: []string{
+					"Install into a virtual environment: python3 -m venv .venv && . .venv/bin/activate && pip install " + sdk.PackageName,
+					"Or use the project's tool if it has one: poetry add / uv add / pipenv install " + sdk.PackageName,
+				},
+			}
+		}
+
+	case "go":
+		module := sdk.PackageName
+		if m := goModuleName.FindStringSubmatch(output); m != nil {
+			module = m[1]
+		}
+		switch {
+		case strings.Contains(output, "SECURITY ERROR") || strings.Contains(output, "checksum mismatch"):
+			return &Diagnosis{
+				Problem: "The checksum of " + module + " doesn't match the checksum database",
+				Fixes: []string{
+					"Clear the module cache and retry: go clean -modcache",
+					"If go.sum was edited by hand, remove its lines for " + module,
+				},
+			}
+		case strings.Contains(output, "410 Gone") || strings.Contains(output, "404 Not Found") ||
+			strings.Contains(output, "terminal prompts disabled"):
+			return &Diagnosis{
+				Problem: "The Go module proxy couldn't serve " + module,
+				Fixes: []string{
+					"For private modules, bypass the proxy: go env -w GOPRIVATE=" + module,
+					"Or fetch straight from the source: GOPROXY=direct " + sdk.InstallCmd,
+				},
+			}
+		case strings.Contains(output, "proxy.golang.org") || strings.Contains(output, "dial tcp") ||
+			strings.Contains(output, "i/o timeout") || strings.Contains(output, "no such host"):
+			return &Diagnosis{
+				Problem: "The Go module proxy can't be reached",
+				Fixes: []string{
+					"Check your network connection and any HTTPS_PROXY setting",
+					"Use a proxy you can reach: go env -w GOPROXY=https://goproxy.io,direct",
+					"Or fetch straight from the source: GOPROXY=direct " + sdk.InstallCmd,
+				},
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package sdk
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/yourusername/context.io/cli/internal/plan"
+)
+
+// command is a package manager invocation
+type command struct {
+	args []string
+	dir  string
+	env  []string // Added to the environment
+}
+
+func (c command) String() string {
+	line := strings.Join(c.args, " ")
+	if len(c.env) > 0 {
+		line = strings.Join(c.env, " ") + " " + line
+	}
+	return line
+}
+
+// AgentPath returns the file InstallTarget downloads sdk's agent to in dir,
+// or "" for SDKs installed as a dependency
+func AgentPath(sdk SDK, dir string) string {
+	if sdk.Language == "java" && sdk.PackageName != quarkusExtension {
+		return filepath.Join(dir, javaAgentJar)
+	}
+	return ""
+}
+
+// Upgrade moves the SDK in target to version ("" for the latest release).
+// Like InstallTarget, it streams output to out and returns the log path.
+func Upgrade(sdk SDK, target Target, version string, out io.Writer) (string, error) {
+	commands, err := upgradeCommands(sdk, target, version)
+	if err != nil {
+		return "", err
+	}
+	return runCommands(sdk, "upgrade", commands, out)
+}
+
+// Uninstall removes the SDK from target, along with the Rails initializer
+// and framework configuration files if they are unchanged since init
+// created them
+func Uninstall(sdk SDK, target Target, out io.Writer) (string, error) {
+	if agent := AgentPath(sdk, target.Dir); agent != "" {
+		if err := plan.Remove("uninstall "+sdk.Name, agent); err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to remove %s: %w", javaAgentJar, err)
+		}
+		return "", nil
+	}
+
+	commands, err := uninstallCommands(sdk, target)
+	if err != nil {
+		return "", err
+	}
+	logPath, err := runCommands(sdk, "uninstall", commands, out)
+	if err != nil {
+		return logPath, err
+	}
+
+	if sdk.Language == "ruby" {
+		initializer := filepath.Join(target.Dir, filepath.FromSlash(RailsInitializerPath))
+		if content, err := os.ReadFile(initializer); err == nil && string(content) == RailsInitializer {
+			plan.Remove("uninstall "+sdk.Name, initializer)
+		}
+	}
+	for _, file := range ConfigFiles(sdk, target) {
+		path := filepath.Join(target.Dir, filepath.FromSlash(file.Path))
+		if content, err := os.ReadFile(path); err == nil && string(content) == file.Content {
+			plan.Remove("uninstall "+sdk.Name, path)
+		}
+	}
+	return logPath, nil
+}
+
+// UpgradeCommand returns the command line that upgrades sdk in target
+func UpgradeCommand(sdk SDK, target Target, version string) (string, error) {
+	commands, err := upgradeCommands(sdk, target, version)
+	return joinCommands(commands), err
+}
+
+// UninstallCommand returns the command line that removes sdk from target
+func UninstallCommand(sdk SDK, target Target) (string, error) {
+	if agent := AgentPath(sdk, target.Dir); agent != "" {
+		return "rm " + javaAgentJar, nil
+	}
+	commands, err := uninstallCommands(sdk, target)
+	return joinCommands(commands), err
+}
+
+func joinCommands(commands []command) string {
+	var lines []string
+	for _, c := range commands {
+		lines = append(lines, c.String())
+	}
+	return strings.Join(lines, " && ")
+}
+
+// runCommands runs commands in order, logging them like an installation
+func runCommands(sdk SDK, action string, commands []command, out io.Writer) (string, error) {
+	r := newInstallRun(sdk, action, out)
+	defer r.close()
+
+	for _, c := range commands {
+		cmd := exec.Command(c.args[0], c.args[1:]...)
+		cmd.Dir = c.dir
+		if len(c.env) > 0 {
+			cmd.Env = append(os.Environ(), c.env...)
+		}
+		if err := r.run(cmd); err != nil {
+			return r.logPath(), err
+		}
+	}
+	return r.logPath(), nil
+}
+
+// goCommand runs go in the module of target; go get doesn't support
+// workspace mode, so workspace members are changed directly
+func goCommand(target Target, args ...string) command {
+	c := command{args: append([]string{"go"}, args...), dir: target.Dir}
+	if target.WorkspaceRoot != "" {
+		c.dir = filepath.Join(target.WorkspaceRoot, filepath.FromSlash(target.WorkspaceMember))
+		c.env = []string{"GOWORK=off"}
+	}
+	return c
+}
+
+// pipCommand returns pip, or pip3 when only that is installed
+func pipCommand() string {
+	if !commandExists("pip") && commandExists("pip3") {
+		return "pip3"
+	}
+	return "pip"
+}
+
+func upgradeCommands(sdk SDK, target Target, version string) ([]command, error) {
+	dir := target.Dir
+	single := func(args ...string) []command {
+		return []command{{args: args, dir: dir}}
+	}
+
+	switch sdk.Language {
+	case "php":
+		spec := sdk.PackageName
+		if version != "" {
+			spec += ":^" + version
+		}
+		return single("composer", "require", spec, "--with-all-dependencies"), nil
+
+	case "node":
+		spec := sdk.PackageName + "@latest"
+		if version != "" {
+			spec = sdk.PackageName + "@" + version
+		}
+		args, runDir := nodeInstallArgs(spec, target)
+		return []command{{args: args, dir: runDir}}, nil
+
+	case "go":
+		spec := sdk.PackageName + "@latest"
+		if version != "" {
+			spec = sdk.PackageName + "@v" + strings.TrimPrefix(version, "v")
+		}
+		return []command{goCommand(target, "get", spec)}, nil
+
+	case "python":
+		spec := sdk.PackageName
+		if version != "" {
+			spec += ">=" + version
+		}
+		switch target.PackageManager {
+		case "poetry":
+			if version == "" {
+				spec += "@latest"
+			}
+			return single("poetry", "add", spec), nil
+		case "uv":
+			return single("uv", "add", "--upgrade-package", sdk.PackageName, spec), nil
+		case "pipenv":
+			return single("pipenv", "install", spec), nil
+		default:
+			return single(pipCommand(), "install", "--upgrade", spec), nil
+		}
+
+	case "ruby":
+		// The Gemfile constraint bundle add wrote still applies
+		return single("bundle", "update", sdk.PackageName), nil
+
+	case "java":
+		if sdk.PackageName == quarkusExtension {
+			return nil, fmt.Errorf("the Quarkus extension follows the Quarkus platform version; upgrade that instead")
+		}
+		// The agent URL always serves the latest release
+		return single("curl", "-sSLfo", javaAgentJar, javaAgentURL), nil
+
+	case "dotnet":
+		var commands []command
+		for i, pkg := range sdk.packages() {
+			args := []string{"dotnet", "add", "package", pkg}
+			if i == 0 && version != "" {
+				args = append(args, "--version", version)
+			}
+			commands = append(commands, command{args: args, dir: dir})
+		}
+		return commands, nil
+
+	case "rust":
+		pkgs := sdk.packages()
+		if version != "" {
+			pkgs[0] += "@" + version
+		}
+		return single(append([]string{"cargo", "add"}, pkgs...)...), nil
+
+	default:
+		return nil, fmt.Errorf("%s can't be upgraded automatically - %s", sdk.Name, sdk.InstallCmd)
+	}
+}
+
+func uninstallCommands(sdk SDK, target Target) ([]command, error) {
+	dir := target.Dir
+	single := func(args ...string) []command {
+		return []command{{args: args, dir: dir}}
+	}
+
+	switch sdk.Language {
+	case "php":
+		return single("composer", "remove", sdk.PackageName), nil
+
+	case "node":
+		args, runDir := nodeInstallArgs(sdk.PackageName, target)
+		// Every package manager removes with the flags it adds with
+		if args[0] == "npm" {
+			args[1] = "uninstall"
+		} else {
+			for i, arg := range args {
+				if arg == "add" {
+					args[i] = "remove"
+					break
+				}
+			}
+		}
+		return []command{{args: args, dir: runDir}}, nil
+
+	case "go":
+		return []command{
+			goCommand(target, "get", sdk.PackageName+"@none"),
+			goCommand(target, "mod", "tidy"),
+		}, nil
+
+	case "python":
+		switch target.PackageManager {
+		case "poetry", "uv":
+			return single(target.PackageManager, "remove", sdk.PackageName), nil
+		case "pipenv":
+			return single("pipenv", "uninstall", sdk.PackageName), nil
+		default:
+			return single(pipCommand(), "uninstall", "-y", sdk.PackageName), nil
+		}
+
+	case "ruby":
+		return single("bundle", "remove", sdk.PackageName), nil
+
+	case "java":
+		if target.PackageManager == "gradle" {
+			return single("./gradlew", "removeExtension", "--extensions=opentelemetry"), nil
+		}
+		return single("./mvnw", "quarkus:remove-extension", "-Dextensions=opentelemetry"), nil
+
+	case "dotnet":
+		var commands []command
+		for _, pkg := range sdk.packages() {
+			commands = append(commands, command{args: []string{"dotnet", "remove", "package", pkg}, dir: dir})
+		}
+		return commands, nil
+
+	case "rust":
+		return single(append([]string{"cargo", "remove"}, sdk.packages()...)...), nil
+
+	default:
+		return nil, fmt.Errorf("%s can't be removed automatically - remove %s from your dependencies", sdk.Name, sdk.PackageName)
+	}
+}
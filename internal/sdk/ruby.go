@@ -0,0 +1,40 @@
+package sdk
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/yourusername/context.io/cli/internal/plan"
+)
+
+const (
+	rubyGem = "tracekit-ruby"
+
+	// RackMiddleware traces requests in Rails, Sinatra and other Rack apps
+	RackMiddleware = "TraceKit::Rack::Middleware"
+
+	// RailsInitializerPath is where Rails apps start the Ruby SDK
+	RailsInitializerPath = "config/initializers/tracekit.rb"
+)
+
+// RailsInitializer starts the Ruby SDK and puts its middleware at the top of
+// the Rack stack so it times the whole request
+const RailsInitializer = `# TraceKit APM. The SDK reads TRACEKIT_API_KEY, TRACEKIT_ENDPOINT and
+# TRACEKIT_SERVICE_NAME from the environment (see .env).
+TraceKit.init
+
+Rails.application.config.middleware.insert_before 0, ` + RackMiddleware + `
+`
+
+// writeRailsInitializer creates the initializer in the Rails app in dir,
+// leaving an existing one alone
+func writeRailsInitializer(dir string) error {
+	path := filepath.Join(dir, filepath.FromSlash(RailsInitializerPath))
+	if fileExists(path) {
+		return nil
+	}
+	if err := plan.WriteFile("start the SDK in Rails", path, []byte(RailsInitializer), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", RailsInitializerPath, err)
+	}
+	return nil
+}
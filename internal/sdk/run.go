@@ -0,0 +1,124 @@
+package sdk
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yourusername/context.io/cli/internal/plan"
+)
+
+// InstallError is a failed installation command, with where its output was
+// logged and what likely went wrong
+type InstallError struct {
+	Command   string
+	Err       error
+	LogPath   string     // Full output of the installation ("" if it couldn't be saved)
+	Diagnosis *Diagnosis // nil when the failure isn't a known one
+}
+
+func (e *InstallError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Command, e.Err)
+}
+
+func (e *InstallError) Unwrap() error {
+	return e.Err
+}
+
+// LogDir returns the directory installation, upgrade and uninstall logs are saved in, or an empty
+// string when there is no user cache directory
+func LogDir() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(cacheDir, "tracekit", "logs")
+}
+
+// installRun runs the commands of one installation, streaming their output
+// to out and saving it to a log file
+type installRun struct {
+	sdk    SDK
+	action string
+	out    io.Writer
+	log    *os.File // nil when the log couldn't be created
+	output bytes.Buffer
+}
+
+// newInstallRun logs to a file named after action ("install", "upgrade", ...)
+func newInstallRun(sdk SDK, action string, out io.Writer) *installRun {
+	if out == nil {
+		out = io.Discard
+	}
+	r := &installRun{sdk: sdk, action: action, out: out}
+	if plan.DryRun() {
+		return r
+	}
+
+	if dir := LogDir(); dir != "" && os.MkdirAll(dir, 0755) == nil {
+		pattern := fmt.Sprintf("%s-%s-%s-*.log", action, time.Now().Format("20060102-150405"), sdk.Language)
+		r.log, _ = os.CreateTemp(dir, pattern)
+	}
+	return r
+}
+
+// logPath returns the path of the log file, if there is one
+func (r *installRun) logPath() string {
+	if r.log == nil {
+		return ""
+	}
+	return r.log.Name()
+}
+
+func (r *installRun) close() {
+	if r.log != nil {
+		r.log.Close()
+	}
+}
+
+// run runs cmd, failing with an InstallError that diagnoses its output
+func (r *installRun) run(cmd *exec.Cmd) error {
+	command := strings.Join(cmd.Args, " ")
+
+	// Added to the environment (GOWORK=off)
+	var env []string
+	if cmd.Env != nil {
+		env = cmd.Env[min(len(cmd.Env), len(os.Environ())):]
+	}
+	if plan.Command(r.action+" "+r.sdk.Name, cmd.Dir, cmd.Args, env) {
+		return nil
+	}
+
+	writers := []io.Writer{&r.output, r.out}
+	if r.log != nil {
+		fmt.Fprintf(r.log, "$ %s\n", command)
+		writers = append(writers, r.log)
+	}
+	// The same writer for both streams keeps their lines in order
+	w := io.MultiWriter(writers...)
+	cmd.Stdout = w
+	cmd.Stderr = w
+
+	err := cmd.Run()
+	if r.log != nil {
+		if err != nil {
+			fmt.Fprintf(r.log, "# failed: %v\n\n", err)
+		} else {
+			fmt.Fprintln(r.log)
+		}
+	}
+	if err != nil {
+		return &InstallError{
+			Command:   command,
+			Err:       err,
+			LogPath:   r.logPath(),
+			Diagnosis: diagnose(r.sdk, r.output.String()),
+		}
+	}
+	return nil
+}
@@ -2,70 +2,74 @@ package sdk
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/yourusername/context.io/cli/internal/detector"
+	"github.com/yourusername/context.io/cli/internal/plan"
+	"github.com/yourusername/context.io/cli/internal/state"
 )
 
-// SDK represents an SDK installation option
+// SDK represents an SDK installation option, as listed in the catalog
 type SDK struct {
-	Name        string
-	Language    string
-	PackageName string
-	InstallCmd  string
-	Description string
+	Name          string   `json:"name"`
+	Language      string   `json:"language"`
+	PackageName   string   `json:"package"`
+	ExtraPackages []string `json:"extra_packages,omitempty"` // Installed alongside PackageName (OpenTelemetry setups)
+	InstallCmd    string   `json:"install_command"`
+	Description   string   `json:"description"`
+	Version       string   `json:"version,omitempty"`    // Latest release ("" when not tracked)
+	Frameworks    []string `json:"frameworks,omitempty"` // Recommended over the language default for these
+
+	Setup          []Snippet            `json:"setup,omitempty"`           // Steps after installing
+	FrameworkSetup map[string][]Snippet `json:"framework_setup,omitempty"` // Replaces Setup and Middleware for a framework
+	Middleware     map[string]Snippet   `json:"middleware,omitempty"`      // Hooks the SDK into a framework's requests
 }
 
-// GetAvailableSDKs returns list of all available SDKs
+const (
+	// javaAgentURL is the latest OpenTelemetry Java agent release
+	javaAgentURL = "https://github.com/open-telemetry/opentelemetry-java-instrumentation/releases/latest/download/opentelemetry-javaagent.jar"
+	javaAgentJar = "opentelemetry-javaagent.jar"
+
+	quarkusExtension = "io.quarkus:quarkus-opentelemetry"
+
+	browserPackage = "@tracekit/browser"
+)
+
+// GetAvailableSDKs returns the SDKs in the current catalog
 func GetAvailableSDKs() []SDK {
-	return []SDK{
-		{
-			Name:        "PHP",
-			Language:    "php",
-			PackageName: "tracekit/php-apm",
-			InstallCmd:  "composer require tracekit/php-apm",
-			Description: "TraceKit PHP SDK (works with any PHP project)",
-		},
-		{
-			Name:        "Laravel",
-			Language:    "php",
-			PackageName: "tracekit/laravel-apm",
-			InstallCmd:  "composer require tracekit/laravel-apm",
-			Description: "TraceKit Laravel SDK (optimized for Laravel)",
-		},
-		{
-			Name:        "Node.js",
-			Language:    "node",
-			PackageName: "@tracekit/node-apm",
-			InstallCmd:  "npm install @tracekit/node-apm",
-			Description: "TraceKit Node.js SDK (Express, Fastify, etc.)",
-		},
-		{
-			Name:        "Go",
-			Language:    "go",
-			PackageName: "github.com/Tracekit-Dev/go-sdk",
-			InstallCmd:  "go get github.com/Tracekit-Dev/go-sdk",
-			Description: "TraceKit Go SDK (Gin, Echo, Fiber, net/http)",
-		},
-		{
-			Name:        "Python",
-			Language:    "python",
-			PackageName: "tracekit-python",
-			InstallCmd:  "pip install tracekit-python",
-			Description: "TraceKit Python SDK (Django, Flask, FastAPI)",
-		},
+	return slices.Clone(CurrentCatalog().SDKs)
+}
+
+// packages returns PackageName followed by ExtraPackages
+func (s SDK) packages() []string {
+	return append([]string{s.PackageName}, s.ExtraPackages...)
+}
+
+// MiddlewareStep returns a one-line instruction for adding the TraceKit
+// middleware to framework, or "" when the SDK has no framework middleware
+func MiddlewareStep(framework string) string {
+	for _, s := range CurrentCatalog().SDKs {
+		if m, ok := s.Middleware[framework]; ok {
+			return m.Description + ": " + m.Code
+		}
 	}
+	return ""
 }
 
 // GetRecommendedSDK returns the recommended SDK based on framework type
 func GetRecommendedSDK(frameworkType, frameworkName string) *SDK {
 	sdks := GetAvailableSDKs()
 
-	// Special case for Laravel
-	if frameworkName == "laravel" {
-		for _, sdk := range sdks {
-			if sdk.Name == "Laravel" {
-				return &sdk
-			}
+	// Framework-specific SDKs (Laravel, Quarkus, browser)
+	for _, sdk := range sdks {
+		if slices.Contains(sdk.Frameworks, frameworkName) {
+			return &sdk
 		}
 	}
 
@@ -79,9 +83,60 @@ func GetRecommendedSDK(frameworkType, frameworkName string) *SDK {
 	return nil
 }
 
+// GetSDK returns the SDK with the given name (case-insensitive), or nil if
+// there is none
+func GetSDK(name string) *SDK {
+	for _, sdk := range GetAvailableSDKs() {
+		if strings.EqualFold(sdk.Name, name) {
+			return &sdk
+		}
+	}
+	return nil
+}
+
+// Target describes the project an SDK is installed into, as reported by the
+// detector
+type Target struct {
+	Dir            string // Project directory ("" for the current directory)
+	PackageManager string // "poetry", "pnpm", "gradle", ... ("" for the language default)
+	Framework      string // Detected framework, for middleware instructions
+
+	// Node.js or Go workspace the project belongs to, if any
+	WorkspaceRoot    string // Absolute workspace root
+	WorkspaceMember  string // Dir relative to WorkspaceRoot ("" when Dir is the root)
+	WorkspacePackage string // package.json name (Node.js) or module path (Go) of the member
+}
+
 // Install runs the SDK installation command
 func Install(sdk SDK) error {
+	_, err := InstallTarget(sdk, Target{}, nil)
+	return err
+}
+
+// InstallTarget runs the SDK installation command for target, using the
+// project's package manager. The package manager's output is streamed to out
+// (if not nil) and saved to a log under LogDir, whose path is returned.
+// Failed commands return an *InstallError.
+func InstallTarget(sdk SDK, target Target, out io.Writer) (string, error) {
+	r := newInstallRun(sdk, "install", out)
+	defer r.close()
+	if err := installTarget(r, sdk, target); err != nil {
+		return r.logPath(), err
+	}
+	if !plan.DryRun() {
+		var workspace *detector.Workspace
+		if target.WorkspaceRoot != "" {
+			workspace = &detector.Workspace{Root: target.WorkspaceRoot, Member: target.WorkspaceMember, Package: target.WorkspacePackage}
+		}
+		state.RecordPackage(sdk.Name, target.Dir, target.Framework, target.PackageManager, workspace)
+	}
+	return r.logPath(), nil
+}
+
+func installTarget(r *installRun, sdk SDK, target Target) error {
 	var cmd *exec.Cmd
+	dir := target.Dir
+	packageManager := target.PackageManager
 
 	switch sdk.Language {
 	case "php":
@@ -90,11 +145,10 @@ func Install(sdk SDK) error {
 			return fmt.Errorf("composer not found - please install composer first: https://getcomposer.org")
 		}
 		cmd = exec.Command("composer", "require", sdk.PackageName)
+		cmd.Dir = dir
 
 		// Run composer require
-		cmd.Stdout = nil
-		cmd.Stderr = nil
-		if err := cmd.Run(); err != nil {
+		if err := r.run(cmd); err != nil {
 			return err
 		}
 
@@ -102,24 +156,29 @@ func Install(sdk SDK) error {
 		if sdk.Name == "Laravel" {
 			if commandExists("php") {
 				publishCmd := exec.Command("php", "artisan", "vendor:publish", "--provider=TraceKit\\Laravel\\TracekitServiceProvider")
-				publishCmd.Stdout = nil
-				publishCmd.Stderr = nil
+				publishCmd.Dir = dir
 				// Ignore error if artisan command fails (user might need to run it manually)
-				_ = publishCmd.Run()
+				_ = r.run(publishCmd)
 			}
 		}
 
 		return nil
 
 	case "node":
-		// Check if npm exists, fallback to yarn
-		if commandExists("npm") {
-			cmd = exec.Command("npm", "install", sdk.PackageName)
-		} else if commandExists("yarn") {
-			cmd = exec.Command("yarn", "add", sdk.PackageName)
-		} else {
-			return fmt.Errorf("npm or yarn not found - please install Node.js first: https://nodejs.org")
+		args, runDir := nodeInstallArgs(sdk.PackageName, target)
+		if !commandExists(args[0]) {
+			switch {
+			case target.PackageManager != "" && target.PackageManager != "npm":
+				return fmt.Errorf("%s not found - this project uses %s (install it or run: corepack enable)", args[0], target.PackageManager)
+			case commandExists("yarn"):
+				// npm is only the default, fallback to yarn
+				args, runDir = nodeInstallArgs(sdk.PackageName, Target{Dir: dir, PackageManager: "yarn"})
+			default:
+				return fmt.Errorf("npm or yarn not found - please install Node.js first: https://nodejs.org")
+			}
 		}
+		cmd = exec.Command(args[0], args[1:]...)
+		dir = runDir
 
 	case "go":
 		// Check if go exists
@@ -127,26 +186,182 @@ func Install(sdk SDK) error {
 			return fmt.Errorf("go not found - please install Go first: https://go.dev")
 		}
 		cmd = exec.Command("go", "get", sdk.PackageName)
+		if target.WorkspaceRoot != "" {
+			// go get doesn't support workspace mode; add it to the member module
+			dir = filepath.Join(target.WorkspaceRoot, filepath.FromSlash(target.WorkspaceMember))
+			cmd.Env = append(os.Environ(), "GOWORK=off")
+		}
+
+	case "ruby":
+		if !commandExists("bundle") {
+			return fmt.Errorf("bundler not found - please install it first: gem install bundler")
+		}
+		cmd = exec.Command("bundle", "add", sdk.PackageName)
+		cmd.Dir = dir
+		if err := r.run(cmd); err != nil {
+			return err
+		}
+
+		// Rails apps start the SDK from an initializer
+		if target.Framework == "rails" {
+			return writeRailsInitializer(dir)
+		}
+		return nil
 
 	case "python":
-		// Check if pip exists, fallback to pip3
-		if commandExists("pip") {
-			cmd = exec.Command("pip", "install", sdk.PackageName)
-		} else if commandExists("pip3") {
-			cmd = exec.Command("pip3", "install", sdk.PackageName)
+		switch packageManager {
+		case "poetry", "uv", "pipenv":
+			if !commandExists(packageManager) {
+				return fmt.Errorf("%s not found - please install %s first or run: %s", packageManager, packageManager, InstallCommand(sdk, Target{PackageManager: "pip"}))
+			}
+			args := strings.Fields(InstallCommand(sdk, target))
+			cmd = exec.Command(args[0], args[1:]...)
+		default:
+			// Check if pip exists, fallback to pip3
+			if commandExists("pip") {
+				cmd = exec.Command("pip", append([]string{"install"}, sdk.packages()...)...)
+			} else if commandExists("pip3") {
+				cmd = exec.Command("pip3", append([]string{"install"}, sdk.packages()...)...)
+			} else {
+				return fmt.Errorf("pip not found - please install Python first: https://python.org")
+			}
+		}
+
+	case "java":
+		if sdk.PackageName == quarkusExtension {
+			args := strings.Fields(InstallCommand(sdk, target))
+			// Fall back to a system-wide Maven/Gradle when the project has no wrapper
+			if !fileExists(filepath.Join(dir, args[0])) {
+				args[0] = strings.TrimSuffix(strings.TrimPrefix(args[0], "./"), "w")
+				if !commandExists(args[0]) {
+					return fmt.Errorf("%s not found - please install it or add the build wrapper to your project", args[0])
+				}
+			}
+			cmd = exec.Command(args[0], args[1:]...)
 		} else {
-			return fmt.Errorf("pip not found - please install Python first: https://python.org")
+			if !commandExists("curl") {
+				return fmt.Errorf("curl not found - please download the agent manually: %s", javaAgentURL)
+			}
+			cmd = exec.Command("curl", "-sSLfo", javaAgentJar, javaAgentURL)
 		}
 
+	case "dotnet":
+		if !commandExists("dotnet") {
+			return fmt.Errorf("dotnet not found - please install the .NET SDK first: https://dot.net")
+		}
+		// dotnet add package only takes one package at a time
+		for _, pkg := range sdk.packages() {
+			addCmd := exec.Command("dotnet", "add", "package", pkg)
+			addCmd.Dir = dir
+			if err := r.run(addCmd); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "rust":
+		if !commandExists("cargo") {
+			return fmt.Errorf("cargo not found - please install Rust first: https://rustup.rs")
+		}
+		cmd = exec.Command("cargo", append([]string{"add"}, sdk.packages()...)...)
+
+	case "elixir":
+		return fmt.Errorf("mix cannot add dependencies automatically - add %s to deps in mix.exs, then run %s", strings.Join(sdk.packages(), ", "), sdk.InstallCmd)
+
 	default:
 		return fmt.Errorf("unsupported SDK language: %s", sdk.Language)
 	}
 
 	// Set environment and run
-	cmd.Stdout = nil
-	cmd.Stderr = nil
+	cmd.Dir = dir
+	return r.run(cmd)
+}
+
+// InstallCommand returns the command line that installs sdk into target,
+// falling back to the SDK's default InstallCmd
+func InstallCommand(sdk SDK, target Target) string {
+	packageManager := target.PackageManager
+
+	if sdk.Language == "node" {
+		args, _ := nodeInstallArgs(sdk.PackageName, target)
+		return strings.Join(args, " ")
+	}
 
-	return cmd.Run()
+	if sdk.Language == "go" && target.WorkspaceRoot != "" {
+		module := filepath.Join(target.WorkspaceRoot, filepath.FromSlash(target.WorkspaceMember))
+		if rel, err := filepath.Rel(target.Dir, module); err == nil && rel != "." && target.Dir != "" {
+			return "cd " + filepath.ToSlash(rel) + " && GOWORK=off " + sdk.InstallCmd
+		}
+		return "GOWORK=off " + sdk.InstallCmd
+	}
+
+	if sdk.PackageName == quarkusExtension && packageManager == "gradle" {
+		return "./gradlew addExtension --extensions=opentelemetry"
+	}
+
+	if sdk.Language == "python" {
+		packages := strings.Join(sdk.packages(), " ")
+		switch packageManager {
+		case "poetry":
+			return "poetry add " + packages
+		case "uv":
+			return "uv add " + packages
+		case "pipenv":
+			return "pipenv install " + packages
+		}
+	}
+	return sdk.InstallCmd
+}
+
+// nodeInstallArgs returns the command that adds pkg with the target's
+// package manager and the directory to run it in. Workspace members are
+// installed from the workspace root with the manager's workspace flags so
+// the shared lockfile stays consistent.
+func nodeInstallArgs(pkg string, target Target) ([]string, string) {
+	root, member, name := target.WorkspaceRoot, target.WorkspaceMember, target.WorkspacePackage
+	inWorkspace := root != ""
+	isRoot := inWorkspace && member == ""
+
+	switch target.PackageManager {
+	case "pnpm":
+		switch {
+		case isRoot:
+			return []string{"pnpm", "add", pkg, "--workspace-root"}, root
+		case inWorkspace:
+			filter := name
+			if filter == "" {
+				filter = "./" + member
+			}
+			return []string{"pnpm", "add", pkg, "--filter", filter}, root
+		}
+		return []string{"pnpm", "add", pkg}, target.Dir
+
+	case "yarn", "yarn-berry":
+		switch {
+		case isRoot && target.PackageManager == "yarn":
+			// Yarn classic refuses to add to a workspace root without -W
+			return []string{"yarn", "add", pkg, "--ignore-workspace-root-check"}, root
+		case inWorkspace && !isRoot && name != "":
+			return []string{"yarn", "workspace", name, "add", pkg}, root
+		}
+		return []string{"yarn", "add", pkg}, target.Dir
+
+	case "bun":
+		// bun add is workspace-aware when run from the member directory
+		return []string{"bun", "add", pkg}, target.Dir
+
+	default:
+		if inWorkspace && !isRoot {
+			return []string{"npm", "install", pkg, "--workspace", member}, root
+		}
+		return []string{"npm", "install", pkg}, target.Dir
+	}
+}
+
+// fileExists reports whether path exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
 }
 
 // commandExists checks if a command is available in PATH
@@ -155,50 +370,24 @@ func commandExists(cmd string) bool {
 	return err == nil
 }
 
-// GetInstallInstructions returns manual installation instructions for an SDK
-func GetInstallInstructions(sdk SDK) []string {
+// GetInstallInstructions returns manual installation instructions for an SDK.
+// framework selects the middleware snippet, if the SDK has one for it.
+func GetInstallInstructions(sdk SDK, framework string) []string {
 	instructions := []string{
 		fmt.Sprintf("Install %s SDK:", sdk.Name),
 		"  " + sdk.InstallCmd,
 		"",
 	}
 
-	// Add initialization example based on language
-	switch sdk.Language {
-	case "php":
-		if sdk.Name == "Laravel" {
-			instructions = append(instructions,
-				"Laravel auto-discovery will register the service provider.",
-				"Publish config (optional): php artisan vendor:publish --tag=tracekit",
-			)
-		} else {
-			instructions = append(instructions,
-				"Require in your code:",
-				"  require 'vendor/autoload.php';",
-				"  TraceKit\\SDK::init();",
-			)
+	for _, snippet := range sdk.SetupSnippets(framework) {
+		if snippet.Code == "" {
+			instructions = append(instructions, snippet.Description)
+			continue
+		}
+		instructions = append(instructions, snippet.Description+":")
+		for _, line := range strings.Split(snippet.Code, "\n") {
+			instructions = append(instructions, "  "+line)
 		}
-
-	case "node":
-		instructions = append(instructions,
-			"Import in your code:",
-			"  const tracekit = require('@tracekit/node-apm');",
-			"  tracekit.init();",
-		)
-
-	case "go":
-		instructions = append(instructions,
-			"Import in your code:",
-			"  import \"github.com/Tracekit-Dev/go-sdk\"",
-			"  tracekit.Init()",
-		)
-
-	case "python":
-		instructions = append(instructions,
-			"Import in your code:",
-			"  import tracekit",
-			"  tracekit.init()",
-		)
 	}
 
 	return instructions
@@ -0,0 +1,63 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const sessionFile = "session.json"
+
+// SessionTTL is how long a verification code stays worth resuming
+const SessionTTL = 15 * time.Minute
+
+// Session is a sign-up or login waiting for its email verification code, so
+// a failed or interrupted verification can resume without a new code
+type Session struct {
+	Email     string    `json:"email"`
+	SessionID string    `json:"session_id"`
+	APIURL    string    `json:"api_url"`
+	Created   time.Time `json:"created"`
+}
+
+// Expired reports whether the session's code is too old to use
+func (s *Session) Expired() bool {
+	return time.Since(s.Created) > SessionTTL
+}
+
+// LoadSession returns the pending verification session, or nil when there
+// is none
+func LoadSession() *Session {
+	content, err := os.ReadFile(filepath.Join(Root(), Dir, sessionFile))
+	if err != nil {
+		return nil
+	}
+	var s Session
+	if json.Unmarshal(content, &s) != nil || s.SessionID == "" {
+		return nil
+	}
+	return &s
+}
+
+// SaveSession keeps a pending verification session until ClearSession
+func SaveSession(s *Session) error {
+	dir := filepath.Join(Root(), Dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := ignoreState(); err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, sessionFile), append(content, '\n'), 0600)
+}
+
+// ClearSession removes the pending verification session
+func ClearSession() {
+	os.Remove(filepath.Join(Root(), Dir, sessionFile))
+	removeIfUnused()
+}
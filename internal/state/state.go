@@ -0,0 +1,343 @@
+// Package state keeps .tracekit/state.json, the record of every change the
+// CLI makes to a project (files written, SDKs installed and resources
+// created in TraceKit) so 'tracekit rollback' can undo them.
+package state
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yourusername/context.io/cli/internal/detector"
+)
+
+const (
+	// Dir holds the manifest and file backups, in the project directory
+	Dir = ".tracekit"
+
+	manifestFile    = "state.json"
+	backupDir       = "backups"
+	manifestVersion = 1
+)
+
+// Change is one recorded side effect
+type Change struct {
+	Type   string    `json:"type"` // "file", "package" or "resource"
+	Reason string    `json:"reason,omitempty"`
+	Time   time.Time `json:"time"`
+
+	// file: Path (relative to the project) was written, or deleted when
+	// Removed. Backup holds its previous contents, relative to Dir ("" when
+	// the file was created).
+	Path    string      `json:"path,omitempty"`
+	Backup  string      `json:"backup,omitempty"`
+	SHA256  string      `json:"sha256,omitempty"` // Of the contents written
+	Mode    os.FileMode `json:"mode,omitempty"`   // Of the file before the change
+	Removed bool        `json:"removed,omitempty"`
+
+	// package: an SDK installed into the project in Dir
+	SDK            string              `json:"sdk,omitempty"`
+	Dir            string              `json:"dir,omitempty"`
+	Framework      string              `json:"framework,omitempty"`
+	PackageManager string              `json:"package_manager,omitempty"`
+	Workspace      *detector.Workspace `json:"workspace,omitempty"` // Node.js or Go workspace Dir belongs to
+
+	// resource: something created in TraceKit
+	Kind      string `json:"kind,omitempty"` // "webhook", "health check", "public key" or "api key"
+	ID        string `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	DeleteURL string `json:"delete_url,omitempty"` // "" when the API can't delete it
+}
+
+// Manifest is the contents of state.json, oldest change first
+type Manifest struct {
+	Version int      `json:"version"`
+	Changes []Change `json:"changes"`
+}
+
+var (
+	root   string // Project directory (the working directory by default)
+	paused bool
+)
+
+// Root returns the project directory holding Dir
+func Root() string {
+	if root == "" {
+		root, _ = os.Getwd()
+	}
+	return root
+}
+
+// Pause stops recording, for commands that undo recorded changes
+func Pause() {
+	paused = true
+}
+
+// Load reads the manifest, returning an empty one when there is none
+func Load() (*Manifest, error) {
+	m := &Manifest{Version: manifestVersion}
+	content, err := os.ReadFile(filepath.Join(Root(), Dir, manifestFile))
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, m); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", filepath.Join(Dir, manifestFile), err)
+	}
+	if m.Version > manifestVersion {
+		return nil, fmt.Errorf("%s was written by a newer TraceKit CLI", filepath.Join(Dir, manifestFile))
+	}
+	return m, nil
+}
+
+// Save writes the manifest. Once no changes are left, it is removed along
+// with its backups, and so is Dir when nothing else (a pending session or
+// the detector rules) is in it.
+func Save(m *Manifest) error {
+	dir := filepath.Join(Root(), Dir)
+	if len(m.Changes) == 0 {
+		if err := os.Remove(filepath.Join(dir, manifestFile)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if err := os.RemoveAll(filepath.Join(dir, backupDir)); err != nil {
+			return err
+		}
+		removeIfUnused()
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, backupDir), 0755); err != nil {
+		return err
+	}
+	if err := ignoreState(); err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, manifestFile), append(content, '\n'), 0600); err != nil {
+		return err
+	}
+
+	// Drop backups of changes that have been rolled back
+	used := make(map[string]bool)
+	for _, c := range m.Changes {
+		used[c.Backup] = true
+	}
+	entries, _ := os.ReadDir(filepath.Join(dir, backupDir))
+	for _, e := range entries {
+		if !used[backupDir+"/"+e.Name()] {
+			os.Remove(filepath.Join(dir, backupDir, e.Name()))
+		}
+	}
+	return nil
+}
+
+// ignored are the files in Dir kept out of git: backups hold copies of .env
+// and the session an email address. Other files, like the detector rules,
+// are meant to be committed.
+var ignored = []string{manifestFile, sessionFile, backupDir + "/"}
+
+// ignoreState adds the entries in ignored that Dir's .gitignore is missing,
+// creating it if needed
+func ignoreState() error {
+	path := filepath.Join(Root(), Dir, ".gitignore")
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		existing[strings.TrimSpace(line)] = true
+	}
+	var missing []string
+	for _, entry := range ignored {
+		if !existing[entry] {
+			missing = append(missing, entry)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+		content = append(content, '\n')
+	}
+	content = append(content, strings.Join(missing, "\n")+"\n"...)
+	return os.WriteFile(path, content, 0644)
+}
+
+// removeIfUnused removes Dir once nothing is left in it but the .gitignore
+// written by ignoreState
+func removeIfUnused() {
+	dir := filepath.Join(Root(), Dir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if e.Name() != ".gitignore" {
+			return
+		}
+	}
+	content, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if err == nil && string(content) != strings.Join(ignored, "\n")+"\n" {
+		// Written or edited by the user
+		return
+	}
+	os.Remove(filepath.Join(dir, ".gitignore"))
+	os.Remove(dir)
+}
+
+// AbsPath returns the absolute path of a file change
+func (c Change) AbsPath() string {
+	if filepath.IsAbs(c.Path) {
+		return c.Path
+	}
+	return filepath.Join(Root(), filepath.FromSlash(c.Path))
+}
+
+// ReadBackup returns the contents a file change replaced
+func (c Change) ReadBackup() ([]byte, error) {
+	return os.ReadFile(filepath.Join(Root(), Dir, filepath.FromSlash(c.Backup)))
+}
+
+// Modified reports whether the file has changed since it was written
+func (c Change) Modified() bool {
+	content, err := os.ReadFile(c.AbsPath())
+	return err != nil || Hash(content) != c.SHA256
+}
+
+// Hash returns the checksum recorded for file contents
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// PrepareFile backs up path before it is written with data. The returned
+// change is recorded with Record once the write succeeds, or dropped with
+// Discard when it fails; it is nil when there is nothing to record.
+func PrepareFile(reason, path string, data []byte) (*Change, error) {
+	if paused {
+		return nil, nil
+	}
+	c := &Change{Type: "file", Reason: reason, Path: relPath(path), SHA256: Hash(data)}
+	if info, err := os.Stat(path); err == nil {
+		before, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(before, data) {
+			return nil, nil
+		}
+		if c.Backup, err = writeBackup(path, before); err != nil {
+			return nil, err
+		}
+		c.Mode = info.Mode().Perm()
+	}
+	return c, nil
+}
+
+// PrepareRemove backs up path before it is deleted, like PrepareFile
+func PrepareRemove(reason, path string) (*Change, error) {
+	if paused {
+		return nil, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Change{Type: "file", Reason: reason, Path: relPath(path), Removed: true, Mode: info.Mode().Perm()}
+	if c.Backup, err = writeBackup(path, before); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Record adds a prepared change to the manifest
+func Record(c *Change) error {
+	if c == nil {
+		return nil
+	}
+	return record(*c)
+}
+
+// Discard drops a prepared change whose file operation failed
+func Discard(c *Change) {
+	if c == nil || c.Backup == "" {
+		return
+	}
+	os.Remove(filepath.Join(Root(), Dir, filepath.FromSlash(c.Backup)))
+	// Only removed when empty
+	os.Remove(filepath.Join(Root(), Dir, backupDir))
+	removeIfUnused()
+}
+
+// RecordPackage records an SDK installed into the project in dir, which
+// belongs to workspace unless that is nil
+func RecordPackage(sdk, dir, framework, packageManager string, workspace *detector.Workspace) error {
+	if paused {
+		return nil
+	}
+	return record(Change{Type: "package", Reason: "install " + sdk, SDK: sdk, Dir: relPath(dir), Framework: framework, PackageManager: packageManager, Workspace: workspace})
+}
+
+// RecordResource records a resource created in TraceKit. deleteURL is the
+// API URL that deletes it, or "" when it can't be deleted from the CLI.
+func RecordResource(kind, id, name, deleteURL string) error {
+	if paused {
+		return nil
+	}
+	return record(Change{Type: "resource", Reason: "create " + kind, Kind: kind, ID: id, Name: name, DeleteURL: deleteURL})
+}
+
+func record(c Change) error {
+	m, err := Load()
+	if err != nil {
+		return err
+	}
+	c.Time = time.Now().UTC()
+	m.Changes = append(m.Changes, c)
+	return Save(m)
+}
+
+// writeBackup copies content into the backup directory, returning its path
+// relative to Dir
+func writeBackup(path string, content []byte) (string, error) {
+	name := time.Now().UTC().Format("20060102-150405.000000000") + "-" + filepath.Base(path)
+	dir := filepath.Join(Root(), Dir, backupDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), content, 0600); err != nil {
+		return "", fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	return backupDir + "/" + name, nil
+}
+
+// relPath makes path relative to the project when it is inside it
+func relPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(Root(), abs); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.ToSlash(rel)
+	}
+	return abs
+}
@@ -0,0 +1,160 @@
+package trace
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// otlpExport is the OTLP/HTTP JSON trace export payload
+type otlpExport struct {
+	ResourceSpans []struct {
+		Resource struct {
+			Attributes []otlpKeyValue `json:"attributes"`
+		} `json:"resource"`
+		ScopeSpans []struct {
+			Spans []otlpSpan `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId"`
+	Name              string          `json:"name"`
+	Kind              json.RawMessage `json:"kind"`
+	StartTimeUnixNano json.Number     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   json.Number     `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue  `json:"attributes"`
+	Events            []struct {
+		TimeUnixNano json.Number    `json:"timeUnixNano"`
+		Name         string         `json:"name"`
+		Attributes   []otlpKeyValue `json:"attributes"`
+	} `json:"events"`
+	Status struct {
+		Code    json.RawMessage `json:"code"`
+		Message string          `json:"message"`
+	} `json:"status"`
+}
+
+type otlpKeyValue struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+// IsOTLP reports whether data looks like an OTLP/HTTP JSON trace export
+func IsOTLP(data []byte) bool {
+	var probe struct {
+		ResourceSpans json.RawMessage `json:"resourceSpans"`
+	}
+	return json.Unmarshal(data, &probe) == nil && len(probe.ResourceSpans) > 0
+}
+
+// ParseOTLP converts an OTLP/HTTP JSON trace export into spans
+func ParseOTLP(data []byte) ([]Span, error) {
+	var export otlpExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, err
+	}
+
+	var spans []Span
+	for _, rs := range export.ResourceSpans {
+		resource := otlpAttributes(rs.Resource.Attributes)
+		serviceName, _ := resource["service.name"].(string)
+		serviceVersion, _ := resource["service.version"].(string)
+
+		for _, ss := range rs.ScopeSpans {
+			for _, s := range ss.Spans {
+				start := otlpNanos(s.StartTimeUnixNano)
+				end := otlpNanos(s.EndTimeUnixNano)
+
+				span := Span{
+					TraceID:    s.TraceID,
+					SpanID:     s.SpanID,
+					ParentID:   s.ParentSpanID,
+					Name:       s.Name,
+					Kind:       otlpKind(s.Kind),
+					Timestamp:  start / 1e6,
+					Duration:   float64(end-start) / 1e6,
+					Service:    SpanService{Name: serviceName, Version: serviceVersion},
+					Attributes: otlpAttributes(s.Attributes),
+					Status: SpanStatus{
+						Code:    otlpStatusCode(s.Status.Code),
+						Message: s.Status.Message,
+					},
+				}
+				for _, e := range s.Events {
+					span.Events = append(span.Events, SpanEvent{
+						Timestamp:  otlpNanos(e.TimeUnixNano) / 1e6,
+						Name:       e.Name,
+						Attributes: otlpAttributes(e.Attributes),
+					})
+				}
+				spans = append(spans, span)
+			}
+		}
+	}
+
+	return spans, nil
+}
+
+// otlpNanos parses a Unix nanosecond timestamp, which OTLP JSON encodes as
+// either a string or a number
+func otlpNanos(n json.Number) int64 {
+	v, err := strconv.ParseInt(strings.Trim(string(n), `"`), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+func otlpAttributes(kvs []otlpKeyValue) map[string]interface{} {
+	if len(kvs) == 0 {
+		return nil
+	}
+
+	attrs := make(map[string]interface{}, len(kvs))
+	for _, kv := range kvs {
+		for valueType, v := range kv.Value {
+			if valueType == "intValue" {
+				// int64 values are encoded as strings in OTLP JSON
+				if s, ok := v.(string); ok {
+					if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+						v = i
+					}
+				}
+			}
+			attrs[kv.Key] = v
+		}
+	}
+	return attrs
+}
+
+// otlpKind maps OTLP span kinds (enum number or name) to TraceKit kinds
+func otlpKind(raw json.RawMessage) string {
+	switch strings.Trim(string(raw), `"`) {
+	case "2", "SPAN_KIND_SERVER":
+		return "server"
+	case "3", "SPAN_KIND_CLIENT":
+		return "client"
+	case "4", "SPAN_KIND_PRODUCER":
+		return "producer"
+	case "5", "SPAN_KIND_CONSUMER":
+		return "consumer"
+	default:
+		return "internal"
+	}
+}
+
+// otlpStatusCode maps OTLP status codes (enum number or name) to TraceKit codes
+func otlpStatusCode(raw json.RawMessage) string {
+	switch strings.Trim(string(raw), `"`) {
+	case "1", "STATUS_CODE_OK":
+		return "ok"
+	case "2", "STATUS_CODE_ERROR":
+		return "error"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,208 @@
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// Span is a single span in the format accepted by /v1/traces
+type Span struct {
+	TraceID    string                 `json:"trace_id"`
+	SpanID     string                 `json:"span_id"`
+	ParentID   string                 `json:"parent_id"`
+	Name       string                 `json:"name"`
+	Kind       string                 `json:"kind"`
+	Timestamp  int64                  `json:"timestamp"` // Unix milliseconds
+	Duration   float64                `json:"duration"`  // Milliseconds
+	Service    SpanService            `json:"service"`
+	Attributes map[string]interface{} `json:"attributes"`
+	Events     []SpanEvent            `json:"events"`
+	Status     SpanStatus             `json:"status"`
+}
+
+// SpanService identifies the service that emitted a span
+type SpanService struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// SpanEvent is a timestamped annotation on a span
+type SpanEvent struct {
+	Timestamp  int64                  `json:"timestamp"` // Unix milliseconds
+	Name       string                 `json:"name"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// SpanStatus is the outcome of a span
+type SpanStatus struct {
+	Code    string `json:"code"` // "ok", "error" or empty when unset
+	Message string `json:"message"`
+}
+
+// StartTime returns the span start as a time.Time
+func (s *Span) StartTime() time.Time {
+	return time.UnixMilli(s.Timestamp)
+}
+
+// End returns the span end in Unix milliseconds
+func (s *Span) End() float64 {
+	return float64(s.Timestamp) + s.Duration
+}
+
+// IsError reports whether the span finished with an error status
+func (s *Span) IsError() bool {
+	return s.Status.Code == "error"
+}
+
+// SpanNode is a span together with its children in a trace tree
+type SpanNode struct {
+	Span     *Span
+	Children []*SpanNode
+	Depth    int
+}
+
+// LoadFile reads spans from a local JSON trace file.
+//
+// The file may contain a single span object, an array of spans, an object
+// with a "spans" array (as returned by the traces API), or an OTLP/HTTP JSON
+// export.
+func LoadFile(path string) ([]Span, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trace file: %w", err)
+	}
+
+	spans, err := ParseSpans(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return spans, nil
+}
+
+// ParseSpans decodes spans from JSON in any of the formats LoadFile accepts
+func ParseSpans(data []byte) ([]Span, error) {
+	// OTLP export
+	if IsOTLP(data) {
+		spans, err := ParseOTLP(data)
+		if err != nil {
+			return nil, err
+		}
+		return validateSpans(spans)
+	}
+
+	// Array of spans
+	var spans []Span
+	if err := json.Unmarshal(data, &spans); err == nil {
+		return validateSpans(spans)
+	}
+
+	// Object wrapping a spans array
+	var wrapped struct {
+		Spans []Span `json:"spans"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err == nil && len(wrapped.Spans) > 0 {
+		return validateSpans(wrapped.Spans)
+	}
+
+	// Single span
+	var span Span
+	if err := json.Unmarshal(data, &span); err != nil {
+		return nil, err
+	}
+
+	return validateSpans([]Span{span})
+}
+
+func validateSpans(spans []Span) ([]Span, error) {
+	if len(spans) == 0 {
+		return nil, fmt.Errorf("no spans found")
+	}
+
+	for i, span := range spans {
+		if span.SpanID == "" {
+			return nil, fmt.Errorf("span %d is missing span_id", i)
+		}
+	}
+
+	return spans, nil
+}
+
+// BuildTree links spans to their parents and returns the root nodes ordered
+// by start time. Spans whose parent is not present are treated as roots, and
+// so is one span of each parent cycle, so no span is dropped. A span_id that
+// appears twice keeps its first span.
+func BuildTree(spans []Span) []*SpanNode {
+	nodes := make(map[string]*SpanNode, len(spans))
+	var unique []*SpanNode
+	for i := range spans {
+		if _, ok := nodes[spans[i].SpanID]; ok {
+			continue
+		}
+		node := &SpanNode{Span: &spans[i]}
+		nodes[spans[i].SpanID] = node
+		unique = append(unique, node)
+	}
+
+	parents := make(map[*SpanNode]*SpanNode, len(unique))
+	for _, node := range unique {
+		if parent, ok := nodes[node.Span.ParentID]; ok && parent != node {
+			parents[node] = parent
+		}
+	}
+
+	// Follow each span's parents; reaching a span already on the path means
+	// a cycle, which is cut there so that span becomes a root
+	const onPath, done = 1, 2
+	state := make(map[*SpanNode]int, len(unique))
+	for _, node := range unique {
+		var path []*SpanNode
+		n := node
+		for n != nil && state[n] == 0 {
+			state[n] = onPath
+			path = append(path, n)
+			n = parents[n]
+		}
+		if n != nil && state[n] == onPath {
+			delete(parents, n)
+		}
+		for _, p := range path {
+			state[p] = done
+		}
+	}
+
+	var roots []*SpanNode
+	for _, node := range unique {
+		if parent, ok := parents[node]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	sortNodes(roots)
+	for _, root := range roots {
+		setDepth(root, 0)
+	}
+
+	return roots
+}
+
+func sortNodes(nodes []*SpanNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Span.Timestamp < nodes[j].Span.Timestamp
+	})
+	for _, node := range nodes {
+		sortNodes(node.Children)
+	}
+}
+
+func setDepth(node *SpanNode, depth int) {
+	node.Depth = depth
+	for _, child := range node.Children {
+		setDepth(child, depth+1)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/yourusername/context.io/cli/internal/config"
+	"github.com/yourusername/context.io/cli/internal/plan"
 )
 
 const CLIVersion = "1.0.0"
@@ -74,10 +75,7 @@ func GenerateTestTrace(serviceName string) map[string]interface{} {
 // SendTrace sends the trace to TraceKit endpoint
 func SendTrace(cfg *config.Config, trace map[string]interface{}) error {
 	// Determine endpoint
-	endpoint := cfg.Endpoint
-	if endpoint == "" {
-		endpoint = "https://app.tracekit.dev/v1/traces"
-	}
+	endpoint := cfg.GetTraceEndpoint()
 
 	// Prepare request body
 	body, err := json.Marshal(trace)
@@ -85,6 +83,10 @@ func SendTrace(cfg *config.Config, trace map[string]interface{}) error {
 		return fmt.Errorf("failed to marshal trace: %w", err)
 	}
 
+	if plan.Request("send test trace", "POST", endpoint, body) {
+		return nil
+	}
+
 	// Create request
 	req, err := http.NewRequest("POST", endpoint, bytes.NewReader(body))
 	if err != nil {
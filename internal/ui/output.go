@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-isatty"
+)
+
+const (
+	// paneHeight is how many lines of command output an OutputPane shows
+	paneHeight = 8
+	// paneWidth is where long output lines are cut off in a terminal
+	paneWidth = 100
+)
+
+// OutputPane streams a command's output. In a terminal it shows the last few
+// lines in place and can be collapsed when the command is done; otherwise
+// every line is printed.
+type OutputPane struct {
+	tty     bool
+	lines   []string // Last paneHeight complete lines
+	partial string
+	drawn   int // Lines currently on screen
+}
+
+// NewOutputPane returns a pane writing to stdout
+func NewOutputPane() *OutputPane {
+	return &OutputPane{tty: isatty.IsTerminal(os.Stdout.Fd())}
+}
+
+// Write implements io.Writer
+func (p *OutputPane) Write(b []byte) (int, error) {
+	text := p.partial + strings.ReplaceAll(string(b), "\r\n", "\n")
+	parts := strings.Split(text, "\n")
+	p.partial = parts[len(parts)-1]
+
+	for _, line := range parts[:len(parts)-1] {
+		// Progress bars redraw a line with carriage returns; keep the last frame
+		if i := strings.LastIndex(line, "\r"); i >= 0 {
+			line = line[i+1:]
+		}
+		p.addLine(line)
+	}
+	if p.tty && len(parts) > 1 {
+		p.redraw()
+	}
+	return len(b), nil
+}
+
+func (p *OutputPane) addLine(line string) {
+	if !p.tty {
+		fmt.Println(mutedStyle.Render("   │ " + line))
+		return
+	}
+	p.lines = append(p.lines, line)
+	if len(p.lines) > paneHeight {
+		p.lines = p.lines[len(p.lines)-paneHeight:]
+	}
+}
+
+// redraw replaces the lines on screen with the latest output
+func (p *OutputPane) redraw() {
+	p.clear()
+	style := lipgloss.NewStyle().Foreground(subtleColor)
+	for _, line := range p.lines {
+		if r := []rune(line); len(r) > paneWidth {
+			line = string(r[:paneWidth-1]) + "…"
+		}
+		fmt.Println(style.Render("   │ " + line))
+	}
+	p.drawn = len(p.lines)
+}
+
+// clear erases the lines on screen
+func (p *OutputPane) clear() {
+	if p.drawn > 0 {
+		fmt.Printf("\x1b[%dA\x1b[J", p.drawn)
+		p.drawn = 0
+	}
+}
+
+// Close flushes a trailing partial line. In a terminal, collapse removes the
+// output from the screen (for commands that succeeded); otherwise the last
+// lines stay visible.
+func (p *OutputPane) Close(collapse bool) {
+	if p.partial != "" {
+		p.addLine(p.partial)
+		p.partial = ""
+		if p.tty {
+			p.redraw()
+		}
+	}
+	if p.tty && collapse {
+		p.clear()
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
 )
 
 var (
@@ -279,3 +280,58 @@ func PrintProgress(current, total int, msg string) {
 		fmt.Println()
 	}
 }
+
+// PrintTable prints rows under a styled header row
+func PrintTable(headers []string, rows [][]string) {
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(subtleColor)).
+		BorderColumn(false).
+		BorderLeft(false).
+		BorderRight(false).
+		BorderTop(false).
+		BorderBottom(false).
+		Headers(headers...).
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return lipgloss.NewStyle().Foreground(brandColor).Bold(true).PaddingRight(2)
+			}
+			return lipgloss.NewStyle().PaddingRight(2)
+		})
+
+	fmt.Println(t.Render())
+}
+
+// StatusText renders a status word in the color matching its severity
+func StatusText(status string) string {
+	switch status {
+	case "ok", "healthy", "active", "success":
+		return successStyle.Render(status)
+	case "error", "unhealthy", "failed":
+		return errorStyle.Render(status)
+	case "degraded", "warning", "outdated":
+		return warningStyle.Render(status)
+	default:
+		return mutedStyle.Render(status)
+	}
+}
+
+// PrintDiff prints a unified diff with added lines in green and removed
+// lines in red
+func PrintDiff(diff string) {
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			fmt.Println(lipgloss.NewStyle().Bold(true).Render(line))
+		case strings.HasPrefix(line, "@@"):
+			fmt.Println(lipgloss.NewStyle().Foreground(accentColor).Render(line))
+		case strings.HasPrefix(line, "+"):
+			fmt.Println(lipgloss.NewStyle().Foreground(successColor).Render(line))
+		case strings.HasPrefix(line, "-"):
+			fmt.Println(lipgloss.NewStyle().Foreground(dangerColor).Render(line))
+		default:
+			fmt.Println(line)
+		}
+	}
+}
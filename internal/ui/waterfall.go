@@ -0,0 +1,594 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/context.io/cli/internal/trace"
+)
+
+const (
+	waterfallMinWidth    = 60
+	waterfallDurationCol = 10
+)
+
+// waterfallRow is a visible line in the waterfall
+type waterfallRow struct {
+	node *trace.SpanNode
+	root *trace.SpanNode
+}
+
+// waterfallBounds is the time range a root span's bars are scaled to
+type waterfallBounds struct {
+	start float64
+	end   float64
+}
+
+type waterfallKeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	Collapse key.Binding
+	Expand   key.Binding
+	Toggle   key.Binding
+	Detail   key.Binding
+	Quit     key.Binding
+}
+
+func (k waterfallKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Toggle, k.Collapse, k.Expand, k.Detail, k.Quit}
+}
+
+func (k waterfallKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
+
+var waterfallKeys = waterfallKeyMap{
+	Up:       key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+	Down:     key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+	Collapse: key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "collapse")),
+	Expand:   key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "expand")),
+	Toggle:   key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "toggle")),
+	Detail:   key.NewBinding(key.WithKeys("tab", "d"), key.WithHelp("tab", "details")),
+	Quit:     key.NewBinding(key.WithKeys("q", "esc", "ctrl+c"), key.WithHelp("q", "quit")),
+}
+
+// WaterfallModel is an interactive, collapsible trace waterfall
+type WaterfallModel struct {
+	roots      []*trace.SpanNode
+	bounds     map[*trace.SpanNode]waterfallBounds
+	collapsed  map[*trace.SpanNode]bool
+	rows       []waterfallRow
+	cursor     int
+	offset     int
+	width      int
+	height     int
+	showDetail bool
+	detail     viewport.Model
+	help       help.Model
+}
+
+// NewWaterfallModel creates a waterfall model for the given span trees
+func NewWaterfallModel(roots []*trace.SpanNode) *WaterfallModel {
+	m := &WaterfallModel{
+		roots:      roots,
+		bounds:     waterfallRootBounds(roots),
+		collapsed:  make(map[*trace.SpanNode]bool),
+		width:      100,
+		height:     30,
+		showDetail: true,
+		detail:     viewport.New(100, 10),
+		help:       help.New(),
+	}
+	m.refreshRows()
+	m.detail.SetContent(m.renderDetail())
+	return m
+}
+
+// RunWaterfall opens the interactive waterfall viewer for the given spans
+func RunWaterfall(spans []trace.Span) error {
+	roots := trace.BuildTree(spans)
+	program := tea.NewProgram(NewWaterfallModel(roots), tea.WithAltScreen())
+	_, err := program.Run()
+	return err
+}
+
+// RenderWaterfall renders a fully expanded, non-interactive waterfall
+func RenderWaterfall(roots []*trace.SpanNode, width int) string {
+	m := NewWaterfallModel(roots)
+	if width < waterfallMinWidth {
+		width = waterfallMinWidth
+	}
+	m.width = width
+
+	var b strings.Builder
+	for _, row := range m.rows {
+		b.WriteString(m.renderRow(row, false))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Init implements tea.Model
+func (m *WaterfallModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (m *WaterfallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.help.Width = msg.Width
+		m.resizeDetail()
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, waterfallKeys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, waterfallKeys.Up):
+			m.moveCursor(-1)
+		case key.Matches(msg, waterfallKeys.Down):
+			m.moveCursor(1)
+		case key.Matches(msg, waterfallKeys.Toggle):
+			if node := m.selected(); node != nil && len(node.Children) > 0 {
+				m.collapsed[node] = !m.collapsed[node]
+				m.refreshRows()
+			}
+		case key.Matches(msg, waterfallKeys.Collapse):
+			m.collapseSelected()
+		case key.Matches(msg, waterfallKeys.Expand):
+			if node := m.selected(); node != nil && m.collapsed[node] {
+				delete(m.collapsed, node)
+				m.refreshRows()
+			}
+		case key.Matches(msg, waterfallKeys.Detail):
+			m.showDetail = !m.showDetail
+			m.resizeDetail()
+		}
+	}
+
+	m.detail.SetContent(m.renderDetail())
+	m.detail.GotoTop()
+	return m, nil
+}
+
+// View implements tea.Model
+func (m *WaterfallModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(m.renderHeader())
+	b.WriteString("\n")
+
+	listHeight := m.listHeight()
+	end := m.offset + listHeight
+	if end > len(m.rows) {
+		end = len(m.rows)
+	}
+	for i := m.offset; i < end; i++ {
+		b.WriteString(m.renderRow(m.rows[i], i == m.cursor))
+		b.WriteString("\n")
+	}
+	for i := end - m.offset; i < listHeight; i++ {
+		b.WriteString("\n")
+	}
+
+	if m.showDetail {
+		b.WriteString(lipgloss.NewStyle().Foreground(subtleColor).Render(strings.Repeat("─", m.width)))
+		b.WriteString("\n")
+		b.WriteString(m.detail.View())
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.help.View(waterfallKeys))
+	return b.String()
+}
+
+func (m *WaterfallModel) selected() *trace.SpanNode {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return nil
+	}
+	return m.rows[m.cursor].node
+}
+
+func (m *WaterfallModel) moveCursor(delta int) {
+	m.cursor += delta
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
+	}
+	m.clampOffset()
+}
+
+// collapseSelected collapses the selected span, or jumps to its parent when
+// it is already collapsed or has no children
+func (m *WaterfallModel) collapseSelected() {
+	node := m.selected()
+	if node == nil {
+		return
+	}
+
+	if len(node.Children) > 0 && !m.collapsed[node] {
+		m.collapsed[node] = true
+		m.refreshRows()
+		return
+	}
+
+	for i := m.cursor - 1; i >= 0; i-- {
+		if m.rows[i].node.Depth < node.Depth {
+			m.cursor = i
+			m.clampOffset()
+			return
+		}
+	}
+}
+
+func (m *WaterfallModel) refreshRows() {
+	selected := m.selected()
+
+	m.rows = m.rows[:0]
+	for _, root := range m.roots {
+		m.appendRows(root, root)
+	}
+
+	// Keep the cursor on the same span after collapsing or expanding
+	for i, row := range m.rows {
+		if row.node == selected {
+			m.cursor = i
+			break
+		}
+	}
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
+	}
+	m.clampOffset()
+}
+
+func (m *WaterfallModel) appendRows(node, root *trace.SpanNode) {
+	m.rows = append(m.rows, waterfallRow{node: node, root: root})
+	if m.collapsed[node] {
+		return
+	}
+	for _, child := range node.Children {
+		m.appendRows(child, root)
+	}
+}
+
+func (m *WaterfallModel) listHeight() int {
+	// Header, help line and (optionally) the divider plus detail pane
+	height := m.height - 2
+	if m.showDetail {
+		height -= m.detail.Height + 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	return height
+}
+
+func (m *WaterfallModel) clampOffset() {
+	listHeight := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+listHeight {
+		m.offset = m.cursor - listHeight + 1
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+}
+
+func (m *WaterfallModel) resizeDetail() {
+	m.detail.Width = m.width
+	m.detail.Height = m.height * 2 / 5
+	if m.detail.Height < 5 {
+		m.detail.Height = 5
+	}
+	m.clampOffset()
+}
+
+// columnWidths splits the terminal width into label and bar columns
+func (m *WaterfallModel) columnWidths() (labelWidth, barWidth int) {
+	width := m.width
+	if width < waterfallMinWidth {
+		width = waterfallMinWidth
+	}
+	labelWidth = width * 2 / 5
+	if labelWidth > 50 {
+		labelWidth = 50
+	}
+	barWidth = width - labelWidth - waterfallDurationCol - 2
+	return labelWidth, barWidth
+}
+
+func (m *WaterfallModel) renderHeader() string {
+	spanCount := 0
+	errorCount := 0
+	for _, root := range m.roots {
+		walkSpanNodes(root, func(n *trace.SpanNode) {
+			spanCount++
+			if n.Span.IsError() {
+				errorCount++
+			}
+		})
+	}
+
+	title := lipgloss.NewStyle().Foreground(brandColor).Bold(true).Render("Trace Waterfall")
+	stats := fmt.Sprintf("%d spans", spanCount)
+	if len(m.roots) == 1 {
+		stats = fmt.Sprintf("%s · %s · %s", m.roots[0].Span.TraceID, stats, FormatDurationMs(m.roots[0].Span.Duration))
+	}
+	if errorCount > 0 {
+		stats += " · " + errorStyle.Render(fmt.Sprintf("%d errors", errorCount))
+	}
+	return title + "  " + mutedStyle.Render(stats)
+}
+
+func (m *WaterfallModel) renderRow(row waterfallRow, selected bool) string {
+	labelWidth, barWidth := m.columnWidths()
+	span := row.node.Span
+
+	// Label: indentation, expand marker, kind badge and name
+	marker := "•"
+	if len(row.node.Children) > 0 {
+		marker = "▾"
+		if m.collapsed[row.node] {
+			marker = "▸"
+		}
+	}
+	badge := spanKindBadge(span.Kind)
+	prefix := strings.Repeat("  ", row.node.Depth) + marker + " "
+	nameWidth := labelWidth - lipgloss.Width(prefix) - lipgloss.Width(badge) - 1
+	name := truncate(span.Name, nameWidth)
+
+	nameStyle := lipgloss.NewStyle()
+	if span.IsError() {
+		nameStyle = nameStyle.Foreground(dangerColor)
+	}
+	if selected {
+		nameStyle = nameStyle.Bold(true).Reverse(true)
+	}
+
+	label := mutedStyle.Render(prefix) + spanKindStyle(span.Kind).Render(badge) + " " + nameStyle.Render(name)
+	label += strings.Repeat(" ", max(0, labelWidth-lipgloss.Width(label)))
+
+	// Bar: offset and width scaled to the root span's time range
+	bounds := m.bounds[row.root]
+	total := bounds.end - bounds.start
+	startCol, length := 0, barWidth
+	if total > 0 {
+		startCol = int((float64(span.Timestamp) - bounds.start) / total * float64(barWidth))
+		length = int(span.Duration / total * float64(barWidth))
+	}
+	startCol = clamp(startCol, 0, barWidth-1)
+	length = clamp(length, 1, barWidth-startCol)
+
+	bar := strings.Repeat(" ", startCol) +
+		spanStatusStyle(span).Render(strings.Repeat("█", length)) +
+		strings.Repeat(" ", barWidth-startCol-length)
+
+	duration := fmt.Sprintf("%*s", waterfallDurationCol, FormatDurationMs(span.Duration))
+
+	return label + " " + bar + " " + mutedStyle.Render(duration)
+}
+
+func (m *WaterfallModel) renderDetail() string {
+	node := m.selected()
+	if node == nil {
+		return ""
+	}
+	span := node.Span
+	bounds := m.bounds[m.rows[m.cursor].root]
+
+	var b strings.Builder
+	line := func(label, value string) {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("%-12s", label)))
+		b.WriteString(value)
+		b.WriteString("\n")
+	}
+
+	b.WriteString(lipgloss.NewStyle().Foreground(brandColor).Bold(true).Render(span.Name))
+	b.WriteString("\n")
+
+	status := span.Status.Code
+	if status == "" {
+		status = "unset"
+	}
+	if span.Status.Message != "" {
+		status += " - " + span.Status.Message
+	}
+	line("Status:", spanStatusStyle(span).Render(status))
+	line("Kind:", spanKindStyle(span.Kind).Render(span.Kind))
+	if span.Service.Name != "" {
+		line("Service:", span.Service.Name)
+	}
+	line("Start:", fmt.Sprintf("+%s (%s)", FormatDurationMs(float64(span.Timestamp)-bounds.start),
+		span.StartTime().Format("15:04:05.000")))
+	line("Duration:", FormatDurationMs(span.Duration))
+	line("Span ID:", span.SpanID)
+	if span.ParentID != "" {
+		line("Parent ID:", span.ParentID)
+	}
+
+	if len(span.Attributes) > 0 {
+		b.WriteString("\n")
+		b.WriteString(sectionStyle.Render("Attributes"))
+		b.WriteString("\n")
+		keys := make([]string, 0, len(span.Attributes))
+		for k := range span.Attributes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			b.WriteString(fmt.Sprintf("  %s = %v\n", mutedStyle.Render(k), span.Attributes[k]))
+		}
+	}
+
+	if len(span.Events) > 0 {
+		b.WriteString("\n")
+		b.WriteString(sectionStyle.Render("Events"))
+		b.WriteString("\n")
+		for _, event := range span.Events {
+			offset := FormatDurationMs(float64(event.Timestamp - span.Timestamp))
+			b.WriteString(fmt.Sprintf("  %s %s\n", mutedStyle.Render("+"+offset), event.Name))
+			keys := make([]string, 0, len(event.Attributes))
+			for k := range event.Attributes {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				b.WriteString(fmt.Sprintf("      %s = %v\n", mutedStyle.Render(k), event.Attributes[k]))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// waterfallRootBounds computes the time range covered by each root's subtree
+func waterfallRootBounds(roots []*trace.SpanNode) map[*trace.SpanNode]waterfallBounds {
+	bounds := make(map[*trace.SpanNode]waterfallBounds, len(roots))
+	for _, root := range roots {
+		b := waterfallBounds{start: float64(root.Span.Timestamp), end: root.Span.End()}
+		walkSpanNodes(root, func(n *trace.SpanNode) {
+			if start := float64(n.Span.Timestamp); start < b.start {
+				b.start = start
+			}
+			if end := n.Span.End(); end > b.end {
+				b.end = end
+			}
+		})
+		bounds[root] = b
+	}
+	return bounds
+}
+
+func walkSpanNodes(node *trace.SpanNode, fn func(*trace.SpanNode)) {
+	fn(node)
+	for _, child := range node.Children {
+		walkSpanNodes(child, fn)
+	}
+}
+
+// spanStatusStyle colors a span by its status
+func spanStatusStyle(span *trace.Span) lipgloss.Style {
+	switch span.Status.Code {
+	case "error":
+		return lipgloss.NewStyle().Foreground(dangerColor)
+	case "ok":
+		return lipgloss.NewStyle().Foreground(successColor)
+	default:
+		return lipgloss.NewStyle().Foreground(brandColor)
+	}
+}
+
+// spanKindStyle colors a span by its kind
+func spanKindStyle(kind string) lipgloss.Style {
+	switch kind {
+	case "server":
+		return lipgloss.NewStyle().Foreground(brandColor).Bold(true)
+	case "client":
+		return lipgloss.NewStyle().Foreground(accentColor).Bold(true)
+	case "producer", "consumer":
+		return lipgloss.NewStyle().Foreground(highlightColor).Bold(true)
+	default:
+		return lipgloss.NewStyle().Foreground(mutedColor)
+	}
+}
+
+// spanKindBadge returns a short fixed-width label for a span kind
+func spanKindBadge(kind string) string {
+	switch kind {
+	case "server":
+		return "SRV"
+	case "client":
+		return "CLI"
+	case "producer":
+		return "PRD"
+	case "consumer":
+		return "CNS"
+	default:
+		return "INT"
+	}
+}
+
+// FormatDurationMs formats a duration given in milliseconds
+func FormatDurationMs(ms float64) string {
+	switch {
+	case ms < 1:
+		return fmt.Sprintf("%.2fms", ms)
+	case ms < 1000:
+		return fmt.Sprintf("%.0fms", ms)
+	case ms < 60000:
+		return fmt.Sprintf("%.2fs", ms/1000)
+	default:
+		return fmt.Sprintf("%.1fm", ms/60000)
+	}
+}
+
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// SpanLine renders a compact one-line summary of a span for streaming output
+func SpanLine(span *trace.Span) string {
+	icon := lipgloss.NewStyle().Foreground(successColor).Render("✓")
+	if span.IsError() {
+		icon = lipgloss.NewStyle().Foreground(dangerColor).Render("✗")
+	}
+
+	name := span.Name
+	if span.IsError() {
+		name = errorStyle.Render(name)
+	}
+
+	line := fmt.Sprintf("%s %s %-16s %s %s",
+		mutedStyle.Render(span.StartTime().Format("15:04:05.000")),
+		icon,
+		lipgloss.NewStyle().Foreground(accentColor).Render(truncate(span.Service.Name, 16)),
+		name,
+		spanStatusStyle(span).Render(FormatDurationMs(span.Duration)),
+	)
+
+	for _, key := range []string{"http.status_code", "http.response.status_code"} {
+		if code, ok := span.Attributes[key]; ok {
+			line += " " + mutedStyle.Render(fmt.Sprintf("%v", code))
+			break
+		}
+	}
+
+	if span.IsError() && span.Status.Message != "" {
+		line += " " + errorStyle.Render(span.Status.Message)
+	}
+
+	return line
+}
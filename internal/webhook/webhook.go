@@ -0,0 +1,138 @@
+// Package webhook holds the catalog of webhook event types and reads webhook
+// definitions from JSON or YAML files, for 'tracekit webhook create' and the
+// webhook step of 'tracekit init'.
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Event is a type of event a webhook can subscribe to
+type Event struct {
+	Name        string
+	Description string
+}
+
+// Events is the catalog of event types, in the order they are offered
+var Events = []Event{
+	{"health_check.failed", "A health check failed or missed its heartbeats"},
+	{"health_check.recovered", "A failing health check passed again"},
+	{"alert.triggered", "An alert rule fired"},
+	{"alert.resolved", "A triggered alert cleared"},
+	{"trace.error", "A trace recorded an error"},
+	{"anomaly.detected", "Latency or error rate left its usual range"},
+}
+
+// EventNames returns the names of the catalog's event types
+func EventNames() []string {
+	names := make([]string, len(Events))
+	for i, e := range Events {
+		names[i] = e.Name
+	}
+	return names
+}
+
+// Spec is a webhook definition. JSON files decode too, as YAML is a
+// superset of JSON.
+type Spec struct {
+	Name        string   `yaml:"name"`
+	URL         string   `yaml:"url"`
+	Description string   `yaml:"description"`
+	Events      []string `yaml:"events"`
+}
+
+// Load reads a webhook definition from path, or from stdin when path is "-"
+func Load(path string) (*Spec, error) {
+	var content []byte
+	var err error
+	if path == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	spec := &Spec{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(spec); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("invalid webhook definition in %s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// ValidateURL checks that url can receive webhooks. HTTPS is required
+// except for localhost, unless allowHTTP is set (development API).
+func ValidateURL(url string, allowHTTP bool) error {
+	if url == "" {
+		return fmt.Errorf("webhook URL is required")
+	}
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return fmt.Errorf("webhook URL must start with https:// or http://")
+	}
+	if !allowHTTP && !strings.HasPrefix(url, "https://") && !strings.Contains(url, "localhost") && !strings.Contains(url, "127.0.0.1") {
+		return fmt.Errorf("webhook URL must use HTTPS for non-localhost URLs (got: %s)", url)
+	}
+	return nil
+}
+
+// ValidateEvents checks events against the catalog
+func ValidateEvents(events []string) error {
+	if len(events) == 0 {
+		return fmt.Errorf("at least one event must be selected")
+	}
+	for _, name := range events {
+		if !known(name) {
+			return fmt.Errorf("unknown event %q (available: %s)", name, strings.Join(EventNames(), ", "))
+		}
+	}
+	return nil
+}
+
+// ParseSelection turns a comma-separated list of catalog numbers (starting
+// at 1) or event names into event names, without duplicates
+func ParseSelection(input string) ([]string, error) {
+	var events []string
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(input, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name := item
+		if n, err := strconv.Atoi(item); err == nil {
+			if n < 1 || n > len(Events) {
+				return nil, fmt.Errorf("no event numbered %d (choose 1-%d)", n, len(Events))
+			}
+			name = Events[n-1].Name
+		} else if !known(name) {
+			return nil, fmt.Errorf("unknown event %q (available: %s)", name, strings.Join(EventNames(), ", "))
+		}
+		if !seen[name] {
+			seen[name] = true
+			events = append(events, name)
+		}
+	}
+	if len(events) == 0 {
+		return nil, fmt.Errorf("at least one event must be selected")
+	}
+	return events, nil
+}
+
+func known(name string) bool {
+	for _, e := range Events {
+		if e.Name == name {
+			return true
+		}
+	}
+	return false
+}